@@ -11,10 +11,15 @@ import (
 
 	"github.com/chrisS41/gobike-server/internal/config"
 	"github.com/chrisS41/gobike-server/internal/database"
+	"github.com/chrisS41/gobike-server/internal/elevation"
 	"github.com/chrisS41/gobike-server/internal/errors"
 	"github.com/chrisS41/gobike-server/internal/handlers"
 	"github.com/chrisS41/gobike-server/internal/logger"
+	"github.com/chrisS41/gobike-server/internal/middleware"
 	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/chrisS41/gobike-server/internal/routing"
+	"github.com/chrisS41/gobike-server/internal/track"
+	"github.com/chrisS41/gobike-server/internal/trackstore"
 	"github.com/chrisS41/gobike-server/internal/version"
 	"github.com/gin-gonic/gin"
 )
@@ -53,18 +58,82 @@ func run() error {
 	defer db.Close()
 	log.Info("Database connection established")
 
+	// DEM 고도 타일 로드 (실패하면 기기가 기록한 고도를 그대로 사용)
+	dem, err := elevation.New(cfg.DEMDir)
+	if err != nil {
+		log.Error("Failed to load elevation tiles: %v", err)
+	} else if dem != nil {
+		log.Info("Elevation tiles loaded: %d from %s", dem.Tiles(), dem.Dir())
+	}
+
+	// 경로 탐색용 도로망 로드 (DEM 고도를 경사 비용에 사용하므로 DEM 다음에 로드)
+	var roads *routing.Graph
+	if cfg.OSMFile != "" {
+		started := time.Now()
+		roads, err = routing.Load(cfg.OSMFile, dem)
+		if err != nil {
+			log.Error("Failed to load road network: %v", err)
+		} else {
+			log.Info("Road network loaded: %d nodes, %d edges from %s (%s)",
+				roads.Nodes(), roads.Edges(), cfg.OSMFile, time.Since(started).Round(time.Millisecond))
+		}
+	}
+
+	// 라이드 트랙 정제 설정 (설정이 잘못되면 정제하지 않고 입력 트랙을 그대로 저장)
+	var cleaner *track.Cleaner
+	if cfg.TrackCleaning {
+		cleaner, err = track.New(track.Options{
+			MaxSpeed:          cfg.TrackMaxSpeed / 3.6,
+			MaxAccuracy:       cfg.TrackMaxAccuracy,
+			Smoothing:         cfg.TrackSmoothing,
+			ProcessNoise:      track.DefaultOptions().ProcessNoise,
+			DefaultAccuracy:   track.DefaultOptions().DefaultAccuracy,
+			PauseRadius:       cfg.TrackPauseRadius,
+			PauseDuration:     cfg.TrackPauseDuration,
+			Simplify:          cfg.TrackSimplify,
+			SimplifyTolerance: cfg.TrackSimplifyTolerance,
+		})
+		if err != nil {
+			log.Error("Invalid track cleaning options: %v", err)
+		} else {
+			log.Info("Track cleaning enabled (simplify: %s)", cfg.TrackSimplify)
+		}
+	}
+
+	// 라이드 문서에 남아 있는 트랙을 트랙 묶음 컬렉션으로 옮김 (옮기기 전 라이드는 조회할 때 옮김)
+	tracks := trackstore.New(db.Tracks, db.Rides)
+	go func() {
+		started := time.Now()
+		n, err := tracks.Migrate()
+		if err != nil {
+			log.Error("Failed to migrate ride tracks: %v", err)
+		}
+		if n > 0 {
+			log.Info("Ride tracks migrated: %d rides (%s)", n, time.Since(started).Round(time.Millisecond))
+		}
+	}()
+
+	// 난이도가 자유 입력이던 때의 경로 난이도를 정의된 값으로 변환
+	if n, err := handlers.MigrateRouteDifficulty(db.Routes); err != nil {
+		log.Error("Failed to migrate route difficulty: %v", err)
+	} else if n > 0 {
+		log.Info("Route difficulty migrated: %d routes", n)
+	}
+
 	// 핸들러 초기화
-	handlers := initializeHandlers(db, log)
+	handlers := initializeHandlers(db, tracks, dem, roads, cleaner, log)
 
 	// Gin 설정
 	gin.SetMode(cfg.GinMode) //debug, test, release
-	router := setupRouter(handlers, log)
+	router := setupRouter(handlers, db.Users, cfg, log)
 
 	// 그레이스풀 셧다운을 위한 설정
 	srv := &http.Server{
 		Addr:    ":" + cfg.ServerPort,
 		Handler: router,
 	}
+	// 실시간 세션의 SSE 구독과 WebSocket 연결은 Shutdown이 기다리지 않도록 먼저 종료
+	srv.RegisterOnShutdown(handlers.Live.Shutdown)
 
 	// 서버 시작을 비동기로 실행
 	go func() {
@@ -91,29 +160,42 @@ func run() error {
 	return nil
 }
 
-func initializeHandlers(db *database.MongoDB, log *logger.Log) *handlers.Handlers {
+func initializeHandlers(db *database.MongoDB, tracks *trackstore.Store, dem *elevation.Service, roads *routing.Graph, cleaner *track.Cleaner, log *logger.Log) *handlers.Handlers {
 	h := &handlers.Handlers{
-		Users:  handlers.NewUserHandler(db.Users, log),
-		Routes: handlers.NewRouteHandler(db.Routes, log),
-		Rides:  handlers.NewRideHandler(db.Rides, log),
+		Users:    handlers.NewUserHandler(db.Users, db.Tokens, log),
+		Routes:   handlers.NewRouteHandler(db.Routes, db.Rides, db.Users, dem, roads, log),
+		Rides:    handlers.NewRideHandler(db.Rides, db.Routes, db.Users, db.Records, db.Segments, db.Efforts, tracks, dem, cleaner, log),
+		Admin:    handlers.NewAdminHandler(db.Users, db.Tokens, log),
+		Live:     handlers.NewLiveHandler(db.Live, db.LiveChunks, db.Rides, db.Routes, db.Users, db.Records, db.Segments, db.Efforts, tracks, dem, cleaner, log),
+		Records:  handlers.NewRecordHandler(db.Records, log),
+		Segments: handlers.NewSegmentHandler(db.Segments, db.Efforts, db.Rides, db.Routes, db.Users, tracks, log),
 	}
 	log.Info("All handlers initialized")
 	return h
 }
 
-func setupRouter(h *handlers.Handlers, log *logger.Log) *gin.Engine {
+func setupRouter(h *handlers.Handlers, users *database.Collection, cfg *config.Config, log *logger.Log) *gin.Engine {
 	r := gin.New()
 
 	// 미들웨어 설정
 	r.Use(gin.Recovery())
-	r.Use(gin.Logger())
+	r.Use(middleware.AccessLog())
 
 	// API 라우트 설정
-	api := r.Group("/api")
+	// public: 인증 없이 접근 가능
+	// authed: JWT 인증 필요 (비밀번호 재설정이 강제된 사용자도 접근 가능)
+	// protected: JWT 인증 필요, 비밀번호 재설정 대기 중인 사용자 차단
+	public := r.Group("/api")
+	authed := r.Group("/api", middleware.Auth(cfg.JWTSecret, users))
+	protected := authed.Group("", middleware.BlockPendingPasswordReset())
 	{
-		setupUserRoutes(api, h.Users)
-		setupRouteRoutes(api, h.Routes)
-		setupRideRoutes(api, h.Rides)
+		setupUserRoutes(public, authed, protected, h.Users)
+		setupRouteRoutes(protected, h.Routes)
+		setupRideRoutes(protected, h.Rides)
+		setupAdminRoutes(protected, h.Admin)
+		setupLiveRoutes(protected, h.Live)
+		setupRecordRoutes(protected, h.Records)
+		setupSegmentRoutes(protected, h.Segments)
 	}
 
 	// 허용되지 않은 HTTP 메서드 처리
@@ -149,12 +231,26 @@ func setupRouter(h *handlers.Handlers, log *logger.Log) *gin.Engine {
 }
 
 // 라우트 설정 함수들
-func setupUserRoutes(api *gin.RouterGroup, h *handlers.UserHandler) {
-	users := api.Group("/users")
+func setupUserRoutes(public, authed, protected *gin.RouterGroup, h *handlers.UserHandler) {
+	auth := public.Group("/users")
+	{
+		// 인증 관련 엔드포인트
+		auth.POST("/register", h.Register)
+		auth.POST("/login", h.Login)
+		auth.POST("/token/refresh", h.RefreshToken)
+	}
+
+	session := authed.Group("/users")
+	{
+		// 세션/비밀번호 관련 엔드포인트
+		session.POST("/logout", h.Logout)
+		session.POST("/logout/all", h.LogoutAll)
+		session.PUT("/password", h.ChangePassword)
+	}
+
+	users := protected.Group("/users")
 	{
 		// User 관련 엔드포인트
-		users.POST("/register", h.Register)
-		users.POST("/login", h.Login)
 		users.GET("/get/:id", h.GetUser)
 		users.PUT("/update/:id", h.UpdateUser)
 
@@ -168,10 +264,20 @@ func setupRouteRoutes(api *gin.RouterGroup, h *handlers.RouteHandler) {
 	routes := api.Group("/routes")
 	{
 		routes.POST("/create", h.CreateRoute)
+		routes.POST("/plan", h.PlanRoute)
+		routes.POST("/loops", h.PlanLoops)
 		routes.GET("/get/:id", h.GetRoute)
 		routes.PUT("/update/:id", h.UpdateRoute)
 		routes.DELETE("/delete/:id", h.DeleteRoute)
 		routes.GET("/list/user/:userId", h.GetUserRoutes)
+		routes.GET("/search", h.SearchRoutes)
+		routes.GET("/nearby", h.NearbyRoutes)
+		routes.GET("/within", h.RoutesWithin)
+		routes.GET("/through", h.RoutesThrough)
+		routes.GET("/:id/export", h.ExportRoute)
+		routes.GET("/:id/elevation", h.GetRouteElevation)
+		routes.GET("/:id/cues", h.GetRouteCues)
+		routes.POST("/:id/deviation", h.CheckDeviation)
 	}
 }
 
@@ -184,5 +290,56 @@ func setupRideRoutes(api *gin.RouterGroup, h *handlers.RideHandler) {
 		rides.DELETE("/delete/:id", h.DeleteRide)
 		rides.GET("/list/user/:userId", h.GetUserRides)
 		rides.GET("/stats/:id", h.GetRideStats)
+		rides.POST("/import/gpx", h.ImportGPX)
+		rides.POST("/import/fit", h.ImportFIT)
+		rides.POST("/import/tcx", h.ImportTCX)
+		rides.GET("/:id/export", h.ExportRide)
+		rides.GET("/:id/track", h.GetRideTrack)
+	}
+}
+
+func setupAdminRoutes(api *gin.RouterGroup, h *handlers.AdminHandler) {
+	// moderator 이상만 접근 가능 (admin은 모든 역할 검사 통과)
+	admin := api.Group("/admin", middleware.RequireRole(models.RoleModerator))
+	{
+		admin.GET("/users", h.ListUsers)
+		admin.PUT("/users/:id/status", h.UpdateUserStatus)
+		admin.POST("/users/:id/password-reset", h.ForcePasswordReset)
+		admin.PUT("/users/:id/role", middleware.RequireRole(models.RoleAdmin), h.UpdateUserRole)
+	}
+}
+
+func setupLiveRoutes(api *gin.RouterGroup, h *handlers.LiveHandler) {
+	live := api.Group("/live")
+	{
+		live.POST("/start", h.StartSession)
+		live.GET("/active", h.GetActiveSession)
+		live.GET("/:id", h.GetSession)
+		live.DELETE("/:id", h.DiscardSession)
+		live.POST("/:id/points", h.UploadBatch)
+		live.GET("/:id/ws", h.UploadSocket)
+		live.GET("/:id/stream", h.StreamSession)
+		live.POST("/:id/finish", h.FinishSession)
+	}
+}
+
+func setupRecordRoutes(api *gin.RouterGroup, h *handlers.RecordHandler) {
+	records := api.Group("/records")
+	{
+		records.GET("", h.ListRecords)
+		records.GET("/:key", h.GetRecord)
+	}
+}
+
+func setupSegmentRoutes(api *gin.RouterGroup, h *handlers.SegmentHandler) {
+	segments := api.Group("/segments")
+	{
+		segments.POST("", h.CreateSegment)
+		segments.GET("", h.ListSegments)
+		segments.GET("/:id", h.GetSegment)
+		segments.PUT("/:id", h.UpdateSegment)
+		segments.DELETE("/:id", h.DeleteSegment)
+		segments.GET("/:id/efforts", h.GetSegmentEfforts)
+		segments.GET("/:id/leaderboard", h.GetLeaderboard)
 	}
 }
@@ -0,0 +1,190 @@
+// analysis 패키지는 GPS 트랙으로부터 주행 지표를 계산합니다.
+package analysis
+
+import (
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+const (
+	// 이 속도(m/s) 미만인 구간은 정지로 간주하여 이동 시간에서 제외
+	MovingSpeedThreshold = 1.0
+	// 두 지점 사이 간격이 이보다 길면 기록 중단(일시정지)으로 간주
+	MaxMovingGap = 30 * time.Second
+	// 최고 속도 계산 시 사용하는 최소 구간 길이 (GPS 튐 완화)
+	MaxSpeedWindow = 5 * time.Second
+	// 고도 변화가 이 값(미터)을 넘을 때만 상승/하강으로 누적 (노이즈 완화)
+	ElevationThreshold = 3.0
+)
+
+// Metrics 트랙에서 계산한 주행 지표
+// 거리는 킬로미터, 속도는 km/h, 고도는 미터 단위
+type Metrics struct {
+	Distance      float64
+	TotalTime     time.Duration
+	MovingTime    time.Duration
+	AvgSpeed      float64 // 이동 시간 기준 평균 속도
+	MaxSpeed      float64
+	ElevationGain float64
+	ElevationLoss float64
+	HasTimestamps bool
+	HasElevation  bool
+}
+
+// Analyze 트랙 지점으로부터 주행 지표 계산
+// 시간 관련 지표는 모든 지점에 Timestamp가 있을 때만 계산됨
+func Analyze(points []models.GeoPoint) Metrics {
+	return analyze(points, nil)
+}
+
+// 자동 일시정지 구간(pauses) 안의 시간은 이동 시간에서 제외
+func analyze(points []models.GeoPoint, pauses []models.Pause) Metrics {
+	var m Metrics
+	if len(points) == 0 {
+		return m
+	}
+
+	m.Distance = TrackDistance(points) / 1000
+	m.HasTimestamps = hasTimestamps(points)
+	m.HasElevation = hasElevation(points)
+
+	if m.HasTimestamps {
+		m.TotalTime = points[len(points)-1].Timestamp.Sub(*points[0].Timestamp)
+		m.MovingTime = movingTime(points, pauses)
+		if hours := m.MovingTime.Hours(); hours > 0 {
+			m.AvgSpeed = m.Distance / hours
+		}
+		m.MaxSpeed = maxSpeed(points)
+	}
+
+	if m.HasElevation {
+		m.ElevationGain, m.ElevationLoss = ElevationChange(points)
+	}
+
+	return m
+}
+
+// ElevationChange 누적 상승/하강 고도 (미터)
+// 고도를 평활화(SmoothElevation)한 뒤 ElevationThreshold 이하의 변동은 무시
+// 고도가 없는 지점은 건너뜀
+func ElevationChange(points []models.GeoPoint) (gain, loss float64) {
+	points = SmoothElevation(points)
+
+	var ref float64
+	found := false
+	for _, p := range points {
+		if p.Elevation == nil {
+			continue
+		}
+		ele := *p.Elevation
+		if !found {
+			ref = ele
+			found = true
+			continue
+		}
+		diff := ele - ref
+		if diff > ElevationThreshold {
+			gain += diff
+			ref = ele
+		} else if diff < -ElevationThreshold {
+			loss -= diff
+			ref = ele
+		}
+	}
+	return gain, loss
+}
+
+// 이동 중인 구간의 시간 합계 (일시정지 구간 안은 제외)
+func movingTime(points []models.GeoPoint, pauses []models.Pause) time.Duration {
+	var total time.Duration
+	paused := 0 // 지점 i가 속할 수 있는 첫 일시정지 구간
+	for i := 1; i < len(points); i++ {
+		for paused < len(pauses) && pauses[paused].EndIndex < i {
+			paused++
+		}
+		if paused < len(pauses) && pauses[paused].StartIndex <= i-1 {
+			continue
+		}
+		dt := points[i].Timestamp.Sub(*points[i-1].Timestamp)
+		if dt <= 0 || dt > MaxMovingGap {
+			continue
+		}
+		if Haversine(points[i-1], points[i])/dt.Seconds() >= MovingSpeedThreshold {
+			total += dt
+		}
+	}
+	return total
+}
+
+// MaxSpeedWindow 이상의 구간에서 측정한 최고 속도 (km/h)
+func maxSpeed(points []models.GeoPoint) float64 {
+	cum := CumulativeDistances(points)
+	var best float64
+	start := 0
+	for end := 1; end < len(points); end++ {
+		// 구간이 최소 길이를 만족하는 한 시작점을 앞으로 당김
+		for start+1 < end && points[end].Timestamp.Sub(*points[start+1].Timestamp) >= MaxSpeedWindow {
+			start++
+		}
+		dt := points[end].Timestamp.Sub(*points[start].Timestamp)
+		if dt < MaxSpeedWindow || dt > MaxSpeedWindow+MaxMovingGap {
+			continue
+		}
+		if speed := (cum[end] - cum[start]) / dt.Seconds() * 3.6; speed > best {
+			best = speed
+		}
+	}
+	return best
+}
+
+func hasTimestamps(points []models.GeoPoint) bool {
+	for _, p := range points {
+		if p.Timestamp == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func hasElevation(points []models.GeoPoint) bool {
+	for _, p := range points {
+		if p.Elevation != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyToRide 트랙으로부터 계산한 지표로 라이드의 통계 필드와 종목별 최고 기록을 갱신
+// 라이드의 자동 일시정지 구간(Pauses)은 이동 시간에서 제외
+// 센서 스트림이 있으면 센서 요약(Sensors)도 계산
+// 트랙에 시각 정보가 없으면 StartTime/EndTime 기준으로 시간과 평균 속도를 계산하고
+// 최고 속도는 검증할 수 없으므로 입력값 대신 평균 속도로 둠
+func ApplyToRide(ride *models.Ride) Metrics {
+	m := analyze(ride.Locations, ride.Pauses)
+
+	ride.Distance = m.Distance
+	ride.ElevationGain = m.ElevationGain
+	ride.ElevationLoss = m.ElevationLoss
+	ride.BestEfforts = BestEfforts(ride.Locations)
+	ride.Climbs = AnalyzeProfile(ride.Locations).Climbs
+	ride.Sensors = SensorSummary(ride.Locations, ride.Streams, ride.Pauses, ride.Thresholds)
+
+	if m.HasTimestamps {
+		ride.Duration = m.TotalTime
+		ride.MovingTime = m.MovingTime
+		ride.AvgSpeed = m.AvgSpeed
+		ride.MaxSpeed = m.MaxSpeed
+		return m
+	}
+
+	ride.Duration = ride.EndTime.Sub(ride.StartTime)
+	ride.MovingTime = ride.Duration
+	ride.AvgSpeed = 0
+	if hours := ride.Duration.Hours(); hours > 0 {
+		ride.AvgSpeed = ride.Distance / hours
+	}
+	ride.MaxSpeed = ride.AvgSpeed
+	return m
+}
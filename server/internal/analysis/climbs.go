@@ -0,0 +1,240 @@
+package analysis
+
+import (
+	"math"
+
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+const (
+	// GradeWindow 경사도를 잴 때 사용하는 최소 구간 길이 (미터), 짧은 구간의 고도 잡음을 줄임
+	GradeWindow = 100.0
+
+	// 오르막 도중 이만큼 내려가면 오르막이 끝난 것으로 봄 (미터, 오른 높이의 climbDropRatio와 큰 값)
+	climbDropTolerance = 10.0
+	climbDropRatio     = 0.2
+	// 오르막 시작점으로 보는 최저점과의 고도 차 (미터)
+	climbBaseTolerance = 3.0
+	// 오르막으로 인정하는 최소 거리 (미터)와 평균 경사도 (%)
+	minClimbDistance = 300.0
+	minClimbGrade    = 3.0
+)
+
+// 오르막 등급 기준 점수 (거리(m) × 평균 경사도(%)), 높은 등급부터
+var climbCategories = []struct {
+	Category string
+	Score    float64
+}{
+	{models.ClimbCategoryHC, 80000},
+	{models.ClimbCategory1, 64000},
+	{models.ClimbCategory2, 32000},
+	{models.ClimbCategory3, 16000},
+	{models.ClimbCategory4, 8000},
+}
+
+// Profile 트랙의 경사 특성
+type Profile struct {
+	AvgGrade float64        // 전체 거리 대비 상승 고도 (%)
+	MaxGrade float64        // GradeWindow 이상 구간 기준 최대 경사도 (%)
+	Climbs   []models.Climb // 등급이 매겨진 오르막 (트랙 순서)
+}
+
+// AnalyzeProfile 트랙의 경사도와 오르막 구간 계산 (평활화한 고도 기준)
+// 고도가 없는 지점은 건너뛰며, 고도 정보가 없으면 빈 Profile 반환
+func AnalyzeProfile(points []models.GeoPoint) Profile {
+	var p Profile
+	raw := points
+	points = SmoothElevation(points)
+	cum := CumulativeDistances(points)
+
+	// 고도가 있는 지점의 원래 인덱스
+	var idx []int
+	for i, pt := range points {
+		if pt.Elevation != nil {
+			idx = append(idx, i)
+		}
+	}
+	if len(idx) < 2 {
+		return p
+	}
+
+	if total := cum[len(cum)-1]; total > 0 {
+		gain, _ := ElevationChange(raw)
+		p.AvgGrade = round1(gain / total * 100)
+	}
+	p.MaxGrade = round1(maxGrade(points, cum, idx))
+	p.Climbs = findClimbs(points, cum, idx)
+	return p
+}
+
+// 고도가 있는 지점(idx) 사이에서 오르막 찾기
+// 최저점에서 시작해 최고점을 갱신하며 나아가다, 최고점에서 허용치 이상 내려가면 오르막을 끝냄
+func findClimbs(points []models.GeoPoint, cum []float64, idx []int) []models.Climb {
+	ele := func(k int) float64 { return *points[idx[k]].Elevation }
+
+	var climbs []models.Climb
+	emit := func(start, peak int) {
+		// 오르막 앞의 평지는 제외: 시작 고도와 climbBaseTolerance 이내인 마지막 지점부터 오르막으로 봄
+		for k := peak; k > start; k-- {
+			if ele(k) <= ele(start)+climbBaseTolerance {
+				start = k
+				break
+			}
+		}
+		if c, ok := rateClimb(points, cum, idx[start], idx[peak]); ok {
+			climbs = append(climbs, c)
+		}
+	}
+
+	start, peak := 0, 0
+	for k := 1; k < len(idx); k++ {
+		switch {
+		case ele(peak)-ele(k) > math.Max(climbDropTolerance, climbDropRatio*(ele(peak)-ele(start))):
+			emit(start, peak)
+			start, peak = k, k
+		case ele(k) <= ele(start):
+			// 평지나 내리막에서는 시작점을 오르막 바로 앞으로 옮김
+			start, peak = k, k
+		case ele(k) >= ele(peak):
+			peak = k
+		}
+	}
+	emit(start, peak)
+	return climbs
+}
+
+// 트랙의 start~end 구간을 오르막으로 평가하고 등급을 매김
+// 거리, 경사도, 점수가 Cat 4 기준에 못 미치면 false
+func rateClimb(points []models.GeoPoint, cum []float64, start, end int) (models.Climb, bool) {
+	distance := cum[end] - cum[start]
+	if end <= start || distance < minClimbDistance {
+		return models.Climb{}, false
+	}
+	gain := *points[end].Elevation - *points[start].Elevation
+	grade := gain / distance * 100
+	if grade < minClimbGrade {
+		return models.Climb{}, false
+	}
+
+	score := distance * grade
+	for _, c := range climbCategories {
+		if score < c.Score {
+			continue
+		}
+
+		var idx []int
+		for i := start; i <= end; i++ {
+			if points[i].Elevation != nil {
+				idx = append(idx, i)
+			}
+		}
+		return models.Climb{
+			StartIndex:    start,
+			EndIndex:      end,
+			StartDistance: math.Round(cum[start]) / 1000,
+			Distance:      math.Round(distance) / 1000,
+			ElevationGain: round1(gain),
+			AvgGrade:      round1(grade),
+			MaxGrade:      round1(maxGrade(points, cum, idx)),
+			Category:      c.Category,
+		}, true
+	}
+	return models.Climb{}, false
+}
+
+// 고도가 있는 지점(idx) 사이에서 GradeWindow 이상 떨어진 두 지점의 최대 경사도 (%)
+// 구간 전체가 GradeWindow보다 짧으면 전체 구간의 경사도
+func maxGrade(points []models.GeoPoint, cum []float64, idx []int) float64 {
+	if len(idx) < 2 {
+		return 0
+	}
+	ele := func(k int) float64 { return *points[idx[k]].Elevation }
+
+	first, last := idx[0], idx[len(idx)-1]
+	if span := cum[last] - cum[first]; span < GradeWindow {
+		if span <= 0 {
+			return 0
+		}
+		return math.Max(0, (ele(len(idx)-1)-ele(0))/span*100)
+	}
+
+	var best float64
+	start := 0
+	for end := 1; end < len(idx); end++ {
+		// 구간이 최소 길이를 만족하는 한 시작점을 앞으로 당김
+		for start+1 < end && cum[idx[end]]-cum[idx[start+1]] >= GradeWindow {
+			start++
+		}
+		span := cum[idx[end]] - cum[idx[start]]
+		if span < GradeWindow {
+			continue
+		}
+		if g := (ele(end) - ele(start)) / span * 100; g > best {
+			best = g
+		}
+	}
+	return best
+}
+
+// RateDifficulty 거리(km), 상승 고도(m), 경사 특성으로 경로 난이도 결정
+// 기본 점수는 상승 고도 100m를 평지 10km로 환산한 거리이며,
+// 가파른 경사, 길게 이어지는 오르막, 높은 등급의 오르막이 있으면 난이도를 올림
+func RateDifficulty(distance, elevationGain float64, profile Profile) string {
+	score := distance + elevationGain/10
+
+	level := 0
+	switch {
+	case score >= 130:
+		level = 3
+	case score >= 70:
+		level = 2
+	case score >= 30:
+		level = 1
+	}
+
+	atLeast := func(l int) {
+		if level < l {
+			level = l
+		}
+	}
+	switch {
+	case profile.MaxGrade >= 18:
+		atLeast(2)
+	case profile.MaxGrade >= 12:
+		atLeast(1)
+	}
+	for _, c := range profile.Climbs {
+		// 길게 이어지는 오르막
+		switch {
+		case c.Distance >= 10:
+			atLeast(2)
+		case c.Distance >= 5:
+			atLeast(1)
+		}
+
+		switch c.Category {
+		case models.ClimbCategoryHC:
+			atLeast(3)
+		case models.ClimbCategory1, models.ClimbCategory2:
+			atLeast(2)
+		case models.ClimbCategory3:
+			atLeast(1)
+		}
+	}
+
+	return models.Difficulties[level]
+}
+
+// ApplyToRoute 트랙으로부터 경로의 경사 특성과 난이도를 계산
+// 거리와 상승 고도는 미리 채워져 있어야 함 (gpx.FillRoute)
+func ApplyToRoute(route *models.Route, points []models.GeoPoint) {
+	profile := AnalyzeProfile(points)
+	route.AvgGrade = profile.AvgGrade
+	route.MaxGrade = profile.MaxGrade
+	route.Climbs = profile.Climbs
+	route.Difficulty = RateDifficulty(route.Distance, route.ElevationGain, profile)
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,271 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+const (
+	// 회전 각도를 잴 때 지점 앞뒤로 보는 거리 (미터), 짧은 굴곡과 GPS 잡음을 무시
+	cueHeadingWindow = 25.0
+	// 이 각도 이상 진행 방향이 바뀌면 회전으로 안내 (도)
+	cueTurnAngle = 30.0
+	// 회전 방향 구분 기준 (도): 이하 약간, 이상 급회전, 이상 유턴
+	cueSlightAngle = 60.0
+	cueSharpAngle  = 135.0
+	cueUTurnAngle  = 165.0
+	// 이 거리 안에서 이어지는 회전 후보는 가장 크게 꺾이는 지점 하나로 합침 (미터)
+	cueMergeDistance = 40.0
+	// 이보다 짧게 나타나는 도로 이름은 도로 매칭 오차로 보고 앞 도로 이름을 이어 씀 (미터)
+	cueMinStreetLength = 50.0
+	// 트랙에서 이 거리 안에 있는 관심 지점만 안내 (미터)
+	cuePOIDistance = 100.0
+)
+
+// 회전 방향별 안내 문구
+var cueDirectionNotes = map[string]string{
+	models.CueDirectionStraight:    "직진",
+	models.CueDirectionSlightLeft:  "왼쪽 방향",
+	models.CueDirectionLeft:        "좌회전",
+	models.CueDirectionSharpLeft:   "급좌회전",
+	models.CueDirectionSlightRight: "오른쪽 방향",
+	models.CueDirectionRight:       "우회전",
+	models.CueDirectionSharpRight:  "급우회전",
+	models.CueDirectionUTurn:       "유턴",
+}
+
+// 같은 지점의 안내 순서
+var cueOrder = map[string]int{
+	models.CueTypeStart:    0,
+	models.CueTypeSummit:   1,
+	models.CueTypeTurn:     2,
+	models.CueTypeContinue: 2,
+	models.CueTypePOI:      3,
+	models.CueTypeClimb:    4,
+	models.CueTypeFinish:   5,
+}
+
+// POI 큐시트에 넣을 관심 지점
+type POI struct {
+	Point models.GeoPoint
+	Name  string
+	Note  string // 설명 (선택)
+}
+
+// CueSheet 트랙의 회전 안내, 오르막, 관심 지점을 거리 순으로 정리한 큐시트
+// streets는 구간(지점 i에서 i+1)별 도로 이름으로 길이가 len(points)-1이어야 하며, 모르면 nil
+// climbs의 인덱스는 points 기준 (AnalyzeProfile 결과)
+func CueSheet(points []models.GeoPoint, streets []string, climbs []models.Climb, pois []POI) []models.Cue {
+	if len(points) < 2 {
+		return nil
+	}
+	cum := CumulativeDistances(points)
+	if len(streets) != len(points)-1 {
+		streets = make([]string, len(points)-1)
+	}
+	streets = smoothStreets(streets, cum)
+
+	cues := []models.Cue{newCue(points, cum, 0, models.CueTypeStart)}
+	cues[0].Street = streets[0]
+	cues = append(cues, turnCues(points, cum, streets)...)
+
+	for _, c := range climbs {
+		if c.StartIndex < 0 || c.EndIndex >= len(points) || c.StartIndex >= c.EndIndex {
+			continue
+		}
+		climb := newCue(points, cum, c.StartIndex, models.CueTypeClimb)
+		climb.Category = c.Category
+		climb.Note = fmt.Sprintf("오르막 시작 (Cat %s, %.1fkm, 평균 %.1f%%)", c.Category, c.Distance, c.AvgGrade)
+		summit := newCue(points, cum, c.EndIndex, models.CueTypeSummit)
+		summit.Category = c.Category
+		summit.Note = fmt.Sprintf("정상 (+%.0fm)", c.ElevationGain)
+		cues = append(cues, climb, summit)
+	}
+
+	for _, poi := range pois {
+		i, ok := nearestPoint(points, poi.Point, cuePOIDistance)
+		if !ok {
+			continue
+		}
+		cue := newCue(points, cum, i, models.CueTypePOI)
+		cue.Name = poi.Name
+		cue.Note = poi.Name
+		if poi.Note != "" {
+			cue.Note += " - " + poi.Note
+		}
+		cues = append(cues, cue)
+	}
+
+	cues = append(cues, newCue(points, cum, len(points)-1, models.CueTypeFinish))
+
+	sort.SliceStable(cues, func(i, j int) bool {
+		if cues[i].Index != cues[j].Index {
+			return cues[i].Index < cues[j].Index
+		}
+		return cueOrder[cues[i].Type] < cueOrder[cues[j].Type]
+	})
+	for i := range cues {
+		if i+1 < len(cues) {
+			cues[i].Next = math.Round(cum[cues[i+1].Index]-cum[cues[i].Index]) / 1000
+		}
+		if cues[i].Note == "" {
+			cues[i].Note = cueNote(cues[i])
+		}
+	}
+	return cues
+}
+
+func newCue(points []models.GeoPoint, cum []float64, i int, cueType string) models.Cue {
+	return models.Cue{
+		Type:      cueType,
+		Index:     i,
+		Distance:  math.Round(cum[i]) / 1000,
+		Latitude:  points[i].Latitude,
+		Longitude: points[i].Longitude,
+		Elevation: points[i].Elevation,
+	}
+}
+
+// 회전과 도로 변경 안내
+// 지점마다 앞뒤 cueHeadingWindow 거리의 진행 방향 차이를 재고, cueMergeDistance 간격 안에서
+// 이어지는 후보(회전 또는 도로 이름 변경)는 하나로 합침
+func turnCues(points []models.GeoPoint, cum []float64, streets []string) []models.Cue {
+	angles := make([]float64, len(points))
+	back, ahead := 0, 0
+	for i := 1; i < len(points)-1; i++ {
+		for back+1 < i && cum[back+1] <= cum[i]-cueHeadingWindow {
+			back++
+		}
+		if ahead < i {
+			ahead = i
+		}
+		for ahead < len(points)-1 && cum[ahead] < cum[i]+cueHeadingWindow {
+			ahead++
+		}
+		if cum[i]-cum[back] == 0 || cum[ahead]-cum[i] == 0 {
+			continue
+		}
+		angles[i] = headingChange(Bearing(points[back], points[i]), Bearing(points[i], points[ahead]))
+	}
+
+	var cues []models.Cue
+	current := streets[0]
+	for i := 1; i < len(points)-1; {
+		if math.Abs(angles[i]) < cueTurnAngle && streets[i] == streets[i-1] {
+			i++
+			continue
+		}
+
+		// 후보 묶음(앞 후보와 cueMergeDistance 안에서 이어지는 후보)에서 가장 크게 꺾이는 지점
+		best, end := i, i
+		for k := i; k < len(points)-1 && cum[k]-cum[end] <= cueMergeDistance; k++ {
+			if math.Abs(angles[k]) >= cueTurnAngle || streets[k] != streets[k-1] {
+				end = k
+				if math.Abs(angles[k]) > math.Abs(angles[best]) {
+					best = k
+				}
+			}
+		}
+		street := streets[end]
+		i = end + 1
+
+		angle := angles[best]
+		direction := turnDirection(angle)
+		if direction == models.CueDirectionStraight && (street == "" || street == current) {
+			continue
+		}
+		cue := newCue(points, cum, best, models.CueTypeTurn)
+		if direction == models.CueDirectionStraight {
+			cue.Type = models.CueTypeContinue
+		} else {
+			cue.Angle = math.Round(angle)
+		}
+		cue.Direction = direction
+		cue.Street = street
+		current = street
+		cues = append(cues, cue)
+	}
+	return cues
+}
+
+// 진행 방향 변화 (-180~180도, 오른쪽이 +)
+func headingChange(from, to float64) float64 {
+	d := math.Mod(to-from+540, 360) - 180
+	if d == -180 {
+		return 180
+	}
+	return d
+}
+
+func turnDirection(angle float64) string {
+	a := math.Abs(angle)
+	switch {
+	case a < cueTurnAngle:
+		return models.CueDirectionStraight
+	case a >= cueUTurnAngle:
+		return models.CueDirectionUTurn
+	case angle < 0 && a < cueSlightAngle:
+		return models.CueDirectionSlightLeft
+	case angle < 0 && a < cueSharpAngle:
+		return models.CueDirectionLeft
+	case angle < 0:
+		return models.CueDirectionSharpLeft
+	case a < cueSlightAngle:
+		return models.CueDirectionSlightRight
+	case a < cueSharpAngle:
+		return models.CueDirectionRight
+	}
+	return models.CueDirectionSharpRight
+}
+
+// 도로 이름이 짧게 바뀌었다 돌아오는 구간(교차로 근처 매칭 오차)은 앞 도로 이름으로 바꿈
+func smoothStreets(streets []string, cum []float64) []string {
+	out := make([]string, len(streets))
+	previous := ""
+	for start := 0; start < len(streets); {
+		end := start
+		for end < len(streets) && streets[end] == streets[start] {
+			end++
+		}
+		name := streets[start]
+		if start > 0 && cum[end]-cum[start] < cueMinStreetLength {
+			name = previous
+		}
+		for k := start; k < end; k++ {
+			out[k] = name
+		}
+		previous = name
+		start = end
+	}
+	return out
+}
+
+// p에서 maxDistance(미터) 안의 가장 가까운 트랙 지점
+func nearestPoint(points []models.GeoPoint, p models.GeoPoint, maxDistance float64) (int, bool) {
+	best, bestDistance := -1, maxDistance
+	for i, q := range points {
+		if d := Haversine(p, q); d <= bestDistance {
+			best, bestDistance = i, d
+		}
+	}
+	return best, best >= 0
+}
+
+func cueNote(c models.Cue) string {
+	var note string
+	switch c.Type {
+	case models.CueTypeStart:
+		note = "출발"
+	case models.CueTypeFinish:
+		return "도착"
+	case models.CueTypeTurn, models.CueTypeContinue:
+		note = cueDirectionNotes[c.Direction]
+	}
+	if c.Street != "" {
+		note += ": " + c.Street
+	}
+	return note
+}
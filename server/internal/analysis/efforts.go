@@ -0,0 +1,224 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+// EffortKind 최고 기록 종목의 종류
+type EffortKind int
+
+const (
+	EffortFastestDistance  EffortKind = iota // 정해진 거리를 가장 빨리 주행 (시간이 짧을수록 좋음)
+	EffortFarthestDuration                   // 정해진 시간 동안 가장 멀리 주행 (거리가 길수록 좋음)
+	EffortLongestRide                        // 가장 긴 라이드 (거리)
+	EffortBiggestClimb                       // 한 번에 오른 가장 큰 고도 차
+)
+
+// EffortDefinition 최고 기록 종목 정의
+type EffortDefinition struct {
+	Key      string
+	Kind     EffortKind
+	Distance float64       // EffortFastestDistance: 미터
+	Duration time.Duration // EffortFarthestDuration
+}
+
+// EffortDefinitions 측정하는 종목 목록 (응답 정렬 순서)
+var EffortDefinitions = []EffortDefinition{
+	{Key: "1km", Kind: EffortFastestDistance, Distance: 1000},
+	{Key: "5km", Kind: EffortFastestDistance, Distance: 5000},
+	{Key: "10km", Kind: EffortFastestDistance, Distance: 10000},
+	{Key: "20km", Kind: EffortFastestDistance, Distance: 20000},
+	{Key: "40km", Kind: EffortFastestDistance, Distance: 40000},
+	{Key: "100km", Kind: EffortFastestDistance, Distance: 100000},
+	{Key: "1min", Kind: EffortFarthestDuration, Duration: time.Minute},
+	{Key: "5min", Kind: EffortFarthestDuration, Duration: 5 * time.Minute},
+	{Key: "20min", Kind: EffortFarthestDuration, Duration: 20 * time.Minute},
+	{Key: "60min", Kind: EffortFarthestDuration, Duration: time.Hour},
+	{Key: "longest_ride", Kind: EffortLongestRide},
+	{Key: "biggest_climb", Kind: EffortBiggestClimb},
+}
+
+// FindEffortDefinition 종목 키로 정의 조회
+func FindEffortDefinition(key string) (EffortDefinition, bool) {
+	for _, d := range EffortDefinitions {
+		if d.Key == key {
+			return d, true
+		}
+	}
+	return EffortDefinition{}, false
+}
+
+// Better a가 b보다 좋은 기록인지 비교 (같으면 false)
+func (d EffortDefinition) Better(a, b models.Effort) bool {
+	switch d.Kind {
+	case EffortFastestDistance:
+		return a.ElapsedTime < b.ElapsedTime
+	case EffortFarthestDuration, EffortLongestRide:
+		return a.Distance > b.Distance
+	case EffortBiggestClimb:
+		return a.Elevation > b.Elevation
+	}
+	return false
+}
+
+// BestEfforts 트랙에서 종목별 최고 기록 계산
+// 시간 기반 종목은 시각 정보가 있는 지점만 사용하며, 트랙이 종목 기준보다 짧으면 제외
+func BestEfforts(points []models.GeoPoint) []models.Effort {
+	if len(points) < 2 {
+		return nil
+	}
+
+	// 시각 정보가 있는 지점과 원래 인덱스
+	var timed []models.GeoPoint
+	var timedIndex []int
+	for i, p := range points {
+		if p.Timestamp != nil {
+			timed = append(timed, p)
+			timedIndex = append(timedIndex, i)
+		}
+	}
+	timedCum := CumulativeDistances(timed)
+
+	var efforts []models.Effort
+	for _, d := range EffortDefinitions {
+		var e models.Effort
+		ok := false
+		switch d.Kind {
+		case EffortFastestDistance:
+			e, ok = fastestDistance(timed, timedCum, d.Distance)
+		case EffortFarthestDuration:
+			e, ok = farthestDuration(timed, timedCum, d.Duration)
+		case EffortLongestRide:
+			e, ok = wholeTrack(points)
+		case EffortBiggestClimb:
+			e, ok = biggestClimb(points)
+		}
+		if !ok {
+			continue
+		}
+		if d.Kind == EffortFastestDistance || d.Kind == EffortFarthestDuration {
+			e.StartIndex, e.EndIndex = timedIndex[e.StartIndex], timedIndex[e.EndIndex]
+		}
+		e.Key = d.Key
+		efforts = append(efforts, e)
+	}
+	return efforts
+}
+
+// 거리 meters를 가장 짧은 시간에 주행한 구간
+// 구간 시작 위치는 두 지점 사이를 선형 보간하여 정확히 meters가 되도록 맞춤
+func fastestDistance(points []models.GeoPoint, cum []float64, meters float64) (models.Effort, bool) {
+	var best models.Effort
+	found := false
+	start := 0
+	for end := 1; end < len(points); end++ {
+		if cum[end] < meters {
+			continue
+		}
+		// 시작점 이후로도 거리를 만족하는 한 시작점을 앞으로 당김
+		for start+1 < end && cum[end]-cum[start+1] >= meters {
+			start++
+		}
+
+		// 시작 위치(cum[end]-meters)가 start~start+1 사이에 오도록 보간한 시각
+		startTime := *points[start].Timestamp
+		if seg := cum[start+1] - cum[start]; seg > 0 {
+			ratio := (cum[end] - meters - cum[start]) / seg
+			span := points[start+1].Timestamp.Sub(startTime)
+			startTime = startTime.Add(time.Duration(ratio * float64(span)))
+		}
+		elapsed := points[end].Timestamp.Sub(startTime)
+		if elapsed <= 0 {
+			continue
+		}
+
+		if !found || elapsed < best.ElapsedTime {
+			best = models.Effort{
+				Distance:    meters / 1000,
+				ElapsedTime: elapsed,
+				StartIndex:  start,
+				EndIndex:    end,
+			}
+			found = true
+		}
+	}
+	return best, found
+}
+
+// duration 동안 가장 멀리 주행한 구간
+// 구간 시작 시각은 두 지점 사이를 선형 보간하여 정확히 duration이 되도록 맞춤
+func farthestDuration(points []models.GeoPoint, cum []float64, duration time.Duration) (models.Effort, bool) {
+	var best models.Effort
+	found := false
+	start := 0
+	for end := 1; end < len(points); end++ {
+		endTime := *points[end].Timestamp
+		if endTime.Sub(*points[0].Timestamp) < duration {
+			continue
+		}
+		for start+1 < end && endTime.Sub(*points[start+1].Timestamp) >= duration {
+			start++
+		}
+
+		// 시작 시각(endTime-duration)이 start~start+1 사이에 오도록 보간한 거리
+		startDist := cum[start]
+		if span := points[start+1].Timestamp.Sub(*points[start].Timestamp); span > 0 {
+			ratio := float64(endTime.Add(-duration).Sub(*points[start].Timestamp)) / float64(span)
+			startDist += ratio * (cum[start+1] - cum[start])
+		}
+		distance := cum[end] - startDist
+
+		if !found || distance > best.Distance*1000 {
+			best = models.Effort{
+				Distance:    distance / 1000,
+				ElapsedTime: duration,
+				StartIndex:  start,
+				EndIndex:    end,
+			}
+			found = true
+		}
+	}
+	return best, found
+}
+
+// 트랙 전체 (가장 긴 라이드)
+func wholeTrack(points []models.GeoPoint) (models.Effort, bool) {
+	e := models.Effort{
+		Distance: TrackDistance(points) / 1000,
+		EndIndex: len(points) - 1,
+	}
+	if first, last := points[0].Timestamp, points[len(points)-1].Timestamp; first != nil && last != nil {
+		e.ElapsedTime = last.Sub(*first)
+	}
+	return e, e.Distance > 0
+}
+
+// 가장 큰 오르막: 앞선 최저 지점에서 이후 최고 지점까지의 고도 차가 가장 큰 구간
+func biggestClimb(points []models.GeoPoint) (models.Effort, bool) {
+	var best models.Effort
+	low := -1
+	for i, p := range points {
+		if p.Elevation == nil {
+			continue
+		}
+		if low < 0 || *p.Elevation < *points[low].Elevation {
+			low = i
+			continue
+		}
+		if gain := *p.Elevation - *points[low].Elevation; gain > best.Elevation {
+			best = models.Effort{Elevation: gain, StartIndex: low, EndIndex: i}
+		}
+	}
+	if best.Elevation <= ElevationThreshold {
+		return models.Effort{}, false
+	}
+
+	cum := CumulativeDistances(points[best.StartIndex : best.EndIndex+1])
+	best.Distance = cum[len(cum)-1] / 1000
+	if a, b := points[best.StartIndex].Timestamp, points[best.EndIndex].Timestamp; a != nil && b != nil {
+		best.ElapsedTime = b.Sub(*a)
+	}
+	return best, true
+}
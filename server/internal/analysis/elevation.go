@@ -0,0 +1,99 @@
+package analysis
+
+import (
+	"math"
+
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+// ElevationSmoothingRadius 고도 평활화에 사용하는 앞뒤 거리 (미터)
+// GPS 고도의 짧은 요동이 상승 고도로 누적되지 않도록 함
+const ElevationSmoothingRadius = 30.0
+
+// SmoothElevation 각 지점의 고도를 앞뒤 ElevationSmoothingRadius 안 지점들의 평균으로 바꾼 복사본
+// 고도가 없는 지점은 그대로 둠
+func SmoothElevation(points []models.GeoPoint) []models.GeoPoint {
+	smoothed := make([]models.GeoPoint, len(points))
+	copy(smoothed, points)
+
+	cum := CumulativeDistances(points)
+	var idx []int
+	for i, p := range points {
+		if p.Elevation != nil {
+			idx = append(idx, i)
+		}
+	}
+
+	// 고도가 있는 지점들의 누적 합으로 구간 평균 계산
+	sums := make([]float64, len(idx)+1)
+	for k, i := range idx {
+		sums[k+1] = sums[k] + *points[i].Elevation
+	}
+
+	lo, hi := 0, 0
+	for _, i := range idx {
+		for cum[i]-cum[idx[lo]] > ElevationSmoothingRadius {
+			lo++
+		}
+		for hi+1 < len(idx) && cum[idx[hi+1]]-cum[i] <= ElevationSmoothingRadius {
+			hi++
+		}
+		ele := (sums[hi+1] - sums[lo]) / float64(hi-lo+1)
+		smoothed[i].Elevation = &ele
+	}
+	return smoothed
+}
+
+// ElevationProfile 트랙의 거리-고도 그래프 (평활화한 고도 기준)
+// 고도가 있는 구간을 일정한 거리 간격으로 samples개 지점에서 보간하며, 고도 정보가 없으면 false
+func ElevationProfile(points []models.GeoPoint, samples int) (models.ElevationProfile, bool) {
+	var profile models.ElevationProfile
+
+	smoothed := SmoothElevation(points)
+	cum := CumulativeDistances(smoothed)
+	var idx []int
+	for i, p := range smoothed {
+		if p.Elevation != nil {
+			idx = append(idx, i)
+		}
+	}
+	if len(idx) < 2 || samples < 2 {
+		return profile, false
+	}
+
+	profile.Distance = math.Round(cum[len(cum)-1]) / 1000
+	profile.ElevationGain, profile.ElevationLoss = ElevationChange(points)
+	profile.MinElevation, profile.MaxElevation = math.Inf(1), math.Inf(-1)
+	for _, i := range idx {
+		profile.MinElevation = math.Min(profile.MinElevation, *smoothed[i].Elevation)
+		profile.MaxElevation = math.Max(profile.MaxElevation, *smoothed[i].Elevation)
+	}
+
+	first, last := cum[idx[0]], cum[idx[len(idx)-1]]
+	step := (last - first) / float64(samples-1)
+	profile.Points = make([]models.ElevationPoint, 0, samples)
+	k := 0
+	for n := 0; n < samples; n++ {
+		d := first + step*float64(n)
+		for k+2 < len(idx) && cum[idx[k+1]] < d {
+			k++
+		}
+
+		a, b := idx[k], idx[k+1]
+		ele := *smoothed[a].Elevation
+		if span := cum[b] - cum[a]; span > 0 {
+			t := math.Max(0, math.Min(1, (d-cum[a])/span))
+			ele += (*smoothed[b].Elevation - ele) * t
+		}
+		profile.Points = append(profile.Points, models.ElevationPoint{
+			Distance:  math.Round(d) / 1000,
+			Elevation: round1(ele),
+		})
+	}
+
+	profile.ElevationGain = round1(profile.ElevationGain)
+	profile.ElevationLoss = round1(profile.ElevationLoss)
+	profile.MinElevation = round1(profile.MinElevation)
+	profile.MaxElevation = round1(profile.MaxElevation)
+	return profile, true
+}
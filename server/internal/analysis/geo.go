@@ -0,0 +1,97 @@
+package analysis
+
+import (
+	"math"
+
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+// 지구 평균 반지름 (미터)
+const EarthRadius = 6371008.8
+
+// Haversine 두 지점 사이의 대권 거리 (미터)
+func Haversine(a, b models.GeoPoint) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Bearing a에서 b로 향하는 초기 방위 (도, 북쪽 0 시계 방향, 0~360)
+func Bearing(a, b models.GeoPoint) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	y := math.Sin(dLon) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLon)
+	return math.Mod(math.Atan2(y, x)*180/math.Pi+360, 360)
+}
+
+// TrackDistance 트랙 전체 거리 (미터)
+func TrackDistance(points []models.GeoPoint) float64 {
+	var total float64
+	for i := 1; i < len(points); i++ {
+		total += Haversine(points[i-1], points[i])
+	}
+	return total
+}
+
+// CumulativeDistances 각 지점까지의 누적 거리 (미터)
+// 결과의 길이는 points와 같고 첫 값은 0
+func CumulativeDistances(points []models.GeoPoint) []float64 {
+	dist := make([]float64, len(points))
+	for i := 1; i < len(points); i++ {
+		dist[i] = dist[i-1] + Haversine(points[i-1], points[i])
+	}
+	return dist
+}
+
+// DistanceToSegment 지점 p에서 선분 a-b까지의 최단 거리 (미터)
+// p를 중심으로 한 등장방형 투영을 사용하므로 짧은 선분에서만 정확함
+func DistanceToSegment(p, a, b models.GeoPoint) float64 {
+	d, _ := ProjectToSegment(p, a, b)
+	return d
+}
+
+// ProjectToSegment 지점 p에서 선분 a-b까지의 최단 거리 (미터)와 선분 위 가장 가까운 지점의 위치
+// (a가 0, b가 1인 비율)
+func ProjectToSegment(p, a, b models.GeoPoint) (distance, t float64) {
+	scale := math.Cos(p.Latitude * math.Pi / 180)
+	project := func(q models.GeoPoint) (x, y float64) {
+		x = (q.Longitude - p.Longitude) * math.Pi / 180 * scale * EarthRadius
+		y = (q.Latitude - p.Latitude) * math.Pi / 180 * EarthRadius
+		return x, y
+	}
+
+	ax, ay := project(a)
+	bx, by := project(b)
+	dx, dy := bx-ax, by-ay
+	if length := dx*dx + dy*dy; length > 0 {
+		t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/length))
+	}
+	return math.Hypot(ax+t*dx, ay+t*dy), t
+}
+
+// Bounds 지점들을 감싸는 최소/최대 위경도
+func Bounds(points []models.GeoPoint) (lo, hi models.GeoPoint) {
+	for i, p := range points {
+		if i == 0 || p.Latitude < lo.Latitude {
+			lo.Latitude = p.Latitude
+		}
+		if i == 0 || p.Longitude < lo.Longitude {
+			lo.Longitude = p.Longitude
+		}
+		if i == 0 || p.Latitude > hi.Latitude {
+			hi.Latitude = p.Latitude
+		}
+		if i == 0 || p.Longitude > hi.Longitude {
+			hi.Longitude = p.Longitude
+		}
+	}
+	return lo, hi
+}
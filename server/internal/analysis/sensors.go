@@ -0,0 +1,186 @@
+package analysis
+
+import (
+	"math"
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+// 정규화 파워(NP) 계산에 사용하는 이동 평균 구간
+const NormalizedPowerWindow = 30 * time.Second
+
+// 최대 심박수 대비 심박 구간 경계 (%)
+var heartRateZoneBounds = []float64{60, 70, 80, 90}
+
+// FTP 대비 파워 구간 경계 (%, Coggan 7구간)
+var powerZoneBounds = []float64{55, 75, 90, 105, 120, 150}
+
+// SensorSummary 센서 스트림의 평균/최대, 정규화 파워, 강도 계수, 구간별 시간
+// 지점 i의 측정값은 직전 지점부터 i까지의 시간 동안 유지된 것으로 보며,
+// 일시정지 구간과 MaxMovingGap보다 긴 기록 중단은 제외
+// 트랙에 시각 정보가 없으면 측정값마다 1초로 계산
+// 강도 계수와 구간별 시간은 기준값(thresholds)이 있을 때만 계산하며, 스트림이 없으면 nil
+func SensorSummary(points []models.GeoPoint, streams *models.SensorStreams, pauses []models.Pause, thresholds *models.Thresholds) *models.SensorSummary {
+	if streams == nil || len(points) == 0 {
+		return nil
+	}
+	weights := sampleWeights(points, pauses)
+
+	s := &models.SensorSummary{
+		HeartRate:   streamStats(streams.HeartRate, weights, false),
+		Cadence:     streamStats(streams.Cadence, weights, true),
+		Power:       streamStats(streams.Power, weights, false),
+		Speed:       streamStats(streams.Speed, weights, false),
+		Temperature: streamStats(streams.Temperature, weights, false),
+		Balance:     streamStats(streams.Balance, weights, false),
+	}
+	if s.Power != nil {
+		s.NormalizedPower = round1(NormalizedPower(streams.Power, weights))
+	}
+
+	if thresholds != nil {
+		if thresholds.FTP > 0 {
+			if s.NormalizedPower > 0 {
+				s.IntensityFactor = math.Round(s.NormalizedPower/float64(thresholds.FTP)*1000) / 1000
+			}
+			if s.Power != nil {
+				s.PowerZones = zoneTimes(streams.Power, weights, thresholds.FTP, powerZoneBounds)
+			}
+		}
+		if thresholds.MaxHeartRate > 0 && s.HeartRate != nil {
+			s.HeartRateZones = zoneTimes(streams.HeartRate, weights, thresholds.MaxHeartRate, heartRateZoneBounds)
+		}
+	}
+
+	if s.HeartRate == nil && s.Cadence == nil && s.Power == nil &&
+		s.Speed == nil && s.Temperature == nil && s.Balance == nil {
+		return nil
+	}
+	return s
+}
+
+// 지점별 측정값이 유지된 시간
+func sampleWeights(points []models.GeoPoint, pauses []models.Pause) []time.Duration {
+	weights := make([]time.Duration, len(points))
+	if !hasTimestamps(points) {
+		for i := range weights {
+			weights[i] = time.Second
+		}
+		return weights
+	}
+
+	paused := 0 // 지점 i가 속할 수 있는 첫 일시정지 구간
+	for i := 1; i < len(points); i++ {
+		for paused < len(pauses) && pauses[paused].EndIndex < i {
+			paused++
+		}
+		if paused < len(pauses) && pauses[paused].StartIndex <= i-1 {
+			continue
+		}
+		if dt := points[i].Timestamp.Sub(*points[i-1].Timestamp); dt > 0 && dt <= MaxMovingGap {
+			weights[i] = dt
+		}
+	}
+	return weights
+}
+
+// 시간 가중 평균과 최소/최대 (측정값이 없으면 nil)
+// skipZero이면 0은 평균과 최소에서 제외
+func streamStats[T int | float64](values []*T, weights []time.Duration, skipZero bool) *models.StreamStats {
+	var stats *models.StreamStats
+	var sum, total float64
+	for i, v := range values {
+		if v == nil || i >= len(weights) {
+			continue
+		}
+		x := float64(*v)
+		if stats == nil {
+			stats = &models.StreamStats{Min: math.Inf(1), Max: x}
+		}
+		stats.Max = math.Max(stats.Max, x)
+		if skipZero && x == 0 {
+			continue
+		}
+		stats.Min = math.Min(stats.Min, x)
+		sum += x * weights[i].Seconds()
+		total += weights[i].Seconds()
+	}
+	if stats == nil {
+		return nil
+	}
+	if math.IsInf(stats.Min, 1) {
+		stats.Min = 0
+	}
+	if total > 0 {
+		stats.Avg = round1(sum / total)
+	}
+	stats.Min, stats.Max = round1(stats.Min), round1(stats.Max)
+	return stats
+}
+
+// NormalizedPower 정규화 파워 (와트)
+// 파워를 1초 간격으로 다시 표본화해 30초 이동 평균을 구한 뒤 4제곱 평균의 4제곱근
+// 1초보다 짧은 간격의 측정값은 1초가 찰 때까지 시간 가중 평균으로 합치며, 마지막에 채우지 못한 1초는 버림
+// 측정값이 없는 시간은 건너뛰며, 측정 시간이 30초보다 짧으면 0
+func NormalizedPower(power []*int, weights []time.Duration) float64 {
+	var series []float64
+	var filled, sum float64 // 채우는 중인 1초 구간의 시간(초)과 파워×시간
+	for i, v := range power {
+		if v == nil || i >= len(weights) {
+			continue
+		}
+		for rest := weights[i].Seconds(); rest > 0; {
+			dt := math.Min(rest, 1-filled)
+			filled += dt
+			sum += float64(*v) * dt
+			rest -= dt
+			if filled >= 1-1e-9 {
+				series = append(series, sum/filled)
+				filled, sum = 0, 0
+			}
+		}
+	}
+
+	window := int(NormalizedPowerWindow.Seconds())
+	if len(series) < window {
+		return 0
+	}
+	var rolling, sum4 float64
+	for i, p := range series {
+		rolling += p
+		if i >= window {
+			rolling -= series[i-window]
+		}
+		if i >= window-1 {
+			sum4 += math.Pow(rolling/float64(window), 4)
+		}
+	}
+	return math.Pow(sum4/float64(len(series)-window+1), 0.25)
+}
+
+// 기준값(threshold) 대비 bounds(%)로 나눈 구간별 시간
+func zoneTimes(values []*int, weights []time.Duration, threshold int, bounds []float64) []models.ZoneTime {
+	zones := make([]models.ZoneTime, len(bounds)+1)
+	for z := range zones {
+		zones[z].Zone = z + 1
+		if z > 0 {
+			zones[z].Min = int(math.Round(float64(threshold) * bounds[z-1] / 100))
+		}
+		if z < len(bounds) {
+			zones[z].Max = int(math.Round(float64(threshold) * bounds[z] / 100))
+		}
+	}
+
+	for i, v := range values {
+		if v == nil || i >= len(weights) {
+			continue
+		}
+		z := 0
+		for z < len(bounds) && *v >= zones[z+1].Min {
+			z++
+		}
+		zones[z].Time += weights[i]
+	}
+	return zones
+}
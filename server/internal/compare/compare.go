@@ -0,0 +1,234 @@
+// Package compare 라이드 트랙을 따라간 경로와 비교
+//
+// 경로를 얼마나 달렸는지(완주율), 어디서 얼마나 경로를 벗어났는지, 체크포인트를 언제 지났는지 계산하고
+// 주행 중 현재 위치가 경로를 벗어났는지 확인
+package compare
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+const (
+	// OffRouteDistance 경로에서 이 거리보다 멀어지면 경로 이탈 (미터)
+	OffRouteDistance = 50.0
+	// CheckpointRadius 체크포인트를 지난 것으로 인정하는 반경 (미터)
+	CheckpointRadius = 50.0
+	// CheckpointSpacing 경로에 웨이포인트가 없을 때 체크포인트를 두는 간격 (미터)
+	CheckpointSpacing = 5000.0
+
+	// 이보다 짧게 벗어난 구간은 GPS 오차로 보고 무시 (라이드 거리, 미터)
+	minOffRouteLength = 100.0
+	// 완주율을 잴 경로 표본 간격 (미터)
+	sampleSpacing = 20.0
+	// 체크포인트까지 경로 거리 대비 최소 주행 거리 비율
+	// (시작과 도착이 같은 순환 경로에서 출발 직후를 도착으로 보지 않도록 함)
+	minCheckpointProgress = 0.8
+)
+
+// Checkpoint 경로의 체크포인트 (GPX 웨이포인트 등)
+type Checkpoint struct {
+	Name  string
+	Point models.GeoPoint
+}
+
+// Compare 라이드 트랙 ride를 경로 route와 비교
+// checkpoints가 없으면 CheckpointSpacing 간격의 거리 표지를 체크포인트로 사용하며, 도착 지점은 항상 포함
+// 경로나 라이드 지점이 2개 미만이면 nil
+func Compare(route []models.GeoPoint, checkpoints []Checkpoint, ride []models.GeoPoint) *models.RouteComparison {
+	if len(route) < 2 || len(ride) < 2 {
+		return nil
+	}
+
+	routeLine := newLine(route, OffRouteDistance)
+	rideLine := newLine(ride, OffRouteDistance)
+	total := routeLine.cum[len(route)-1]
+
+	result := &models.RouteComparison{
+		RouteDistance: math.Round(total) / 1000,
+		Completion:    completion(route, rideLine),
+	}
+
+	// 라이드 지점마다 경로까지 거리
+	deviation := make([]float64, len(ride))
+	for i, p := range ride {
+		deviation[i] = routeLine.nearest(p).distance
+		result.MaxDeviation = math.Max(result.MaxDeviation, deviation[i])
+	}
+	result.MaxDeviation = math.Round(result.MaxDeviation)
+
+	result.OffRoute = offRouteSections(ride, rideLine.cum, deviation)
+	var offDistance float64
+	for _, s := range result.OffRoute {
+		offDistance += s.Distance
+		result.OffRouteTime += s.Duration
+	}
+	result.OffRouteDistance = math.Round(offDistance*1000) / 1000
+
+	result.Checkpoints = checkpointTimes(routeLine, checkpoints, ride, rideLine.cum)
+	return result
+}
+
+// 경로 표본 중 라이드 트랙에서 OffRouteDistance 안에 있는 비율 (%)
+func completion(route []models.GeoPoint, ride *line) float64 {
+	samples := resample(route, sampleSpacing)
+	covered := 0
+	for _, p := range samples {
+		if _, ok := ride.near(p); ok {
+			covered++
+		}
+	}
+	return math.Round(float64(covered)/float64(len(samples))*1000) / 10
+}
+
+// 경로에서 OffRouteDistance보다 멀리 연속으로 벗어난 구간 (minOffRouteLength 이상만)
+func offRouteSections(ride []models.GeoPoint, cum, deviation []float64) []models.OffRouteSection {
+	var sections []models.OffRouteSection
+	for i := 0; i < len(ride); {
+		if deviation[i] <= OffRouteDistance {
+			i++
+			continue
+		}
+		// 이탈 전후 경로 위 지점까지 포함해 거리와 시간을 잼
+		start, end := max(i-1, 0), i
+		maxDeviation := 0.0
+		for end < len(ride) && deviation[end] > OffRouteDistance {
+			maxDeviation = math.Max(maxDeviation, deviation[end])
+			end++
+		}
+		last := min(end, len(ride)-1)
+		i = end
+
+		if cum[last]-cum[start] < minOffRouteLength {
+			continue
+		}
+		section := models.OffRouteSection{
+			StartIndex:    start,
+			EndIndex:      last,
+			StartDistance: math.Round(cum[start]) / 1000,
+			Distance:      math.Round(cum[last]-cum[start]) / 1000,
+			MaxDeviation:  math.Round(maxDeviation),
+		}
+		if a, b := ride[start].Timestamp, ride[last].Timestamp; a != nil && b != nil && b.After(*a) {
+			section.Duration = b.Sub(*a)
+		}
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+// 체크포인트를 경로 순서대로 라이드에서 찾음
+// 앞 체크포인트를 지난 이후에서, 반경 안에 연속으로 들어온 지점 중 가장 가까운 지점을 통과 지점으로 봄
+func checkpointTimes(route *line, checkpoints []Checkpoint, ride []models.GeoPoint, cum []float64) []models.CheckpointTime {
+	total := route.cum[len(route.points)-1]
+
+	var result []models.CheckpointTime
+	for _, cp := range checkpoints {
+		pr := route.nearest(cp.Point)
+		if pr.distance > CheckpointRadius {
+			continue
+		}
+		result = append(result, models.CheckpointTime{
+			Name:          cp.Name,
+			RouteDistance: pr.along,
+			Latitude:      cp.Point.Latitude,
+			Longitude:     cp.Point.Longitude,
+		})
+	}
+	if len(result) == 0 {
+		for d := CheckpointSpacing; d < total; d += CheckpointSpacing {
+			p := route.at(route.locate(d))
+			result = append(result, models.CheckpointTime{
+				Name:          fmt.Sprintf("%.0fkm", d/1000),
+				RouteDistance: d,
+				Latitude:      p.Latitude,
+				Longitude:     p.Longitude,
+			})
+		}
+	}
+	finish := route.points[len(route.points)-1]
+	result = append(result, models.CheckpointTime{
+		Name:          "도착",
+		RouteDistance: total,
+		Latitude:      finish.Latitude,
+		Longitude:     finish.Longitude,
+	})
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].RouteDistance < result[j].RouteDistance
+	})
+
+	var startTime *time.Time
+	for _, p := range ride {
+		if p.Timestamp != nil {
+			startTime = p.Timestamp
+			break
+		}
+	}
+
+	from := 0
+	for k := range result {
+		cp := &result[k]
+		target := models.GeoPoint{Latitude: cp.Latitude, Longitude: cp.Longitude}
+		minRide := cp.RouteDistance*minCheckpointProgress - CheckpointRadius
+		for i := from; i < len(ride); i++ {
+			if cum[i] < minRide || analysis.Haversine(ride[i], target) > CheckpointRadius {
+				continue
+			}
+			closest, best := i, analysis.Haversine(ride[i], target)
+			for j := i + 1; j < len(ride); j++ {
+				d := analysis.Haversine(ride[j], target)
+				if d > CheckpointRadius {
+					break
+				}
+				if d < best {
+					closest, best = j, d
+				}
+			}
+
+			cp.Reached = true
+			cp.Index = closest
+			cp.RideDistance = math.Round(cum[closest]) / 1000
+			if ts := ride[closest].Timestamp; ts != nil {
+				t := *ts
+				cp.Time = &t
+				if startTime != nil {
+					cp.Elapsed = t.Sub(*startTime)
+				}
+			}
+			from = closest
+			break
+		}
+		cp.RouteDistance = math.Round(cp.RouteDistance) / 1000
+	}
+	return result
+}
+
+// Locate 주행 중 현재 위치 p의 경로 이탈 여부
+// progress는 직전에 확인한 경로 거리 (미터, 모르면 음수)로, 같은 길을 여러 번 지나는 경로에서
+// 진행 위치에 가까운 쪽을 고르는 데 사용
+func Locate(route []models.GeoPoint, p models.GeoPoint, progress float64) *models.RouteDeviation {
+	if len(route) < 2 {
+		return nil
+	}
+	l := newLine(route, OffRouteDistance)
+
+	pr := l.nearest(p)
+	if progress >= 0 {
+		if hinted, ok := l.nearHint(p, progress); ok {
+			pr = hinted
+		}
+	}
+	total := l.cum[len(l.cum)-1]
+	return &models.RouteDeviation{
+		OffRoute:      pr.distance > OffRouteDistance,
+		Deviation:     math.Round(pr.distance*10) / 10,
+		RouteDistance: math.Round(pr.along) / 1000,
+		Remaining:     math.Round(total-pr.along) / 1000,
+		Nearest:       l.at(pr),
+	}
+}
@@ -0,0 +1,236 @@
+package compare
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+var startTime = time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC)
+
+// 기준 위치에서 동쪽 x, 북쪽 y 미터 떨어진 지점
+func xy(x, y float64) models.GeoPoint {
+	const lat, lon = 37.5, 127.0
+	scale := analysis.EarthRadius * math.Pi / 180
+	return models.GeoPoint{
+		Latitude:  lat + y/scale,
+		Longitude: lon + x/(scale*math.Cos(lat*math.Pi/180)),
+	}
+}
+
+// 꼭짓점을 차례로 잇는 경로를 step 미터 간격으로 나눈 지점 (꼭짓점 포함)
+func polyline(step float64, corners ...[2]float64) []models.GeoPoint {
+	points := []models.GeoPoint{xy(corners[0][0], corners[0][1])}
+	for i := 1; i < len(corners); i++ {
+		a, b := corners[i-1], corners[i]
+		d := math.Hypot(b[0]-a[0], b[1]-a[1])
+		n := int(math.Round(d / step))
+		for k := 1; k <= n; k++ {
+			t := float64(k) / float64(n)
+			points = append(points, xy(a[0]+t*(b[0]-a[0]), a[1]+t*(b[1]-a[1])))
+		}
+	}
+	return points
+}
+
+// 10m 간격 지점을 1초마다 지나는 라이드 (10m/s)
+func ride(corners ...[2]float64) []models.GeoPoint {
+	points := polyline(10, corners...)
+	for i := range points {
+		ts := startTime.Add(time.Duration(i) * time.Second)
+		points[i].Timestamp = &ts
+	}
+	return points
+}
+
+// 동쪽으로 10km 곧은 경로
+var straightRoute = polyline(200, [2]float64{0, 0}, [2]float64{10000, 0})
+
+func near(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
+
+func TestCompareFullCompletion(t *testing.T) {
+	// 경로 옆 5m를 따라 달림
+	r := ride([2]float64{0, 5}, [2]float64{10000, 5})
+
+	c := Compare(straightRoute, nil, r)
+	if c == nil {
+		t.Fatal("Compare = nil")
+	}
+	if c.RouteDistance != 10 {
+		t.Errorf("route distance = %v, want 10", c.RouteDistance)
+	}
+	if c.Completion != 100 {
+		t.Errorf("completion = %v, want 100", c.Completion)
+	}
+	if len(c.OffRoute) != 0 || c.OffRouteDistance != 0 || c.OffRouteTime != 0 {
+		t.Errorf("off route = %+v, %v km, %v, want 없음", c.OffRoute, c.OffRouteDistance, c.OffRouteTime)
+	}
+	if c.MaxDeviation != 5 {
+		t.Errorf("max deviation = %v, want 5", c.MaxDeviation)
+	}
+
+	// 웨이포인트가 없으면 5km 거리 표지와 도착
+	want := []struct {
+		name    string
+		route   float64
+		elapsed time.Duration
+	}{
+		{"5km", 5, 500 * time.Second},
+		{"도착", 10, 1000 * time.Second},
+	}
+	if len(c.Checkpoints) != len(want) {
+		t.Fatalf("checkpoints = %d, want %d", len(c.Checkpoints), len(want))
+	}
+	for i, w := range want {
+		cp := c.Checkpoints[i]
+		if cp.Name != w.name || cp.RouteDistance != w.route || !cp.Reached || cp.Elapsed != w.elapsed {
+			t.Errorf("checkpoints[%d] = %s %v km reached %v elapsed %v, want %s %v km elapsed %v",
+				i, cp.Name, cp.RouteDistance, cp.Reached, cp.Elapsed, w.name, w.route, w.elapsed)
+		}
+	}
+}
+
+func TestCompareDetour(t *testing.T) {
+	// 4km 지점에서 북쪽으로 300m 벗어나 1km를 달린 뒤 5km 지점으로 돌아옴
+	r := ride([2]float64{0, 0}, [2]float64{4000, 0}, [2]float64{4000, 300}, [2]float64{5000, 300}, [2]float64{5000, 0}, [2]float64{10000, 0})
+	cp := []Checkpoint{{Name: "중간", Point: xy(4500, 10)}}
+
+	c := Compare(straightRoute, cp, r)
+	if c == nil {
+		t.Fatal("Compare = nil")
+	}
+
+	// 4050~4950m 구간 (약 900m)을 달리지 않음
+	if !near(c.Completion, 91, 1) {
+		t.Errorf("completion = %v, want 약 91", c.Completion)
+	}
+	if c.MaxDeviation != 300 {
+		t.Errorf("max deviation = %v, want 300", c.MaxDeviation)
+	}
+
+	if len(c.OffRoute) != 1 {
+		t.Fatalf("off route sections = %d, want 1", len(c.OffRoute))
+	}
+	s := c.OffRoute[0]
+	// 경로에서 50m 벗어나기 직전부터 돌아온 직후까지 (50m + 250m + 1000m + 250m)
+	if !near(s.StartDistance, 4.05, 0.011) || !near(s.Distance, 1.5, 0.021) {
+		t.Errorf("section = start %v km, %v km, want 4.05 km, 1.5 km", s.StartDistance, s.Distance)
+	}
+	if s.MaxDeviation != 300 {
+		t.Errorf("section max deviation = %v, want 300", s.MaxDeviation)
+	}
+	if !near(s.Duration.Seconds(), 150, 2) {
+		t.Errorf("section duration = %v, want 약 150s", s.Duration)
+	}
+	if c.OffRouteDistance != s.Distance || c.OffRouteTime != s.Duration {
+		t.Errorf("합계 = %v km, %v, want 구간과 같음", c.OffRouteDistance, c.OffRouteTime)
+	}
+
+	// 체크포인트가 있으면 거리 표지 없이 체크포인트와 도착, 우회 구간의 체크포인트는 지나지 않음
+	if len(c.Checkpoints) != 2 {
+		t.Fatalf("checkpoints = %d, want 2", len(c.Checkpoints))
+	}
+	if mid := c.Checkpoints[0]; mid.Name != "중간" || mid.Reached || mid.RouteDistance != 4.5 {
+		t.Errorf("중간 = reached %v, route %v km, want false, 4.5 km", mid.Reached, mid.RouteDistance)
+	}
+	finish := c.Checkpoints[1]
+	if !finish.Reached || finish.RideDistance != 10.6 || finish.Elapsed != 1060*time.Second {
+		t.Errorf("도착 = reached %v, ride %v km, elapsed %v, want true, 10.6 km, 1060s", finish.Reached, finish.RideDistance, finish.Elapsed)
+	}
+}
+
+func TestCompareLoopRoute(t *testing.T) {
+	// 한 변 1km 정사각형을 도는 순환 경로 (시작 = 도착)
+	corners := [][2]float64{{0, 0}, {1000, 0}, {1000, 1000}, {0, 1000}, {0, 0}}
+	route := polyline(100, corners...)
+	cp := []Checkpoint{
+		{Name: "반환점", Point: xy(1000, 1000)},
+		{Name: "경로 밖", Point: xy(500, 500)},
+	}
+
+	t.Run("완주", func(t *testing.T) {
+		c := Compare(route, cp, ride(corners...))
+		if c.RouteDistance != 4 || c.Completion != 100 || len(c.OffRoute) != 0 {
+			t.Errorf("route %v km, completion %v, off route %d, want 4, 100, 0", c.RouteDistance, c.Completion, len(c.OffRoute))
+		}
+
+		want := []struct {
+			name    string
+			route   float64
+			elapsed time.Duration
+		}{
+			{"반환점", 2, 200 * time.Second},
+			{"도착", 4, 400 * time.Second},
+		}
+		if len(c.Checkpoints) != len(want) {
+			t.Fatalf("checkpoints = %d, want %d (경로에서 먼 체크포인트 제외)", len(c.Checkpoints), len(want))
+		}
+		for i, w := range want {
+			cp := c.Checkpoints[i]
+			if cp.Name != w.name || cp.RouteDistance != w.route || !cp.Reached || cp.Elapsed != w.elapsed {
+				t.Errorf("checkpoints[%d] = %s %v km reached %v elapsed %v, want %s %v km elapsed %v",
+					i, cp.Name, cp.RouteDistance, cp.Reached, cp.Elapsed, w.name, w.route, w.elapsed)
+			}
+		}
+	})
+
+	t.Run("절반에서 멈춤", func(t *testing.T) {
+		// 출발 지점에 있었다고 도착으로 보지 않아야 함
+		c := Compare(route, cp, ride(corners[:3]...))
+		// 라이드 양 끝에서 반경 50m 안의 경로도 지난 것으로 봄 (2km + 약 100m)
+		if !near(c.Completion, 52.5, 1) {
+			t.Errorf("completion = %v, want 약 52.5", c.Completion)
+		}
+		if turn := c.Checkpoints[0]; !turn.Reached {
+			t.Error("반환점 reached = false")
+		}
+		if finish := c.Checkpoints[1]; finish.Reached {
+			t.Errorf("도착 reached = true (index %d), want false", finish.Index)
+		}
+	})
+}
+
+func TestCompareTooShort(t *testing.T) {
+	if c := Compare(straightRoute[:1], nil, ride([2]float64{0, 0}, [2]float64{100, 0})); c != nil {
+		t.Errorf("경로 지점 1개 = %+v, want nil", c)
+	}
+	if c := Compare(straightRoute, nil, straightRoute[:1]); c != nil {
+		t.Errorf("라이드 지점 1개 = %+v, want nil", c)
+	}
+}
+
+func TestLocate(t *testing.T) {
+	loop := polyline(100, [2]float64{0, 0}, [2]float64{1000, 0}, [2]float64{1000, 1000}, [2]float64{0, 1000}, [2]float64{0, 0})
+
+	tests := []struct {
+		name      string
+		route     []models.GeoPoint
+		p         models.GeoPoint
+		progress  float64
+		offRoute  bool
+		deviation float64
+		along     float64
+		remaining float64
+	}{
+		{"경로 위", straightRoute, xy(2500, 20), -1, false, 20, 2.5, 7.5},
+		{"경로 이탈", straightRoute, xy(4500, 300), -1, true, 300, 4.5, 5.5},
+		{"순환 경로 출발", loop, xy(0, 5), 10, false, 5, 0, 4},
+		{"순환 경로 도착", loop, xy(0, 5), 3900, false, 0, 3.995, 0.005},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Locate(tt.route, tt.p, tt.progress)
+			if d.OffRoute != tt.offRoute || !near(d.Deviation, tt.deviation, 0.2) {
+				t.Errorf("off route %v, deviation %v, want %v, %v", d.OffRoute, d.Deviation, tt.offRoute, tt.deviation)
+			}
+			if !near(d.RouteDistance, tt.along, 0.002) || !near(d.Remaining, tt.remaining, 0.002) {
+				t.Errorf("route distance %v, remaining %v, want %v, %v", d.RouteDistance, d.Remaining, tt.along, tt.remaining)
+			}
+		})
+	}
+}
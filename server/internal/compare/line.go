@@ -0,0 +1,159 @@
+package compare
+
+import (
+	"math"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+const (
+	// 선분 색인 격자 크기 (도, 약 200m)
+	cellSize = 0.002
+	// 진행 위치로 경로 지점을 고를 때 hint와의 거리 차이 1m를 선까지 거리 몇 m로 볼지
+	hintWeight = 0.05
+)
+
+// 가까운 선분을 빠르게 찾기 위한 선(경로 또는 트랙)
+// 선분마다 radius만큼 넓힌 경계 상자가 걸치는 격자에 등록하므로 지점이 속한 격자만 보면 됨
+type line struct {
+	points []models.GeoPoint
+	cum    []float64 // 지점까지 누적 거리 (미터)
+	radius float64
+	cells  map[[2]int32][]int32 // 격자별 선분 (선분 i는 지점 i에서 i+1)
+}
+
+// 선 위의 가장 가까운 지점
+type projection struct {
+	distance float64 // 선까지 거리 (미터)
+	along    float64 // 선 시작부터 가장 가까운 지점까지 거리 (미터)
+	segment  int
+	t        float64 // 선분 위 위치 (0~1)
+}
+
+func newLine(points []models.GeoPoint, radius float64) *line {
+	l := &line{
+		points: points,
+		cum:    analysis.CumulativeDistances(points),
+		radius: radius,
+		cells:  make(map[[2]int32][]int32),
+	}
+	for i := 1; i < len(points); i++ {
+		lo, hi := analysis.Bounds(points[i-1 : i+1])
+		dLat := radius / analysis.EarthRadius * 180 / math.Pi
+		dLon := dLat / math.Max(math.Cos(hi.Latitude*math.Pi/180), 0.01)
+		from := cellKey(lo.Latitude-dLat, lo.Longitude-dLon)
+		to := cellKey(hi.Latitude+dLat, hi.Longitude+dLon)
+		for y := from[0]; y <= to[0]; y++ {
+			for x := from[1]; x <= to[1]; x++ {
+				l.cells[[2]int32{y, x}] = append(l.cells[[2]int32{y, x}], int32(i-1))
+			}
+		}
+	}
+	return l
+}
+
+func cellKey(lat, lon float64) [2]int32 {
+	return [2]int32{int32(math.Floor(lat / cellSize)), int32(math.Floor(lon / cellSize))}
+}
+
+// radius 안의 선분 위 가장 가까운 지점 (없으면 false)
+func (l *line) near(p models.GeoPoint) (projection, bool) {
+	best := projection{distance: math.Inf(1)}
+	for _, i := range l.cells[cellKey(p.Latitude, p.Longitude)] {
+		if pr := l.project(p, int(i)); pr.distance < best.distance {
+			best = pr
+		}
+	}
+	return best, best.distance <= l.radius
+}
+
+// radius 안의 선분 위 지점 중 선까지 거리와 hint(선 시작부터 거리, 미터)와의 차이를 함께 따져 가장 가까운 지점
+// (같은 길을 여러 번 지나는 경로에서 진행 위치를 이어가기 위함)
+func (l *line) nearHint(p models.GeoPoint, hint float64) (projection, bool) {
+	var best projection
+	bestScore := math.Inf(1)
+	for _, i := range l.cells[cellKey(p.Latitude, p.Longitude)] {
+		pr := l.project(p, int(i))
+		if pr.distance > l.radius {
+			continue
+		}
+		if score := pr.distance + math.Abs(pr.along-hint)*hintWeight; score < bestScore {
+			best, bestScore = pr, score
+		}
+	}
+	return best, !math.IsInf(bestScore, 1)
+}
+
+// 선 전체에서 가장 가까운 지점 (radius 밖까지 모든 선분을 확인)
+func (l *line) nearest(p models.GeoPoint) projection {
+	if pr, ok := l.near(p); ok {
+		return pr
+	}
+	best := projection{distance: math.Inf(1)}
+	for i := 0; i < len(l.points)-1; i++ {
+		if pr := l.project(p, i); pr.distance < best.distance {
+			best = pr
+		}
+	}
+	if len(l.points) == 1 {
+		best = projection{distance: analysis.Haversine(p, l.points[0])}
+	}
+	return best
+}
+
+func (l *line) project(p models.GeoPoint, i int) projection {
+	d, t := analysis.ProjectToSegment(p, l.points[i], l.points[i+1])
+	return projection{
+		distance: d,
+		along:    l.cum[i] + t*(l.cum[i+1]-l.cum[i]),
+		segment:  i,
+		t:        t,
+	}
+}
+
+// 선 시작부터 distance(미터) 떨어진 선 위 위치
+func (l *line) locate(distance float64) projection {
+	for i := 1; i < len(l.cum); i++ {
+		if l.cum[i] >= distance {
+			t := 0.0
+			if length := l.cum[i] - l.cum[i-1]; length > 0 {
+				t = (distance - l.cum[i-1]) / length
+			}
+			return projection{along: distance, segment: i - 1, t: t}
+		}
+	}
+	return projection{along: l.cum[len(l.cum)-1], segment: len(l.cum) - 1}
+}
+
+// 선분 위 위치의 지점
+func (l *line) at(pr projection) models.GeoPoint {
+	if pr.segment+1 >= len(l.points) {
+		return l.points[len(l.points)-1]
+	}
+	a, b := l.points[pr.segment], l.points[pr.segment+1]
+	return models.GeoPoint{
+		Latitude:  a.Latitude + pr.t*(b.Latitude-a.Latitude),
+		Longitude: a.Longitude + pr.t*(b.Longitude-a.Longitude),
+	}
+}
+
+// 선을 spacing 미터 간격으로 보간한 표본 (처음과 마지막 지점 포함)
+func resample(points []models.GeoPoint, spacing float64) []models.GeoPoint {
+	samples := []models.GeoPoint{points[0]}
+	since := 0.0 // 마지막 표본 이후 이동 거리
+	for i := 1; i < len(points); i++ {
+		a, b := points[i-1], points[i]
+		d := analysis.Haversine(a, b)
+		pos := spacing - since
+		for ; pos < d; pos += spacing {
+			t := pos / d
+			samples = append(samples, models.GeoPoint{
+				Latitude:  a.Latitude + t*(b.Latitude-a.Latitude),
+				Longitude: a.Longitude + t*(b.Longitude-a.Longitude),
+			})
+		}
+		since = d - (pos - spacing)
+	}
+	return append(samples, points[len(points)-1])
+}
@@ -3,18 +3,34 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	MongoURI   string
-	DBName     string
-	JWTSecret  string
-	ServerPort string
-	LogDir     string
-	LogLevel   string
-	GinMode    string
+	MongoURI        string
+	DBName          string
+	JWTSecret       string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	ServerPort      string
+	LogDir          string
+	LogLevel        string
+	GinMode         string
+	DEMDir          string // SRTM .hgt/GeoTIFF 고도 타일 디렉터리 (비어 있으면 DEM 고도 보정 안 함)
+	OSMFile         string // 경로 탐색에 사용할 OpenStreetMap .osm.pbf 추출 파일 (비어 있으면 경로 탐색 안 함)
+
+	// 라이드 트랙 정제 (track 패키지)
+	TrackCleaning          bool          // false이면 입력 트랙을 그대로 저장
+	TrackMaxSpeed          float64       // 이 속도(km/h)를 넘어 튀는 지점 제거 (0이면 검사 안 함)
+	TrackMaxAccuracy       float64       // 위치 정확도가 이 값(미터)보다 나쁜 지점 제거 (0이면 검사 안 함)
+	TrackSmoothing         bool          // 칼만 평활화 여부
+	TrackPauseRadius       float64       // 자동 일시정지 반경 (미터, 0이면 검출 안 함)
+	TrackPauseDuration     time.Duration // 자동 일시정지 최소 시간
+	TrackSimplify          string        // 표시용 단순화 방식 (douglas-peucker, visvalingam, none)
+	TrackSimplifyTolerance float64       // 단순화 허용 오차 (미터)
 }
 
 var cfg *Config
@@ -25,13 +41,26 @@ func Load() *Config {
 	}
 
 	cfg = &Config{
-		MongoURI:   getEnv("MONGO_URI", "mongodb://localhost:27017"),
-		DBName:     getEnv("DB_NAME", "gobike"),
-		JWTSecret:  getEnv("JWT_SECRET", "your-secret-key"),
-		ServerPort: getEnv("PORT", "8080"),
-		LogDir:     getEnv("LOG_DIR", "logs"),
-		LogLevel:   getEnv("LOG_LEVEL", "DEBUG"),
-		GinMode:    getEnv("GIN_MODE", "release"),
+		MongoURI:        getEnv("MONGO_URI", "mongodb://localhost:27017"),
+		DBName:          getEnv("DB_NAME", "gobike"),
+		JWTSecret:       getEnv("JWT_SECRET", "your-secret-key"),
+		AccessTokenTTL:  getDurationEnv("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL: getDurationEnv("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+		ServerPort:      getEnv("PORT", "8080"),
+		LogDir:          getEnv("LOG_DIR", "logs"),
+		LogLevel:        getEnv("LOG_LEVEL", "DEBUG"),
+		GinMode:         getEnv("GIN_MODE", "release"),
+		DEMDir:          getEnv("DEM_DIR", ""),
+		OSMFile:         getEnv("OSM_FILE", ""),
+
+		TrackCleaning:          getBoolEnv("TRACK_CLEANING", true),
+		TrackMaxSpeed:          getFloatEnv("TRACK_MAX_SPEED", 100),
+		TrackMaxAccuracy:       getFloatEnv("TRACK_MAX_ACCURACY", 50),
+		TrackSmoothing:         getBoolEnv("TRACK_SMOOTHING", true),
+		TrackPauseRadius:       getFloatEnv("TRACK_PAUSE_RADIUS", 15),
+		TrackPauseDuration:     getDurationEnv("TRACK_PAUSE_DURATION", 20*time.Second),
+		TrackSimplify:          getEnv("TRACK_SIMPLIFY", "douglas-peucker"),
+		TrackSimplifyTolerance: getFloatEnv("TRACK_SIMPLIFY_TOLERANCE", 5),
 	}
 
 	if err := validateConfig(cfg); err != nil {
@@ -46,6 +75,9 @@ func validateConfig(cfg *Config) error {
 	if cfg.JWTSecret == "" {
 		return fmt.Errorf("JWT_SECRET is required")
 	}
+	if cfg.AccessTokenTTL <= 0 || cfg.RefreshTokenTTL <= 0 {
+		return fmt.Errorf("ACCESS_TOKEN_TTL and REFRESH_TOKEN_TTL must be positive")
+	}
 	if cfg.MongoURI == "" {
 		return fmt.Errorf("MONGO_URI is required")
 	}
@@ -66,3 +98,45 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+// getDurationEnv는 "15m", "720h" 형식의 환경변수를 time.Duration으로 변환합니다
+func getDurationEnv(key string, fallback time.Duration) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		fmt.Printf("Warning: %s 값(%s)을 해석할 수 없습니다. 기본값 %s를 사용합니다.\n", key, value, fallback)
+		return fallback
+	}
+	return d
+}
+
+// getFloatEnv는 숫자 환경변수를 float64로 변환합니다
+func getFloatEnv(key string, fallback float64) float64 {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		fmt.Printf("Warning: %s 값(%s)을 해석할 수 없습니다. 기본값 %v를 사용합니다.\n", key, value, fallback)
+		return fallback
+	}
+	return f
+}
+
+// getBoolEnv는 "true", "false", "1", "0" 형식의 환경변수를 bool로 변환합니다
+func getBoolEnv(key string, fallback bool) bool {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		fmt.Printf("Warning: %s 값(%s)을 해석할 수 없습니다. 기본값 %v를 사용합니다.\n", key, value, fallback)
+		return fallback
+	}
+	return b
+}
@@ -10,9 +10,16 @@ import (
 )
 
 var (
-	COL_NAME_RIDES  = "rides"
-	COL_NAME_ROUTES = "routes"
-	COL_NAME_USERS  = "users"
+	COL_NAME_RIDES       = "rides"
+	COL_NAME_ROUTES      = "routes"
+	COL_NAME_USERS       = "users"
+	COL_NAME_TOKENS      = "refresh_tokens"
+	COL_NAME_LIVE        = "live_sessions"
+	COL_NAME_RECORDS     = "personal_records"
+	COL_NAME_SEGMENTS    = "segments"
+	COL_NAME_EFFORTS     = "segment_efforts"
+	COL_NAME_TRACKS      = "ride_tracks"
+	COL_NAME_LIVE_CHUNKS = "live_session_chunks"
 )
 
 type Collection struct {
@@ -20,11 +27,18 @@ type Collection struct {
 }
 
 type MongoDB struct {
-	client *mongo.Client
-	db     *mongo.Database
-	Rides  *Collection
-	Routes *Collection
-	Users  *Collection
+	client     *mongo.Client
+	db         *mongo.Database
+	Rides      *Collection
+	Routes     *Collection
+	Users      *Collection
+	Tokens     *Collection
+	Live       *Collection
+	Records    *Collection
+	Segments   *Collection
+	Efforts    *Collection
+	Tracks     *Collection
+	LiveChunks *Collection
 }
 
 func NewMongoDB(uri, dbName string) (*MongoDB, error) {
@@ -35,13 +49,128 @@ func NewMongoDB(uri, dbName string) (*MongoDB, error) {
 
 	db := client.Database(dbName)
 
-	return &MongoDB{
-		client: client,
-		db:     db,
-		Rides:  &Collection{collection: db.Collection(COL_NAME_RIDES)},
-		Routes: &Collection{collection: db.Collection(COL_NAME_ROUTES)},
-		Users:  &Collection{collection: db.Collection(COL_NAME_USERS)},
-	}, nil
+	m := &MongoDB{
+		client:     client,
+		db:         db,
+		Rides:      &Collection{collection: db.Collection(COL_NAME_RIDES)},
+		Routes:     &Collection{collection: db.Collection(COL_NAME_ROUTES)},
+		Users:      &Collection{collection: db.Collection(COL_NAME_USERS)},
+		Tokens:     &Collection{collection: db.Collection(COL_NAME_TOKENS)},
+		Live:       &Collection{collection: db.Collection(COL_NAME_LIVE)},
+		Records:    &Collection{collection: db.Collection(COL_NAME_RECORDS)},
+		Segments:   &Collection{collection: db.Collection(COL_NAME_SEGMENTS)},
+		Efforts:    &Collection{collection: db.Collection(COL_NAME_EFFORTS)},
+		Tracks:     &Collection{collection: db.Collection(COL_NAME_TRACKS)},
+		LiveChunks: &Collection{collection: db.Collection(COL_NAME_LIVE_CHUNKS)},
+	}
+
+	if err := m.ensureIndexes(); err != nil {
+		client.Disconnect(context.Background())
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// 컬렉션별 인덱스 생성 (이미 존재하면 무시됨)
+func (m *MongoDB) ensureIndexes() error {
+	// 리프레시 토큰: 해시 조회용 유니크 인덱스, 패밀리/사용자별 폐기용 인덱스, 만료 시 자동 삭제
+	_, err := m.Tokens.collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "token_hash", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "family_id", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// 라이드: 사용자별 기간 조회/통계용
+	_, err = m.Rides.collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "start_time", Value: -1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// 경로: 사용자별 목록 조회, 시작 지점 주변/영역 검색, 경로 선이 지나는 영역 검색,
+	// 이름/설명 전문 검색 (한국어 등 다국어 텍스트이므로 영어 형태소 처리를 끔)
+	_, err = m.Routes.collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "start_location", Value: "2dsphere"}}},
+		{Keys: bson.D{{Key: "geometry", Value: "2dsphere"}}},
+		{
+			Keys: bson.D{{Key: "name", Value: "text"}, {Key: "description", Value: "text"}},
+			Options: options.Index().
+				SetWeights(bson.M{"name": 3, "description": 1}).
+				SetDefaultLanguage("none"),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// 실시간 세션: 사용자당 진행 중인 세션은 하나만 허용
+	_, err = m.Live.collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"status": "active"}),
+	})
+	if err != nil {
+		return err
+	}
+
+	// 개인 기록: 사용자별 종목당 하나
+	_, err = m.Records.collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "key", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	// 구간: 라이드 매칭 후보를 찾기 위한 시작/종료 지점 공간 인덱스, 만든 사용자별 조회
+	_, err = m.Segments.collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "start_location", Value: "2dsphere"}}},
+		{Keys: bson.D{{Key: "end_location", Value: "2dsphere"}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// 구간 기록: 순위표(구간별 기록순), 사용자별 이력, 라이드 삭제 시 정리
+	_, err = m.Efforts.collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "segment_id", Value: 1}, {Key: "elapsed_time", Value: 1}}},
+		{Keys: bson.D{{Key: "segment_id", Value: 1}, {Key: "user_id", Value: 1}, {Key: "start_time", Value: -1}}},
+		{Keys: bson.D{{Key: "ride_id", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// 라이드 트랙 묶음: 라이드별 종류마다 순서대로 읽음
+	_, err = m.Tracks.collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "ride_id", Value: 1}, {Key: "kind", Value: 1}, {Key: "seq", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	// 실시간 세션 위치 묶음: 세션별 순번당 하나
+	_, err = m.LiveChunks.collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "session_id", Value: 1}, {Key: "seq", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
 }
 
 // Collection 구조체의 메서드들
@@ -53,14 +182,27 @@ func (c *Collection) Create(document interface{}) (primitive.ObjectID, error) {
 	return result.InsertedID.(primitive.ObjectID), nil
 }
 
-func (c *Collection) ReadOne(filter interface{}, result interface{}) error {
-	err := c.collection.FindOne(context.Background(), filter).Decode(result)
+func (c *Collection) ReadOne(filter interface{}, result interface{}, opts ...*options.FindOneOptions) error {
+	err := c.collection.FindOne(context.Background(), filter, opts...).Decode(result)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// filters[i]에 맞는 문서를 documents[i]로 교체하고, 없으면 새로 생성 (한 번의 요청으로 순서대로 처리)
+func (c *Collection) UpsertMany(filters []interface{}, documents []interface{}) error {
+	if len(documents) == 0 {
+		return nil
+	}
+	models := make([]mongo.WriteModel, len(documents))
+	for i, doc := range documents {
+		models[i] = mongo.NewReplaceOneModel().SetFilter(filters[i]).SetReplacement(doc).SetUpsert(true)
+	}
+	_, err := c.collection.BulkWrite(context.Background(), models)
+	return err
+}
+
 func (c *Collection) ReadMany(filter interface{}) ([]bson.M, error) {
 	cursor, err := c.collection.Find(context.Background(), filter)
 	if err != nil {
@@ -75,6 +217,66 @@ func (c *Collection) ReadMany(filter interface{}) ([]bson.M, error) {
 	return results, nil
 }
 
+// 정렬/건너뛰기/개수 제한을 적용한 조회 (limit이 0이면 제한 없음)
+// extra 옵션(프로젝션 등)은 기본 옵션 뒤에 병합됨
+func (c *Collection) ReadManyPaged(filter interface{}, sort interface{}, skip, limit int64, extra ...*options.FindOptions) ([]bson.M, error) {
+	opts := options.Find().SetSkip(skip)
+	if sort != nil {
+		opts.SetSort(sort)
+	}
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := c.collection.Find(context.Background(), filter, append([]*options.FindOptions{opts}, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	var results []bson.M
+	if err = cursor.All(context.Background(), &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// 조회 결과를 모두 메모리에 올리지 않고 문서마다 fn 호출
+// fn이 오류를 반환하면 중단하고 그 오류를 반환
+func (c *Collection) ReadEach(filter interface{}, fn func(doc bson.Raw) error, opts ...*options.FindOptions) error {
+	cursor, err := c.collection.Find(context.Background(), filter, opts...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(context.Background())
+
+	for cursor.Next(context.Background()) {
+		if err := fn(cursor.Current); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// 집계 파이프라인 실행
+func (c *Collection) Aggregate(pipeline interface{}) ([]bson.M, error) {
+	cursor, err := c.collection.Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	var results []bson.M
+	if err = cursor.All(context.Background(), &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (c *Collection) Count(filter interface{}) (int64, error) {
+	return c.collection.CountDocuments(context.Background(), filter)
+}
+
 func (c *Collection) Update(filter interface{}, update interface{}) error {
 	_, err := c.collection.UpdateOne(
 		context.Background(),
@@ -84,11 +286,50 @@ func (c *Collection) Update(filter interface{}, update interface{}) error {
 	return err
 }
 
+// 조건에 맞는 문서를 document로 교체하고, 없으면 새로 생성
+func (c *Collection) Upsert(filter interface{}, document interface{}) error {
+	_, err := c.collection.ReplaceOne(
+		context.Background(),
+		filter,
+		document,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+func (c *Collection) UpdateMany(filter interface{}, update interface{}) (int64, error) {
+	result, err := c.collection.UpdateMany(
+		context.Background(),
+		filter,
+		update,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// 조건에 맞는 문서 하나를 원자적으로 수정하고 수정 전 문서를 result에 저장
+// (opts로 수정 후 문서 반환, 프로젝션 등을 지정 가능)
+// 조건에 맞는 문서가 없으면 mongo.ErrNoDocuments 반환
+func (c *Collection) FindOneAndUpdate(filter interface{}, update interface{}, result interface{}, opts ...*options.FindOneAndUpdateOptions) error {
+	return c.collection.FindOneAndUpdate(context.Background(), filter, update, opts...).Decode(result)
+}
+
 func (c *Collection) Delete(filter interface{}) error {
 	_, err := c.collection.DeleteOne(context.Background(), filter)
 	return err
 }
 
+// 조건에 맞는 문서를 모두 삭제하고 삭제된 개수 반환
+func (c *Collection) DeleteMany(filter interface{}) (int64, error) {
+	result, err := c.collection.DeleteMany(context.Background(), filter)
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
 // MongoDB 연결 종료
 func (m *MongoDB) Close() error {
 	return m.client.Disconnect(context.Background())
@@ -0,0 +1,195 @@
+// elevation 패키지는 로컬 DEM(수치 표고 모델) 타일에서 지점의 고도를 조회합니다.
+// SRTM .hgt 타일과 경위도 좌표계의 GeoTIFF 타일을 지원합니다.
+package elevation
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+// 메모리에 유지하는 타일 수 (1초 해상도 SRTM 타일 하나가 약 25MB)
+const maxCachedTiles = 8
+
+// Service DEM 타일 디렉터리에서 고도를 조회
+// nil Service는 고도를 조회하지 않음 (DEM 미설정)
+type Service struct {
+	dir   string
+	cells map[[2]int][]*tile // 1도 격자(남서쪽 모서리 위도, 경도)별로 걸치는 타일
+	count int
+
+	mu     sync.Mutex
+	cached []*tile // 최근에 사용한 순서
+}
+
+// 타일 파일과 범위, 읽어 들인 표고 격자
+type tile struct {
+	path                     string
+	kind                     string // "hgt" 또는 "tiff"
+	south, west, north, east float64
+
+	grid   *grid
+	failed bool // 읽기에 실패한 타일은 다시 시도하지 않음
+}
+
+// New dir의 .hgt, .tif, .tiff 파일로 Service 생성
+// 타일 데이터는 처음 조회할 때 읽으며, dir가 비어 있으면 nil 반환
+// 형식이 잘못된 파일은 건너뜀
+func New(dir string) (*Service, error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read dem directory: %w", err)
+	}
+
+	s := &Service{dir: dir, cells: make(map[[2]int][]*tile)}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+
+		var t *tile
+		switch strings.ToLower(filepath.Ext(e.Name())) {
+		case ".hgt":
+			t, err = hgtTile(path)
+		case ".tif", ".tiff":
+			t, err = tiffTile(path)
+		default:
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		s.add(t)
+	}
+	return s, nil
+}
+
+// Dir 타일 디렉터리
+func (s *Service) Dir() string {
+	if s == nil {
+		return ""
+	}
+	return s.dir
+}
+
+// Tiles 사용할 수 있는 타일 수
+func (s *Service) Tiles() int {
+	if s == nil {
+		return 0
+	}
+	return s.count
+}
+
+// 북쪽/동쪽 가장자리 위의 지점은 다음 격자에 속하므로 가장자리가 닿는 격자에도 등록
+func (s *Service) add(t *tile) {
+	for lat := int(math.Floor(t.south)); float64(lat) <= t.north; lat++ {
+		for lon := int(math.Floor(t.west)); float64(lon) <= t.east; lon++ {
+			key := [2]int{lat, lon}
+			s.cells[key] = append(s.cells[key], t)
+		}
+	}
+	s.count++
+}
+
+// Lookup 위도/경도 지점의 고도 (미터, 주변 네 표고값의 쌍선형 보간)
+// 지점을 덮는 타일이 없거나 표고값이 모두 비어 있으면 false
+func (s *Service) Lookup(lat, lon float64) (float64, bool) {
+	if s == nil {
+		return 0, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lookup(lat, lon)
+}
+
+// Apply 트랙 지점의 고도를 DEM 값으로 채우거나 교정
+// 모든 지점이 DEM 범위 안에 있으면 모든 고도를 DEM 값으로 바꾸고 true 반환
+// (기기마다 다른 GPS 고도 오차를 없애고 한 가지 기준으로 맞춤)
+// 일부만 범위 안에 있으면 고도가 없는 지점만 채움
+func (s *Service) Apply(points []models.GeoPoint) bool {
+	if s == nil || len(points) == 0 {
+		return false
+	}
+
+	s.mu.Lock()
+	values := make([]*float64, len(points))
+	covered := true
+	for i, p := range points {
+		if v, ok := s.lookup(p.Latitude, p.Longitude); ok {
+			v = math.Round(v*10) / 10
+			values[i] = &v
+		} else {
+			covered = false
+		}
+	}
+	s.mu.Unlock()
+
+	for i, v := range values {
+		if v != nil && (covered || points[i].Elevation == nil) {
+			points[i].Elevation = v
+		}
+	}
+	return covered
+}
+
+// 호출자가 s.mu를 잠근 상태여야 함
+func (s *Service) lookup(lat, lon float64) (float64, bool) {
+	for _, t := range s.cells[[2]int{int(math.Floor(lat)), int(math.Floor(lon))}] {
+		if lat < t.south || lat > t.north || lon < t.west || lon > t.east {
+			continue
+		}
+		g := s.load(t)
+		if g == nil {
+			continue
+		}
+		if v, ok := g.interpolate(lat, lon); ok {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// 타일의 표고 격자를 읽어 캐시에 보관 (가장 오래 사용하지 않은 타일부터 해제)
+func (s *Service) load(t *tile) *grid {
+	for i, c := range s.cached {
+		if c == t {
+			copy(s.cached[1:i+1], s.cached[:i])
+			s.cached[0] = t
+			return t.grid
+		}
+	}
+	if t.failed {
+		return nil
+	}
+
+	var g *grid
+	var err error
+	switch t.kind {
+	case "hgt":
+		g, err = readHGT(t.path)
+	case "tiff":
+		g, err = readGeoTIFF(t.path)
+	}
+	if err != nil {
+		t.failed = true
+		return nil
+	}
+
+	t.grid = g
+	s.cached = append([]*tile{t}, s.cached...)
+	if len(s.cached) > maxCachedTiles {
+		s.cached[len(s.cached)-1].grid = nil
+		s.cached = s.cached[:len(s.cached)-1]
+	}
+	return g
+}
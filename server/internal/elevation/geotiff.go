@@ -0,0 +1,425 @@
+package elevation
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// 사용하는 TIFF/GeoTIFF 태그
+const (
+	tagImageWidth      = 256
+	tagImageLength     = 257
+	tagBitsPerSample   = 258
+	tagCompression     = 259
+	tagStripOffsets    = 273
+	tagSamplesPerPixel = 277
+	tagRowsPerStrip    = 278
+	tagStripByteCounts = 279
+	tagPredictor       = 317
+	tagTileWidth       = 322
+	tagTileLength      = 323
+	tagTileOffsets     = 324
+	tagTileByteCounts  = 325
+	tagSampleFormat    = 339
+	tagModelPixelScale = 33550
+	tagModelTiepoint   = 33922
+	tagGeoKeyDirectory = 34735
+	tagGDALNoData      = 42113
+)
+
+// GeoKey
+const (
+	geoKeyModelType  = 1024 // 2: 경위도 좌표계
+	geoKeyRasterType = 1025 // 1: 화소가 영역을 나타냄 (기본값), 2: 화소가 지점을 나타냄
+)
+
+// 지원하는 압축 방식 (LZW는 지원하지 않음)
+const (
+	compressionNone        = 1
+	compressionDeflate     = 8
+	compressionDeflateOld  = 32946
+	predictorNone          = 1
+	predictorHorizontal    = 2
+	sampleFormatUint       = 1
+	sampleFormatInt        = 2
+	sampleFormatFloat      = 3
+	modelTypeGeographic    = 2
+	rasterTypePixelIsPoint = 2
+)
+
+// 첫 번째 이미지(IFD)의 태그 값
+type tiffImage struct {
+	order         binary.ByteOrder
+	width, height int
+	bits          int
+	format        int
+	compression   int
+	predictor     int
+	rowsPerStrip  int
+	tileWidth     int
+	tileHeight    int
+	offsets       []uint64
+	counts        []uint64
+
+	// 표고값 (0, 0)의 위치와 간격 (도)
+	north, west float64
+	dLat, dLon  float64
+	pixelIsArea bool
+
+	noData    float64
+	hasNoData bool
+}
+
+// GeoTIFF 헤더에서 타일 범위를 구함
+// 화소가 영역을 나타내는 타일은 화소 가장자리까지를 범위로 함
+func tiffTile(path string) (*tile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	img, err := readTIFFImage(f)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &tile{
+		path:  path,
+		kind:  "tiff",
+		north: img.north,
+		west:  img.west,
+		south: img.north - img.dLat*float64(img.height-1),
+		east:  img.west + img.dLon*float64(img.width-1),
+	}
+	if img.pixelIsArea {
+		t.north += img.dLat / 2
+		t.south -= img.dLat / 2
+		t.west -= img.dLon / 2
+		t.east += img.dLon / 2
+	}
+	return t, nil
+}
+
+// 단일 밴드 GeoTIFF 읽기 (16/32비트 정수, 32비트 실수, 무압축 또는 Deflate)
+func readGeoTIFF(path string) (*grid, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	img, err := readTIFFImage(f)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := img.decode(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return &grid{
+		north:   img.north,
+		west:    img.west,
+		dLat:    img.dLat,
+		dLon:    img.dLon,
+		width:   img.width,
+		height:  img.height,
+		samples: float32Samples{data: data, noData: img.noData, hasNoData: img.hasNoData},
+	}, nil
+}
+
+// TIFF 헤더와 첫 번째 IFD를 읽고 지원하는 형식인지 확인
+func readTIFFImage(r io.ReaderAt) (*tiffImage, error) {
+	header := make([]byte, 8)
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return nil, fmt.Errorf("read tiff header: %w", err)
+	}
+
+	var order binary.ByteOrder
+	switch string(header[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("not a tiff file")
+	}
+	if order.Uint16(header[2:]) != 42 {
+		return nil, fmt.Errorf("unsupported tiff version (BigTIFF is not supported)")
+	}
+
+	tags, err := readIFD(r, order, int64(order.Uint32(header[4:])))
+	if err != nil {
+		return nil, err
+	}
+
+	img := &tiffImage{
+		order:        order,
+		width:        int(tags.uint(tagImageWidth, 0)),
+		height:       int(tags.uint(tagImageLength, 0)),
+		bits:         int(tags.uint(tagBitsPerSample, 1)),
+		format:       int(tags.uint(tagSampleFormat, sampleFormatUint)),
+		compression:  int(tags.uint(tagCompression, compressionNone)),
+		predictor:    int(tags.uint(tagPredictor, predictorNone)),
+		rowsPerStrip: int(tags.uint(tagRowsPerStrip, 0)),
+		tileWidth:    int(tags.uint(tagTileWidth, 0)),
+		tileHeight:   int(tags.uint(tagTileLength, 0)),
+		offsets:      tags.uints(tagStripOffsets),
+		counts:       tags.uints(tagStripByteCounts),
+	}
+	if img.tileWidth > 0 {
+		img.offsets = tags.uints(tagTileOffsets)
+		img.counts = tags.uints(tagTileByteCounts)
+	}
+	if img.rowsPerStrip <= 0 || img.rowsPerStrip > img.height {
+		img.rowsPerStrip = img.height
+	}
+
+	switch {
+	case img.width < 2 || img.height < 2:
+		return nil, fmt.Errorf("tiff image is too small")
+	case tags.uint(tagSamplesPerPixel, 1) != 1:
+		return nil, fmt.Errorf("tiff image must have a single band")
+	case img.bits != 16 && img.bits != 32:
+		return nil, fmt.Errorf("unsupported bits per sample %d", img.bits)
+	case img.format == sampleFormatFloat && img.bits != 32,
+		img.format != sampleFormatUint && img.format != sampleFormatInt && img.format != sampleFormatFloat:
+		return nil, fmt.Errorf("unsupported sample format %d", img.format)
+	case img.compression != compressionNone && img.compression != compressionDeflate && img.compression != compressionDeflateOld:
+		return nil, fmt.Errorf("unsupported compression %d", img.compression)
+	case img.predictor != predictorNone && (img.predictor != predictorHorizontal || img.format == sampleFormatFloat):
+		return nil, fmt.Errorf("unsupported predictor %d", img.predictor)
+	case len(img.offsets) == 0 || len(img.offsets) != len(img.counts):
+		return nil, fmt.Errorf("tiff image has no data")
+	}
+
+	// 경위도 좌표계만 지원
+	keys := tags.uints(tagGeoKeyDirectory)
+	if geoKey(keys, geoKeyModelType, modelTypeGeographic) != modelTypeGeographic {
+		return nil, fmt.Errorf("only geographic (lat/lon) geotiff is supported")
+	}
+	img.pixelIsArea = geoKey(keys, geoKeyRasterType, 1) != rasterTypePixelIsPoint
+
+	scale := tags.floats(tagModelPixelScale)
+	tie := tags.floats(tagModelTiepoint)
+	if len(scale) < 2 || len(tie) < 6 || scale[0] <= 0 || scale[1] <= 0 {
+		return nil, fmt.Errorf("geotiff has no pixel scale or tiepoint")
+	}
+	img.dLon, img.dLat = scale[0], scale[1]
+	img.west = tie[3] - tie[0]*img.dLon
+	img.north = tie[4] + tie[1]*img.dLat
+	if img.pixelIsArea {
+		// 화소 중심을 표고값의 위치로 사용
+		img.west += img.dLon / 2
+		img.north -= img.dLat / 2
+	}
+
+	if v := strings.Trim(tags.ascii(tagGDALNoData), "\x00 "); v != "" {
+		if noData, err := strconv.ParseFloat(v, 64); err == nil {
+			img.noData, img.hasNoData = noData, true
+		}
+	}
+	return img, nil
+}
+
+// 모든 스트립/타일의 표고값을 행 우선 순서로 읽음
+func (img *tiffImage) decode(r io.ReaderAt) ([]float32, error) {
+	chunkWidth, chunkHeight := img.width, img.rowsPerStrip
+	if img.tileWidth > 0 {
+		chunkWidth, chunkHeight = img.tileWidth, img.tileHeight
+	}
+	across := (img.width + chunkWidth - 1) / chunkWidth
+	size := img.bits / 8
+	mask := uint32(1<<img.bits - 1)
+
+	out := make([]float32, img.width*img.height)
+	for i, offset := range img.offsets {
+		buf := make([]byte, img.counts[i])
+		if _, err := r.ReadAt(buf, int64(offset)); err != nil {
+			return nil, fmt.Errorf("read tiff chunk %d: %w", i, err)
+		}
+		if img.compression != compressionNone {
+			zr, err := zlib.NewReader(bytes.NewReader(buf))
+			if err != nil {
+				return nil, fmt.Errorf("inflate tiff chunk %d: %w", i, err)
+			}
+			buf, err = io.ReadAll(zr)
+			zr.Close()
+			if err != nil {
+				return nil, fmt.Errorf("inflate tiff chunk %d: %w", i, err)
+			}
+		}
+
+		x0 := (i % across) * chunkWidth
+		y0 := (i / across) * chunkHeight
+		rows := min(len(buf)/(chunkWidth*size), chunkHeight)
+		for row := 0; row < rows; row++ {
+			y := y0 + row
+			if y >= img.height {
+				break
+			}
+			var prev uint32
+			for col := 0; col < chunkWidth; col++ {
+				raw := img.sample(buf[(row*chunkWidth+col)*size:])
+				if img.predictor == predictorHorizontal && col > 0 {
+					raw = (raw + prev) & mask
+				}
+				prev = raw
+
+				if x := x0 + col; x < img.width {
+					out[y*img.width+x] = img.value(raw)
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+// 표고값 하나의 비트 (바이트 순서만 맞춤)
+func (img *tiffImage) sample(b []byte) uint32 {
+	if img.bits == 16 {
+		return uint32(img.order.Uint16(b))
+	}
+	return img.order.Uint32(b)
+}
+
+// 표고값 비트를 형식에 맞게 해석
+func (img *tiffImage) value(raw uint32) float32 {
+	switch {
+	case img.format == sampleFormatFloat:
+		return math.Float32frombits(raw)
+	case img.format == sampleFormatInt && img.bits == 16:
+		return float32(int16(raw))
+	case img.format == sampleFormatInt:
+		return float32(int32(raw))
+	}
+	return float32(raw)
+}
+
+// GeoKeyDirectory에서 key의 값 (헤더 4개 값 뒤에 키마다 id, 위치, 개수, 값)
+// 위치가 0인 (값을 직접 담은) 키만 찾으며, 없으면 fallback
+func geoKey(keys []uint64, id, fallback uint64) uint64 {
+	for i := 4; i+3 < len(keys); i += 4 {
+		if keys[i] == id && keys[i+1] == 0 {
+			return keys[i+3]
+		}
+	}
+	return fallback
+}
+
+// IFD 항목 (태그별 원본 값)
+type ifdEntry struct {
+	typ   uint16
+	count uint32
+	data  []byte
+	order binary.ByteOrder
+}
+
+type ifd map[uint16]ifdEntry
+
+// TIFF 값 형식별 크기 (바이트)
+var tiffTypeSizes = map[uint16]int{
+	1:  1, // BYTE
+	2:  1, // ASCII
+	3:  2, // SHORT
+	4:  4, // LONG
+	12: 8, // DOUBLE
+}
+
+func readIFD(r io.ReaderAt, order binary.ByteOrder, offset int64) (ifd, error) {
+	countBuf := make([]byte, 2)
+	if _, err := r.ReadAt(countBuf, offset); err != nil {
+		return nil, fmt.Errorf("read tiff ifd: %w", err)
+	}
+	n := int(order.Uint16(countBuf))
+
+	entries := make([]byte, n*12)
+	if _, err := r.ReadAt(entries, offset+2); err != nil {
+		return nil, fmt.Errorf("read tiff ifd: %w", err)
+	}
+
+	tags := make(ifd, n)
+	for i := 0; i < n; i++ {
+		e := entries[i*12 : (i+1)*12]
+		entry := ifdEntry{
+			typ:   order.Uint16(e[2:]),
+			count: order.Uint32(e[4:]),
+			order: order,
+		}
+		size, ok := tiffTypeSizes[entry.typ]
+		if !ok {
+			continue
+		}
+		length := size * int(entry.count)
+		if length <= 4 {
+			entry.data = e[8 : 8+length]
+		} else {
+			entry.data = make([]byte, length)
+			if _, err := r.ReadAt(entry.data, int64(order.Uint32(e[8:]))); err != nil {
+				return nil, fmt.Errorf("read tiff tag %d: %w", order.Uint16(e), err)
+			}
+		}
+		tags[order.Uint16(e)] = entry
+	}
+	return tags, nil
+}
+
+// 정수형 태그 값 목록
+func (t ifd) uints(tag uint16) []uint64 {
+	e, ok := t[tag]
+	if !ok {
+		return nil
+	}
+	values := make([]uint64, e.count)
+	for i := range values {
+		switch e.typ {
+		case 1:
+			values[i] = uint64(e.data[i])
+		case 3:
+			values[i] = uint64(e.order.Uint16(e.data[i*2:]))
+		case 4:
+			values[i] = uint64(e.order.Uint32(e.data[i*4:]))
+		default:
+			return nil
+		}
+	}
+	return values
+}
+
+// 정수형 태그의 첫 번째 값 (없으면 fallback)
+func (t ifd) uint(tag uint16, fallback uint64) uint64 {
+	if values := t.uints(tag); len(values) > 0 {
+		return values[0]
+	}
+	return fallback
+}
+
+// DOUBLE 태그 값 목록
+func (t ifd) floats(tag uint16) []float64 {
+	e, ok := t[tag]
+	if !ok || e.typ != 12 {
+		return nil
+	}
+	values := make([]float64, e.count)
+	for i := range values {
+		values[i] = math.Float64frombits(e.order.Uint64(e.data[i*8:]))
+	}
+	return values
+}
+
+func (t ifd) ascii(tag uint16) string {
+	e, ok := t[tag]
+	if !ok || e.typ != 2 {
+		return ""
+	}
+	return string(e.data)
+}
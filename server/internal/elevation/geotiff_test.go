@@ -0,0 +1,268 @@
+package elevation
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// 테스트용 TIFF 파일 (헤더, 스트립, IFD, IFD 밖 태그 값 순서)
+// 태그 값은 []uint16(SHORT), []uint32(LONG), []float64(DOUBLE), string(ASCII)
+// StripOffsets/StripByteCounts는 strips로 채움
+func encodeTIFF(t *testing.T, order binary.ByteOrder, tags map[uint16]any, strips [][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if order == binary.LittleEndian {
+		buf.WriteString("II")
+	} else {
+		buf.WriteString("MM")
+	}
+	binary.Write(&buf, order, uint16(42))
+	binary.Write(&buf, order, uint32(0)) // IFD 위치는 나중에 채움
+
+	var offsets, counts []uint32
+	for _, s := range strips {
+		offsets = append(offsets, uint32(buf.Len()))
+		counts = append(counts, uint32(len(s)))
+		buf.Write(s)
+		if buf.Len()%2 == 1 {
+			buf.WriteByte(0)
+		}
+	}
+	tags[tagStripOffsets] = offsets
+	tags[tagStripByteCounts] = counts
+
+	ids := make([]int, 0, len(tags))
+	for id := range tags {
+		ids = append(ids, int(id))
+	}
+	sort.Ints(ids)
+
+	ifdOffset := buf.Len()
+	extraOffset := ifdOffset + 2 + len(ids)*12 + 4
+	var entries, extra bytes.Buffer
+	binary.Write(&entries, order, uint16(len(ids)))
+	for _, id := range ids {
+		var typ uint16
+		var count int
+		var value bytes.Buffer
+		switch v := tags[uint16(id)].(type) {
+		case []uint16:
+			typ, count = 3, len(v)
+			binary.Write(&value, order, v)
+		case []uint32:
+			typ, count = 4, len(v)
+			binary.Write(&value, order, v)
+		case []float64:
+			typ, count = 12, len(v)
+			binary.Write(&value, order, v)
+		case string:
+			typ, count = 2, len(v)+1
+			value.WriteString(v)
+			value.WriteByte(0)
+		default:
+			t.Fatalf("tag %d: unsupported value %T", id, v)
+		}
+		binary.Write(&entries, order, uint16(id))
+		binary.Write(&entries, order, typ)
+		binary.Write(&entries, order, uint32(count))
+		if value.Len() <= 4 {
+			entries.Write(append(value.Bytes(), make([]byte, 4-value.Len())...))
+			continue
+		}
+		binary.Write(&entries, order, uint32(extraOffset+extra.Len()))
+		extra.Write(value.Bytes())
+		if extra.Len()%2 == 1 {
+			extra.WriteByte(0)
+		}
+	}
+	binary.Write(&entries, order, uint32(0))
+
+	data := append(buf.Bytes(), entries.Bytes()...)
+	data = append(data, extra.Bytes()...)
+	order.PutUint32(data[4:], uint32(ifdOffset))
+	return data
+}
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// 경위도 좌표계 GeoKeyDirectory (rasterType 1: 영역, 2: 지점)
+func geoKeys(rasterType uint16) []uint16 {
+	return []uint16{1, 1, 0, 2, geoKeyModelType, 0, 1, modelTypeGeographic, geoKeyRasterType, 0, 1, rasterType}
+}
+
+func TestReadGeoTIFFFloat(t *testing.T) {
+	// 화소가 지점을 나타내는 3×3 float32, 북서쪽 (38, 127)에서 0.5도 간격
+	values := []float32{100, 200, 300, 400, 500, 600, 700, 800, -9999}
+	var pixels bytes.Buffer
+	binary.Write(&pixels, binary.LittleEndian, values)
+
+	path := writeFile(t, "dem.tif", encodeTIFF(t, binary.LittleEndian, map[uint16]any{
+		tagImageWidth:      []uint32{3},
+		tagImageLength:     []uint32{3},
+		tagBitsPerSample:   []uint16{32},
+		tagSampleFormat:    []uint16{sampleFormatFloat},
+		tagModelPixelScale: []float64{0.5, 0.5, 0},
+		tagModelTiepoint:   []float64{0, 0, 0, 127, 38, 0},
+		tagGeoKeyDirectory: geoKeys(rasterTypePixelIsPoint),
+		tagGDALNoData:      "-9999",
+	}, [][]byte{pixels.Bytes()}))
+
+	tl, err := tiffTile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tl.north != 38 || tl.south != 37 || tl.west != 127 || tl.east != 128 {
+		t.Errorf("tile = N%v S%v W%v E%v, want N38 S37 W127 E128", tl.north, tl.south, tl.west, tl.east)
+	}
+
+	g, err := readGeoTIFF(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := g.interpolate(37.75, 127.25); !ok || v != 300 {
+		t.Errorf("interpolate(37.75, 127.25) = %v, %v, want 300", v, ok)
+	}
+	if v, ok := g.interpolate(37, 128); ok {
+		t.Errorf("noData 위치 = %v, want 없음", v)
+	}
+}
+
+func TestReadGeoTIFFDeflatePredictor(t *testing.T) {
+	// 화소가 영역을 나타내는 3×3 int16, 빅엔디언, Deflate + 수평 예측, 스트립 2개 (2행, 1행)
+	values := []int16{-5, 10, 300, 400, -500, 600, 700, 800, 900}
+	var strips [][]byte
+	for _, rows := range [][]int{{0, 1}, {2}} {
+		var raw bytes.Buffer
+		for _, row := range rows {
+			var prev uint16
+			for col := 0; col < 3; col++ {
+				v := uint16(values[row*3+col])
+				binary.Write(&raw, binary.BigEndian, v-prev)
+				prev = v
+			}
+		}
+		var z bytes.Buffer
+		zw := zlib.NewWriter(&z)
+		zw.Write(raw.Bytes())
+		zw.Close()
+		strips = append(strips, z.Bytes())
+	}
+
+	// 화소 (0, 0)의 북서쪽 모서리가 (38.25, 126.75)이므로 화소 중심은 (38, 127)
+	path := writeFile(t, "dem.tiff", encodeTIFF(t, binary.BigEndian, map[uint16]any{
+		tagImageWidth:      []uint32{3},
+		tagImageLength:     []uint32{3},
+		tagBitsPerSample:   []uint16{16},
+		tagSampleFormat:    []uint16{sampleFormatInt},
+		tagCompression:     []uint16{compressionDeflate},
+		tagPredictor:       []uint16{predictorHorizontal},
+		tagRowsPerStrip:    []uint32{2},
+		tagModelPixelScale: []float64{0.5, 0.5, 0},
+		tagModelTiepoint:   []float64{0, 0, 0, 126.75, 38.25, 0},
+		tagGeoKeyDirectory: geoKeys(1),
+	}, strips))
+
+	tl, err := tiffTile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tl.north != 38.25 || tl.south != 36.75 || tl.west != 126.75 || tl.east != 128.25 {
+		t.Errorf("tile = N%v S%v W%v E%v, want 화소 가장자리까지 (N38.25 S36.75 W126.75 E128.25)", tl.north, tl.south, tl.west, tl.east)
+	}
+
+	g, err := readGeoTIFF(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.north != 38 || g.west != 127 {
+		t.Errorf("첫 표고값 위치 = %v, %v, want 38, 127 (화소 중심)", g.north, g.west)
+	}
+	for i, want := range values {
+		if v, ok := g.samples.at(i); !ok || v != float64(want) {
+			t.Errorf("samples[%d] = %v, %v, want %d", i, v, ok, want)
+		}
+	}
+	// 화소 가장자리는 가장 가까운 표고값으로 고정
+	if v, ok := g.interpolate(38.25, 126.75); !ok || v != -5 {
+		t.Errorf("북서쪽 모서리 = %v, %v, want -5", v, ok)
+	}
+}
+
+func TestReadTIFFImageRejectsUnsupported(t *testing.T) {
+	base := func() map[uint16]any {
+		return map[uint16]any{
+			tagImageWidth:      []uint32{2},
+			tagImageLength:     []uint32{2},
+			tagBitsPerSample:   []uint16{16},
+			tagModelPixelScale: []float64{1, 1, 0},
+			tagModelTiepoint:   []float64{0, 0, 0, 127, 38, 0},
+			tagGeoKeyDirectory: geoKeys(1),
+		}
+	}
+	tests := []struct {
+		name   string
+		change func(tags map[uint16]any)
+	}{
+		{"투영 좌표계", func(tags map[uint16]any) {
+			tags[tagGeoKeyDirectory] = []uint16{1, 1, 0, 1, geoKeyModelType, 0, 1, 1}
+		}},
+		{"LZW 압축", func(tags map[uint16]any) { tags[tagCompression] = []uint16{5} }},
+		{"여러 밴드", func(tags map[uint16]any) { tags[tagSamplesPerPixel] = []uint16{3} }},
+		{"8비트", func(tags map[uint16]any) { tags[tagBitsPerSample] = []uint16{8} }},
+		{"화소 크기 없음", func(tags map[uint16]any) { delete(tags, tagModelPixelScale) }},
+		{"너무 작음", func(tags map[uint16]any) { tags[tagImageWidth] = []uint32{1} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags := base()
+			tt.change(tags)
+			data := encodeTIFF(t, binary.LittleEndian, tags, [][]byte{make([]byte, 8)})
+			if _, err := readTIFFImage(bytes.NewReader(data)); err == nil {
+				t.Error("error = nil")
+			}
+		})
+	}
+
+	if _, err := readTIFFImage(bytes.NewReader([]byte("GIF89a\x00\x00"))); err == nil {
+		t.Error("TIFF가 아닌 파일 error = nil")
+	}
+}
+
+func TestServiceLookupGeoTIFF(t *testing.T) {
+	var pixels bytes.Buffer
+	binary.Write(&pixels, binary.LittleEndian, []float32{10, 20, 30, float32(math.NaN())})
+	data := encodeTIFF(t, binary.LittleEndian, map[uint16]any{
+		tagImageWidth:      []uint32{2},
+		tagImageLength:     []uint32{2},
+		tagBitsPerSample:   []uint16{32},
+		tagSampleFormat:    []uint16{sampleFormatFloat},
+		tagModelPixelScale: []float64{1, 1, 0},
+		tagModelTiepoint:   []float64{0, 0, 0, -71, -33, 0},
+		tagGeoKeyDirectory: geoKeys(rasterTypePixelIsPoint),
+	}, [][]byte{pixels.Bytes()})
+	dir := filepath.Dir(writeFile(t, "s34w071.tif", data))
+
+	s, err := New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := s.Lookup(-33.5, -71); !ok || v != 20 {
+		t.Errorf("Lookup(-33.5, -71) = %v, %v, want 20", v, ok)
+	}
+	if v, ok := s.Lookup(-34, -70.5); !ok || v != 30 {
+		t.Errorf("Lookup(-34, -70.5) = %v, %v, want 30 (NaN 제외)", v, ok)
+	}
+}
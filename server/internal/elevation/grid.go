@@ -0,0 +1,87 @@
+package elevation
+
+import "math"
+
+// grid 위도/경도 간격이 일정한 표고 격자
+// 첫 행이 북쪽, 첫 열이 서쪽
+type grid struct {
+	north, west float64 // 첫 표고값(북서쪽)의 위치
+	dLat, dLon  float64 // 표고값 사이 간격 (도)
+	width       int
+	height      int
+	samples     samples
+}
+
+// 행 우선 순서로 저장한 표고값 (값이 비어 있으면 false)
+type samples interface {
+	at(i int) (float64, bool)
+}
+
+// SRTM처럼 정수 미터로 저장한 표고값
+type int16Samples struct {
+	data   []int16
+	noData int16
+}
+
+func (s int16Samples) at(i int) (float64, bool) {
+	v := s.data[i]
+	return float64(v), v != s.noData
+}
+
+type float32Samples struct {
+	data      []float32
+	noData    float64
+	hasNoData bool
+}
+
+func (s float32Samples) at(i int) (float64, bool) {
+	v := float64(s.data[i])
+	if math.IsNaN(v) || (s.hasNoData && v == s.noData) {
+		return 0, false
+	}
+	return v, true
+}
+
+// 위도/경도를 둘러싼 네 표고값의 쌍선형 보간
+// 비어 있는 표고값은 제외하고 남은 값의 가중치로 보간하며, 모두 비어 있으면 false
+// 격자 가장자리 밖(표고값 반 칸 이내)은 가장자리 값을 사용
+func (g *grid) interpolate(lat, lon float64) (float64, bool) {
+	if g.width < 2 || g.height < 2 {
+		return 0, false
+	}
+
+	x := clamp((lon-g.west)/g.dLon, 0, float64(g.width-1))
+	y := clamp((g.north-lat)/g.dLat, 0, float64(g.height-1))
+	col := min(int(x), g.width-2)
+	row := min(int(y), g.height-2)
+	fx, fy := x-float64(col), y-float64(row)
+
+	corners := [4]struct {
+		row, col int
+		weight   float64
+	}{
+		{row, col, (1 - fx) * (1 - fy)},
+		{row, col + 1, fx * (1 - fy)},
+		{row + 1, col, (1 - fx) * fy},
+		{row + 1, col + 1, fx * fy},
+	}
+
+	var sum, weight float64
+	for _, c := range corners {
+		if c.weight == 0 {
+			continue
+		}
+		if v, ok := g.samples.at(c.row*g.width + c.col); ok {
+			sum += v * c.weight
+			weight += c.weight
+		}
+	}
+	if weight == 0 {
+		return 0, false
+	}
+	return sum / weight, true
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
@@ -0,0 +1,99 @@
+package elevation
+
+import (
+	"math"
+	"testing"
+)
+
+// 북서쪽 (38, 127)에서 0.5도 간격인 3×3 격자
+//
+//	100 200 300
+//	400 500 600
+//	700 800 (빈 값)
+func testGrid() *grid {
+	return &grid{
+		north: 38, west: 127,
+		dLat: 0.5, dLon: 0.5,
+		width: 3, height: 3,
+		samples: int16Samples{data: []int16{100, 200, 300, 400, 500, 600, 700, 800, hgtVoid}, noData: hgtVoid},
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	g := testGrid()
+
+	tests := []struct {
+		name     string
+		lat, lon float64
+		want     float64
+		ok       bool
+	}{
+		{"표고값 위치", 37.5, 127.5, 500, true},
+		{"네 값의 가운데", 37.75, 127.25, 300, true},
+		{"쌍선형", 37.875, 127.125, 100*0.5625 + 200*0.1875 + 400*0.1875 + 500*0.0625, true},
+		{"행 사이", 37.25, 127, 550, true},
+		{"빈 값은 제외하고 보간", 37.25, 127.75, (500 + 600 + 800) / 3.0, true},
+		{"빈 값 위치", 37, 128, 0, false},
+		{"빈 값 근처는 남은 값으로", 37.1, 127.9, (500*0.04 + 600*0.16 + 800*0.16) / 0.36, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := g.interpolate(tt.lat, tt.lon)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if ok && math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("interpolate(%v, %v) = %v, want %v", tt.lat, tt.lon, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterpolateClamp(t *testing.T) {
+	g := testGrid()
+
+	tests := []struct {
+		name     string
+		lat, lon float64
+		want     float64
+	}{
+		{"북서쪽 모서리 밖", 38.2, 126.8, 100},
+		{"북동쪽 모서리 밖", 38.2, 128.2, 300},
+		{"남서쪽 모서리 밖", 36.8, 126.8, 700},
+		{"북쪽 가장자리 밖", 38.1, 127.25, 150},
+		{"서쪽 가장자리 밖", 37.75, 126.9, 250},
+		{"동쪽 가장자리", 37.75, 128, 450},
+		{"남쪽 가장자리", 37, 127.25, 750},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := g.interpolate(tt.lat, tt.lon)
+			if !ok || math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("interpolate(%v, %v) = %v, %v, want %v", tt.lat, tt.lon, got, ok, tt.want)
+			}
+		})
+	}
+
+	// 남동쪽 모서리는 빈 값
+	if v, ok := g.interpolate(36.8, 128.2); ok {
+		t.Errorf("남동쪽 모서리 밖 = %v, want 없음", v)
+	}
+}
+
+func TestFloat32Samples(t *testing.T) {
+	s := float32Samples{data: []float32{12.5, -9999, float32(math.NaN())}, noData: -9999, hasNoData: true}
+	if v, ok := s.at(0); !ok || v != 12.5 {
+		t.Errorf("at(0) = %v, %v, want 12.5", v, ok)
+	}
+	if _, ok := s.at(1); ok {
+		t.Error("noData 값은 비어 있어야 함")
+	}
+	if _, ok := s.at(2); ok {
+		t.Error("NaN은 비어 있어야 함")
+	}
+
+	s.hasNoData = false
+	if v, ok := s.at(1); !ok || v != -9999 {
+		t.Errorf("noData가 없으면 at(1) = %v, %v, want -9999", v, ok)
+	}
+}
@@ -0,0 +1,90 @@
+package elevation
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SRTM .hgt의 빈 표고값
+const hgtVoid = -32768
+
+// 파일 이름(N37E127.hgt)에서 타일 범위를 구함
+// 이름의 위도/경도는 타일 남서쪽 모서리이고 타일은 1도 x 1도
+func hgtTile(path string) (*tile, error) {
+	south, west, err := parseHGTName(filepath.Base(path))
+	if err != nil {
+		return nil, err
+	}
+	return &tile{
+		path:  path,
+		kind:  "hgt",
+		south: south,
+		west:  west,
+		north: south + 1,
+		east:  west + 1,
+	}, nil
+}
+
+func parseHGTName(name string) (lat, lon float64, err error) {
+	name = strings.ToUpper(name)
+	if len(name) < 7 {
+		return 0, 0, fmt.Errorf("invalid hgt file name %q", name)
+	}
+
+	latValue, errLat := strconv.Atoi(name[1:3])
+	lonValue, errLon := strconv.Atoi(name[4:7])
+	if errLat != nil || errLon != nil ||
+		(name[0] != 'N' && name[0] != 'S') || (name[3] != 'E' && name[3] != 'W') {
+		return 0, 0, fmt.Errorf("invalid hgt file name %q", name)
+	}
+
+	lat, lon = float64(latValue), float64(lonValue)
+	if name[0] == 'S' {
+		lat = -lat
+	}
+	if name[3] == 'W' {
+		lon = -lon
+	}
+	return lat, lon, nil
+}
+
+// .hgt 타일 읽기
+// 빅엔디언 int16 표고값이 n x n개 있으며 (3초 해상도 1201, 1초 해상도 3601)
+// 가장자리 표고값은 이웃 타일과 겹침
+func readHGT(path string) (*grid, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	n := int(math.Sqrt(float64(len(data) / 2)))
+	if n < 2 || n*n*2 != len(data) {
+		return nil, fmt.Errorf("hgt file has unexpected size %d", len(data))
+	}
+
+	south, west, err := parseHGTName(filepath.Base(path))
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]int16, n*n)
+	for i := range values {
+		values[i] = int16(binary.BigEndian.Uint16(data[i*2:]))
+	}
+
+	step := 1 / float64(n-1)
+	return &grid{
+		north:   south + 1,
+		west:    west,
+		dLat:    step,
+		dLon:    step,
+		width:   n,
+		height:  n,
+		samples: int16Samples{data: values, noData: hgtVoid},
+	}, nil
+}
@@ -0,0 +1,139 @@
+package elevation
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// 표고값을 빅엔디언 int16으로 저장한 .hgt 파일
+func writeHGT(t *testing.T, dir, name string, values []int16) string {
+	t.Helper()
+	data := make([]byte, len(values)*2)
+	for i, v := range values {
+		binary.BigEndian.PutUint16(data[i*2:], uint16(v))
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+var hgtValues = []int16{100, 200, 300, 400, 500, 600, 700, 800, hgtVoid}
+
+func TestParseHGTName(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lon float64
+		ok       bool
+	}{
+		{"N37E127.hgt", 37, 127, true},
+		{"S34W071.hgt", -34, -71, true},
+		{"s01w001.hgt", -1, -1, true},
+		{"N00E000.hgt", 0, 0, true},
+		{"X37E127.hgt", 0, 0, false},
+		{"N37X127.hgt", 0, 0, false},
+		{"NabE127.hgt", 0, 0, false},
+		{"N37.hgt", 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lat, lon, err := parseHGTName(tt.name)
+			if (err == nil) != tt.ok {
+				t.Fatalf("error = %v, want ok %v", err, tt.ok)
+			}
+			if tt.ok && (lat != tt.lat || lon != tt.lon) {
+				t.Errorf("parseHGTName = %v, %v, want %v, %v", lat, lon, tt.lat, tt.lon)
+			}
+		})
+	}
+}
+
+func TestReadHGT(t *testing.T) {
+	path := writeHGT(t, t.TempDir(), "S34W071.hgt", hgtValues)
+
+	g, err := readHGT(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.north != -33 || g.west != -71 || g.dLat != 0.5 || g.dLon != 0.5 || g.width != 3 || g.height != 3 {
+		t.Errorf("grid = north %v, west %v, step %v/%v, size %dx%d, want -33, -71, 0.5, 3x3",
+			g.north, g.west, g.dLat, g.dLon, g.width, g.height)
+	}
+	for i, want := range hgtValues {
+		v, ok := g.samples.at(i)
+		if want == hgtVoid {
+			if ok {
+				t.Errorf("samples[%d] = %v, want 빈 값", i, v)
+			}
+			continue
+		}
+		if !ok || v != float64(want) {
+			t.Errorf("samples[%d] = %v, %v, want %d", i, v, ok, want)
+		}
+	}
+}
+
+func TestReadHGTRejectsInvalid(t *testing.T) {
+	dir := t.TempDir()
+
+	bad := filepath.Join(dir, "N37E127.hgt")
+	if err := os.WriteFile(bad, make([]byte, 10), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readHGT(bad); err == nil {
+		t.Error("크기가 맞지 않는 파일 error = nil")
+	}
+
+	if _, err := readHGT(writeHGT(t, dir, "tile.hgt", hgtValues)); err == nil {
+		t.Error("이름이 잘못된 파일 error = nil")
+	}
+}
+
+func TestServiceLookup(t *testing.T) {
+	dir := t.TempDir()
+	writeHGT(t, dir, "S34W071.hgt", hgtValues)
+	writeHGT(t, dir, "N37E127.hgt", hgtValues)
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Tiles() != 2 {
+		t.Fatalf("Tiles = %d, want 2", s.Tiles())
+	}
+
+	tests := []struct {
+		name     string
+		lat, lon float64
+		want     float64
+		ok       bool
+	}{
+		{"남서 반구 타일", -33.25, -70.75, 300, true},
+		{"남서 반구 타일 남서쪽 모서리", -34, -71, 700, true},
+		{"북동 반구 타일", 37.5, 127.5, 500, true},
+		{"북쪽 가장자리", 38, 127.25, 150, true},
+		{"동쪽 가장자리", 37.75, 128, 450, true},
+		{"빈 값", 37, 128, 0, false},
+		{"타일 없음", 36.5, 127.5, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := s.Lookup(tt.lat, tt.lon)
+			if ok != tt.ok || (ok && math.Abs(got-tt.want) > 1e-6) {
+				t.Errorf("Lookup(%v, %v) = %v, %v, want %v, %v", tt.lat, tt.lon, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+
+	var nilService *Service
+	if _, ok := nilService.Lookup(37.5, 127.5); ok {
+		t.Error("nil Service Lookup = true")
+	}
+}
@@ -8,6 +8,8 @@ const (
 	ErrInvalidMethod = 1001
 	ErrPathNotFound  = 1002
 	ErrMissingParams = 1003
+	ErrInvalidFormat = 1004
+	ErrFailedToWrite = 1005
 	// Database errors (5000-5999)
 	ErrDatabaseConn  = 5001
 	ErrDatabaseQuery = 5002
@@ -17,6 +19,9 @@ const (
 	ErrTokenExpired          = 6002
 	ErrUnauthorized          = 6003
 	ErrFailedToGenerateToken = 6004
+	ErrRefreshTokenReused    = 6005
+	ErrFailedToRevokeToken   = 6006
+	ErrPasswordResetRequired = 6007
 
 	// User related errors (7000-7999)
 	ErrUserNotFound         = 7001
@@ -25,16 +30,64 @@ const (
 	ErrFailedToHashPassword = 7004
 	ErrFailedToCreateUser   = 7005
 	ErrFailedToAddFriend    = 7006
+	ErrAccountSuspended     = 7007
+	ErrInvalidRole          = 7008
+	ErrInvalidUserStatus    = 7009
+	ErrFailedToUpdateUser   = 7010
+	ErrFailedToFetchUsers   = 7011
+	ErrInvalidPassword      = 7012
 
 	// Route related errors (8000-8999)
-	ErrRouteNotFound       = 8001
-	ErrInvalidRoute        = 8002
-	ErrFailedToCreateRoute = 8003
-	ErrFailedToUpdateRoute = 8004
-	ErrFailedToFetchRoutes = 8005
+	ErrRouteNotFound          = 8001
+	ErrInvalidRoute           = 8002
+	ErrFailedToCreateRoute    = 8003
+	ErrFailedToUpdateRoute    = 8004
+	ErrFailedToFetchRoutes    = 8005
+	ErrInvalidGPX             = 8006
+	ErrFailedToDeleteRoute    = 8007
+	ErrInvalidRouteVisibility = 8008
+	ErrInvalidSearchQuery     = 8009
+	ErrInvalidDifficulty      = 8010
+	ErrNoElevationData        = 8011
+	ErrRoutePlannerDisabled   = 8012
+	ErrInvalidWaypoints       = 8013
+	ErrNoRouteFound           = 8014
+	ErrInvalidLoopOptions     = 8015
+	ErrNoRouteTrack           = 8016
 
 	// Ride related errors (9000-9999)
-	ErrFailedToCreateRide = 9001
+	ErrFailedToCreateRide    = 9001
+	ErrRideNotFound          = 9002
+	ErrInvalidRide           = 9003
+	ErrFailedToUpdateRide    = 9004
+	ErrFailedToDeleteRide    = 9005
+	ErrFailedToFetchRides    = 9006
+	ErrInvalidRideTime       = 9007
+	ErrInvalidRideLocations  = 9008
+	ErrRideRouteNotFound     = 9009
+	ErrFailedToFetchRideStat = 9010
+	ErrInvalidRideFile       = 9011
+	ErrInvalidRideStreams    = 9012
+
+	ErrLiveSessionNotFound       = 9013
+	ErrLiveSessionClosed         = 9014
+	ErrLiveSessionActive         = 9015
+	ErrLiveSequenceGap           = 9016
+	ErrInvalidLiveBatch          = 9017
+	ErrFailedToUpdateLiveSession = 9018
+	ErrInvalidStatsQuery         = 9019
+	ErrRecordNotFound            = 9020
+	ErrFailedToFetchRecords      = 9021
+
+	// Segment related errors (10000-10999)
+	ErrSegmentNotFound        = 10001
+	ErrInvalidSegment         = 10002
+	ErrFailedToCreateSegment  = 10003
+	ErrFailedToUpdateSegment  = 10004
+	ErrFailedToDeleteSegment  = 10005
+	ErrFailedToFetchSegments  = 10006
+	ErrSegmentSourceNotFound  = 10007
+	ErrInvalidLeaderboardType = 10008
 )
 
 // GetErrorMessage returns predefined error message for error code
@@ -50,6 +103,10 @@ func GetErrorMessage(code int) string {
 		return "경로를 찾을 수 없습니다"
 	case ErrMissingParams:
 		return "필수 파라미터가 누락되었습니다"
+	case ErrInvalidFormat:
+		return "지원하지 않는 형식입니다"
+	case ErrFailedToWrite:
+		return "응답 생성에 실패했습니다"
 
 	// Database errors
 	case ErrDatabaseConn:
@@ -66,6 +123,12 @@ func GetErrorMessage(code int) string {
 		return "인증되지 않은 접근입니다"
 	case ErrFailedToGenerateToken:
 		return "토큰 생성에 실패했습니다"
+	case ErrRefreshTokenReused:
+		return "이미 사용된 리프레시 토큰입니다. 모든 세션이 로그아웃되었습니다"
+	case ErrFailedToRevokeToken:
+		return "토큰 폐기에 실패했습니다"
+	case ErrPasswordResetRequired:
+		return "비밀번호를 재설정해야 합니다"
 
 	// User errors
 	case ErrUserNotFound:
@@ -80,6 +143,18 @@ func GetErrorMessage(code int) string {
 		return "사용자 생성에 실패했습니다"
 	case ErrFailedToAddFriend:
 		return "친구 추가에 실패했습니다"
+	case ErrAccountSuspended:
+		return "정지된 계정입니다"
+	case ErrInvalidRole:
+		return "유효하지 않은 역할입니다"
+	case ErrInvalidUserStatus:
+		return "유효하지 않은 사용자 상태입니다"
+	case ErrFailedToUpdateUser:
+		return "사용자 정보 수정에 실패했습니다"
+	case ErrFailedToFetchUsers:
+		return "사용자 목록 조회에 실패했습니다"
+	case ErrInvalidPassword:
+		return "비밀번호는 8자 이상이어야 합니다"
 
 	// Route errors
 	case ErrRouteNotFound:
@@ -92,10 +167,90 @@ func GetErrorMessage(code int) string {
 		return "경로 업데이트에 실패했습니다"
 	case ErrFailedToFetchRoutes:
 		return "경로 조회에 실패했습니다"
+	case ErrInvalidGPX:
+		return "GPX 데이터를 해석할 수 없습니다"
+	case ErrFailedToDeleteRoute:
+		return "경로 삭제에 실패했습니다"
+	case ErrInvalidRouteVisibility:
+		return "유효하지 않은 경로 공개 범위입니다"
+	case ErrInvalidSearchQuery:
+		return "잘못된 검색 조건입니다"
+	case ErrInvalidDifficulty:
+		return "유효하지 않은 난이도입니다"
+	case ErrNoElevationData:
+		return "고도 정보가 없는 경로입니다"
+	case ErrRoutePlannerDisabled:
+		return "경로 탐색을 사용할 수 없습니다"
+	case ErrInvalidWaypoints:
+		return "유효하지 않은 경유지입니다"
+	case ErrNoRouteFound:
+		return "경유지를 잇는 경로를 찾을 수 없습니다"
+	case ErrInvalidLoopOptions:
+		return "유효하지 않은 순환 경로 조건입니다"
+	case ErrNoRouteTrack:
+		return "트랙이 없는 경로입니다"
 
 	// Ride errors
 	case ErrFailedToCreateRide:
 		return "라이드 생성에 실패했습니다"
+	case ErrRideNotFound:
+		return "라이드를 찾을 수 없습니다"
+	case ErrInvalidRide:
+		return "잘못된 라이드 정보입니다"
+	case ErrFailedToUpdateRide:
+		return "라이드 수정에 실패했습니다"
+	case ErrFailedToDeleteRide:
+		return "라이드 삭제에 실패했습니다"
+	case ErrFailedToFetchRides:
+		return "라이드 조회에 실패했습니다"
+	case ErrInvalidRideTime:
+		return "라이드 시작 시간은 종료 시간보다 빨라야 합니다"
+	case ErrInvalidRideLocations:
+		return "라이드 위치 정보가 올바르지 않습니다"
+	case ErrRideRouteNotFound:
+		return "라이드에 연결된 경로를 찾을 수 없습니다"
+	case ErrFailedToFetchRideStat:
+		return "라이드 통계 조회에 실패했습니다"
+	case ErrInvalidRideFile:
+		return "라이드 파일을 해석할 수 없습니다"
+	case ErrInvalidRideStreams:
+		return "센서 데이터의 개수가 위치 정보와 일치하지 않습니다"
+	case ErrLiveSessionNotFound:
+		return "실시간 세션을 찾을 수 없습니다"
+	case ErrLiveSessionClosed:
+		return "이미 종료된 실시간 세션입니다"
+	case ErrLiveSessionActive:
+		return "진행 중인 실시간 세션이 있습니다"
+	case ErrLiveSequenceGap:
+		return "위치 묶음 순번이 연속되지 않습니다"
+	case ErrInvalidLiveBatch:
+		return "잘못된 위치 묶음입니다"
+	case ErrFailedToUpdateLiveSession:
+		return "실시간 세션 저장에 실패했습니다"
+	case ErrInvalidStatsQuery:
+		return "잘못된 통계 조회 조건입니다"
+	case ErrRecordNotFound:
+		return "개인 기록을 찾을 수 없습니다"
+	case ErrFailedToFetchRecords:
+		return "개인 기록 조회에 실패했습니다"
+
+	// Segment errors
+	case ErrSegmentNotFound:
+		return "구간을 찾을 수 없습니다"
+	case ErrInvalidSegment:
+		return "잘못된 구간 정보입니다"
+	case ErrFailedToCreateSegment:
+		return "구간 생성에 실패했습니다"
+	case ErrFailedToUpdateSegment:
+		return "구간 수정에 실패했습니다"
+	case ErrFailedToDeleteSegment:
+		return "구간 삭제에 실패했습니다"
+	case ErrFailedToFetchSegments:
+		return "구간 조회에 실패했습니다"
+	case ErrSegmentSourceNotFound:
+		return "구간을 만들 경로 또는 라이드를 찾을 수 없습니다"
+	case ErrInvalidLeaderboardType:
+		return "잘못된 순위표 조회 조건입니다"
 
 	default:
 		return "내부 서버 오류가 발생했습니다"
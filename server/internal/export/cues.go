@@ -0,0 +1,121 @@
+package export
+
+import (
+	"html/template"
+	"io"
+
+	"github.com/chrisS41/gobike-server/internal/gpx"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/chrisS41/gobike-server/internal/tcx"
+)
+
+// 안내 종류/방향별 TCX CoursePoint PointType
+var coursePointTypes = map[string]string{
+	models.CueDirectionStraight:    "Straight",
+	models.CueDirectionSlightLeft:  "Left",
+	models.CueDirectionLeft:        "Left",
+	models.CueDirectionSharpLeft:   "Left",
+	models.CueDirectionSlightRight: "Right",
+	models.CueDirectionRight:       "Right",
+	models.CueDirectionSharpRight:  "Right",
+	models.CueTypeSummit:           "Summit",
+}
+
+// 오르막 등급별 TCX CoursePoint PointType
+var climbPointTypes = map[string]string{
+	models.ClimbCategory4:  "4th Category",
+	models.ClimbCategory3:  "3rd Category",
+	models.ClimbCategory2:  "2nd Category",
+	models.ClimbCategory1:  "1st Category",
+	models.ClimbCategoryHC: "Hors Category",
+}
+
+// 안내 지점을 잇는 GPX 경로 (<rtept>의 name은 안내 문구, type은 안내 종류나 회전 방향)
+func cueRoute(t *Track) gpx.Route {
+	route := gpx.Route{Name: t.Name, Description: t.Description}
+	for _, cue := range t.Cues {
+		p := gpx.Point{
+			Latitude:    cue.Latitude,
+			Longitude:   cue.Longitude,
+			Elevation:   cue.Elevation,
+			Name:        cue.Note,
+			Description: cue.Street,
+			Type:        cue.Type,
+		}
+		if cue.Direction != "" {
+			p.Type = cue.Direction
+		}
+		route.Points = append(route.Points, p)
+	}
+	return route
+}
+
+// 안내를 TCX CoursePoint로 변환
+// CoursePoint 시각은 같은 지점의 트랙포인트 시각을 사용 (기기가 시각으로 트랙 위치를 찾음)
+func coursePoints(cues []models.Cue, points []tcx.Trackpoint) []tcx.CoursePoint {
+	var out []tcx.CoursePoint
+	for _, cue := range cues {
+		if cue.Index < 0 || cue.Index >= len(points) {
+			continue
+		}
+		cp := tcx.CoursePoint{
+			Name:      cue.Street,
+			Time:      points[cue.Index].Time,
+			Latitude:  cue.Latitude,
+			Longitude: cue.Longitude,
+			Altitude:  cue.Elevation,
+			PointType: coursePointTypes[cue.Direction],
+			Notes:     cue.Note,
+		}
+		switch cue.Type {
+		case models.CueTypeClimb:
+			cp.PointType = climbPointTypes[cue.Category]
+		case models.CueTypeSummit:
+			cp.PointType = coursePointTypes[cue.Type]
+		}
+		if cp.Name == "" {
+			cp.Name = cue.Note
+		}
+		out = append(out, cp)
+	}
+	return out
+}
+
+var cueSheetTemplate = template.Must(template.New("cues").Parse(`<!DOCTYPE html>
+<html lang="ko">
+<head>
+<meta charset="utf-8">
+<title>{{.Name}} 큐시트</title>
+<style>
+body { font-family: sans-serif; margin: 1.5em; }
+h1 { font-size: 1.4em; margin-bottom: 0.2em; }
+p { margin-top: 0; color: #555; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #999; padding: 0.3em 0.5em; text-align: left; }
+th { background: #eee; }
+td.num { text-align: right; white-space: nowrap; }
+tr { page-break-inside: avoid; }
+@media print { body { margin: 0; } }
+</style>
+</head>
+<body>
+<h1>{{.Name}}</h1>
+<p>총 {{printf "%.1f" .Distance}}km · 안내 {{len .Cues}}개</p>
+<table>
+<thead>
+<tr><th>#</th><th>거리 (km)</th><th>안내</th><th>도로</th><th>다음 (km)</th></tr>
+</thead>
+<tbody>
+{{- range $i, $c := .Cues}}
+<tr><td class="num">{{$i}}</td><td class="num">{{printf "%.2f" $c.Distance}}</td><td>{{$c.Note}}</td><td>{{$c.Street}}</td><td class="num">{{if $c.Next}}{{printf "%.2f" $c.Next}}{{end}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+</body>
+</html>
+`))
+
+// WriteCueSheetHTML 인쇄용 HTML 큐시트 출력
+func WriteCueSheetHTML(w io.Writer, sheet *models.CueSheet) error {
+	return cueSheetTemplate.Execute(w, sheet)
+}
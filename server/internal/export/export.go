@@ -0,0 +1,191 @@
+// export 패키지는 라이드와 경로를 외부 서비스용 파일 형식으로 변환합니다.
+// 지원 형식: GPX 1.1, TCX, GeoJSON, KML
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/gpx"
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+type Format string
+
+const (
+	FormatGPX     Format = "gpx"
+	FormatTCX     Format = "tcx"
+	FormatGeoJSON Format = "geojson"
+	FormatKML     Format = "kml"
+)
+
+// Track 내보내기 대상 공통 표현
+// Activity가 true이면 실제 주행 기록(라이드), false이면 계획된 경로(코스)
+type Track struct {
+	Name        string
+	Description string
+	Tags        []string
+	Activity    bool
+	StartTime   time.Time
+	Duration    time.Duration
+	Distance    float64 // 킬로미터
+	Calories    float64
+	Points      []models.GeoPoint
+	Streams     *models.SensorStreams // Points와 같은 인덱스의 센서 값 (없으면 nil)
+	Cues        []models.Cue          // 경로 안내 (GPX <rte>의 <rtept>, TCX CoursePoint로 출력)
+}
+
+// ParseFormat 쿼리 문자열을 Format으로 변환
+func ParseFormat(value string) (Format, error) {
+	switch f := Format(strings.ToLower(value)); f {
+	case FormatGPX, FormatTCX, FormatGeoJSON, FormatKML:
+		return f, nil
+	}
+	return "", fmt.Errorf("unsupported export format: %q", value)
+}
+
+// ContentType 형식별 MIME 타입
+func (f Format) ContentType() string {
+	switch f {
+	case FormatGPX:
+		return "application/gpx+xml"
+	case FormatTCX:
+		return "application/vnd.garmin.tcx+xml"
+	case FormatGeoJSON:
+		return "application/geo+json"
+	case FormatKML:
+		return "application/vnd.google-earth.kml+xml"
+	}
+	return "application/octet-stream"
+}
+
+// Extension 형식별 파일 확장자
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+// Write 지정한 형식으로 출력
+func Write(w io.Writer, format Format, t *Track) error {
+	if len(t.Points) == 0 {
+		return fmt.Errorf("track has no points")
+	}
+
+	switch format {
+	case FormatGPX:
+		return writeGPX(w, t)
+	case FormatTCX:
+		return writeTCX(w, t)
+	case FormatGeoJSON:
+		return writeGeoJSON(w, t)
+	case FormatKML:
+		return writeKML(w, t)
+	}
+	return fmt.Errorf("unsupported export format: %q", format)
+}
+
+// FromRide 라이드를 내보내기 대상으로 변환
+func FromRide(ride *models.Ride) *Track {
+	name := fmt.Sprintf("Ride %s", ride.StartTime.Format("2006-01-02 15:04"))
+	return &Track{
+		Name:      name,
+		Activity:  true,
+		StartTime: ride.StartTime,
+		Duration:  ride.Duration,
+		Distance:  ride.Distance,
+		Calories:  ride.Calories,
+		Points:    ride.Locations,
+		Streams:   ride.Streams,
+	}
+}
+
+// FromRoute 경로를 내보내기 대상으로 변환
+// 경로 형상은 GPXData에서 읽고, 없으면 시작/종료 지점만 사용
+func FromRoute(route *models.Route) (*Track, error) {
+	t := &Track{
+		Name:        route.Name,
+		Description: route.Description,
+		Tags:        route.Tags,
+		Duration:    route.Duration,
+		Distance:    route.Distance,
+	}
+
+	if route.GPXData != "" {
+		g, err := gpx.ParseString(route.GPXData)
+		if err != nil {
+			return nil, err
+		}
+		t.Points = g.GeoPoints()
+	}
+	if len(t.Points) == 0 {
+		t.Points = []models.GeoPoint{route.StartPoint, route.EndPoint}
+	}
+	return t, nil
+}
+
+// FileName 다운로드 파일 이름 (영문/숫자 외 문자는 '_'로 치환)
+func FileName(name string, format Format) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	if b.Len() == 0 {
+		b.WriteString("track")
+	}
+	return b.String() + format.Extension()
+}
+
+func writeGPX(w io.Writer, t *Track) error {
+	g := &gpx.GPX{
+		Name:        t.Name,
+		Description: t.Description,
+		Keywords:    t.Tags,
+	}
+	if !t.StartTime.IsZero() {
+		start := t.StartTime
+		g.Time = &start
+	}
+
+	points := make([]gpx.Point, len(t.Points))
+	for i, p := range t.Points {
+		points[i] = gpx.Point{
+			Latitude:  p.Latitude,
+			Longitude: p.Longitude,
+			Elevation: p.Elevation,
+			Time:      p.Timestamp,
+		}
+		if s := t.Streams; s != nil {
+			points[i].HeartRate = streamValue(s.HeartRate, i)
+			points[i].Cadence = streamValue(s.Cadence, i)
+			points[i].Power = streamValue(s.Power, i)
+			points[i].Temperature = streamValue(s.Temperature, i)
+		}
+	}
+
+	// 경로도 <trk>로 출력 (대부분의 기기/서비스가 <rte>보다 <trk>를 잘 지원)
+	g.Tracks = []gpx.Track{{
+		Name:        t.Name,
+		Description: t.Description,
+		Type:        "cycling",
+		Segments:    []gpx.Segment{{Points: points}},
+	}}
+	// 안내는 안내 지점을 잇는 <rte>로 함께 출력 (회전 안내를 표시하는 기기용)
+	if len(t.Cues) > 0 {
+		g.Routes = []gpx.Route{cueRoute(t)}
+	}
+	return gpx.Write(w, g)
+}
+
+// 스트림의 i번째 값 (스트림이 없거나 범위를 벗어나면 nil)
+func streamValue[T any](stream []*T, i int) *T {
+	if i < len(stream) {
+		return stream[i]
+	}
+	return nil
+}
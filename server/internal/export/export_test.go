@@ -0,0 +1,419 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/gpx"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/chrisS41/gobike-server/internal/tcx"
+)
+
+var testStart = time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC)
+
+func testRide() *models.Ride {
+	ride := &models.Ride{
+		StartTime: testStart,
+		EndTime:   testStart.Add(20 * time.Second),
+		Duration:  20 * time.Second,
+		Distance:  0.1,
+		Calories:  12,
+		Streams:   models.NewSensorStreams(3),
+	}
+	for i := 0; i < 3; i++ {
+		ts := testStart.Add(time.Duration(i*10) * time.Second)
+		ele := 50.5 + float64(i)
+		hr := 120 + i
+		ride.Locations = append(ride.Locations, models.GeoPoint{
+			Latitude:  37.5 + float64(i)*0.0004,
+			Longitude: -122.25 - float64(i)*0.0004,
+			Elevation: &ele,
+			Timestamp: &ts,
+		})
+		ride.Streams.HeartRate[i] = &hr
+	}
+	ride.Streams = ride.Streams.Compact()
+	return ride
+}
+
+func testRoute(t *testing.T) *models.Route {
+	t.Helper()
+	var b bytes.Buffer
+	points := []gpx.Point{
+		{Latitude: 37.5, Longitude: 127.0, Elevation: ptr(10.0)},
+		{Latitude: 37.51, Longitude: 127.01, Elevation: ptr(25.5)},
+		{Latitude: 37.52, Longitude: 127.02, Elevation: ptr(18.0)},
+	}
+	if err := gpx.Write(&b, &gpx.GPX{Tracks: []gpx.Track{{Segments: []gpx.Segment{{Points: points}}}}}); err != nil {
+		t.Fatal(err)
+	}
+	return &models.Route{
+		Name:        "한강 순환",
+		Description: "반포 출발 & 복귀 <야간>",
+		Tags:        []string{"river", "night"},
+		Distance:    2.4,
+		Duration:    10 * time.Minute,
+		GPXData:     b.String(),
+	}
+}
+
+func ptr(v float64) *float64 { return &v }
+
+func export(t *testing.T, format Format, track *Track) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	if err := Write(&b, format, track); err != nil {
+		t.Fatalf("Write %s: %v", format, err)
+	}
+	return b.Bytes()
+}
+
+// 좌표, 고도, 시각(want에 시각이 있을 때)이 일치하는지 확인
+func checkPoints(t *testing.T, format Format, got, want []models.GeoPoint) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: points = %d, want %d", format, len(got), len(want))
+	}
+	for i := range want {
+		if math.Abs(got[i].Latitude-want[i].Latitude) > 1e-9 || math.Abs(got[i].Longitude-want[i].Longitude) > 1e-9 {
+			t.Errorf("%s: point %d = (%v, %v), want (%v, %v)", format, i,
+				got[i].Latitude, got[i].Longitude, want[i].Latitude, want[i].Longitude)
+		}
+		if got[i].Elevation == nil || *got[i].Elevation != *want[i].Elevation {
+			t.Errorf("%s: point %d elevation = %v, want %v", format, i, got[i].Elevation, *want[i].Elevation)
+		}
+		if want[i].Timestamp != nil && (got[i].Timestamp == nil || !got[i].Timestamp.Equal(*want[i].Timestamp)) {
+			t.Errorf("%s: point %d time = %v, want %v", format, i, got[i].Timestamp, *want[i].Timestamp)
+		}
+	}
+}
+
+func TestGPXRoundTrip(t *testing.T) {
+	ride := testRide()
+	track := FromRide(ride)
+	g, err := gpx.Parse(bytes.NewReader(export(t, FormatGPX, track)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Name != track.Name || g.Time == nil || !g.Time.Equal(testStart) {
+		t.Errorf("ride metadata = %q %v", g.Name, g.Time)
+	}
+	checkPoints(t, FormatGPX, g.GeoPoints(), ride.Locations)
+	for i, p := range g.Points() {
+		if p.HeartRate == nil || *p.HeartRate != *ride.Streams.HeartRate[i] {
+			t.Errorf("gpx: point %d heart rate = %v", i, p.HeartRate)
+		}
+	}
+
+	route := testRoute(t)
+	track, err = FromRoute(route)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err = gpx.Parse(bytes.NewReader(export(t, FormatGPX, track)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Name != route.Name || g.Description != route.Description || strings.Join(g.Keywords, ",") != "river,night" {
+		t.Errorf("route metadata = %q %q %v", g.Name, g.Description, g.Keywords)
+	}
+	checkPoints(t, FormatGPX, g.GeoPoints(), track.Points)
+}
+
+// TCX에는 태그를 담을 요소가 없으므로 이름, 설명, 시각, 고도만 확인
+func TestTCXRoundTrip(t *testing.T) {
+	ride := testRide()
+	db, err := tcx.Parse(bytes.NewReader(export(t, FormatTCX, FromRide(ride))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(db.Activities) != 1 || len(db.Activities[0].Laps) != 1 {
+		t.Fatalf("activities = %+v", db.Activities)
+	}
+	activity := db.Activities[0]
+	if !activity.ID.Equal(testStart) || activity.Laps[0].Calories != 12 {
+		t.Errorf("activity = %v, calories %d", activity.ID, activity.Laps[0].Calories)
+	}
+	var points []models.GeoPoint
+	for i, p := range activity.Laps[0].Points {
+		ts := p.Time
+		points = append(points, models.GeoPoint{Latitude: *p.Latitude, Longitude: *p.Longitude, Elevation: p.Altitude, Timestamp: &ts})
+		if p.HeartRate == nil || *p.HeartRate != *ride.Streams.HeartRate[i] {
+			t.Errorf("tcx: point %d heart rate = %v", i, p.HeartRate)
+		}
+	}
+	checkPoints(t, FormatTCX, points, ride.Locations)
+
+	route := testRoute(t)
+	track, err := FromRoute(route)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err = tcx.Parse(bytes.NewReader(export(t, FormatTCX, track)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(db.Courses) != 1 {
+		t.Fatalf("courses = %d", len(db.Courses))
+	}
+	course := db.Courses[0]
+	if course.Name != route.Name || course.Notes != route.Description {
+		t.Errorf("course = %q %q", course.Name, course.Notes)
+	}
+	points = nil
+	for _, p := range course.Points {
+		points = append(points, models.GeoPoint{Latitude: *p.Latitude, Longitude: *p.Longitude, Elevation: p.Altitude})
+	}
+	checkPoints(t, FormatTCX, points, track.Points)
+}
+
+// 지점이 입력과 같은지 확인 (고도/시각이 없는 지점은 없는 채로 읽혀야 함)
+func checkRoundTrip(t *testing.T, format Format, got, want []models.GeoPoint) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: points = %d, want %d", format, len(got), len(want))
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if g.Latitude != w.Latitude || g.Longitude != w.Longitude {
+			t.Errorf("%s: point %d = (%v, %v), want (%v, %v)", format, i, g.Latitude, g.Longitude, w.Latitude, w.Longitude)
+		}
+		if (g.Elevation == nil) != (w.Elevation == nil) || (w.Elevation != nil && *g.Elevation != *w.Elevation) {
+			t.Errorf("%s: point %d elevation = %v, want %v", format, i, g.Elevation, w.Elevation)
+		}
+		if (g.Timestamp == nil) != (w.Timestamp == nil) || (w.Timestamp != nil && !g.Timestamp.Equal(*w.Timestamp)) {
+			t.Errorf("%s: point %d time = %v, want %v", format, i, g.Timestamp, w.Timestamp)
+		}
+	}
+}
+
+// 고도와 시각이 없고 좌표 자릿수가 긴 라이드 (출력 형식의 정밀도 확인)
+func bareTrack() *Track {
+	track := &Track{Name: "bare", Activity: true, StartTime: testStart}
+	seoul := time.FixedZone("KST", 9*3600)
+	for i := 0; i < 3; i++ {
+		ts := testStart.In(seoul).Add(time.Duration(i)*time.Second + 250*time.Millisecond)
+		track.Points = append(track.Points, models.GeoPoint{
+			Latitude:  -33.868819671 + float64(i)*1e-7,
+			Longitude: 151.209295123456 - float64(i)*1e-7,
+			Timestamp: &ts,
+		})
+	}
+	return track
+}
+
+// 시각이 없는 라이드는 지점 시각 없이 출력
+func untimedTrack() *Track {
+	track := bareTrack()
+	for i := range track.Points {
+		track.Points[i].Timestamp = nil
+		track.Points[i].Elevation = ptr(float64(i) - 0.5)
+	}
+	return track
+}
+
+type geoJSONOutput struct {
+	Type     string `json:"type"`
+	Geometry struct {
+		Type        string      `json:"type"`
+		Coordinates [][]float64 `json:"coordinates"`
+	} `json:"geometry"`
+	Properties struct {
+		Type        string   `json:"type"`
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		Tags        []string `json:"tags"`
+		StartTime   string   `json:"start_time"`
+		CoordTimes  []string `json:"coordTimes"`
+	} `json:"properties"`
+}
+
+// 출력한 GeoJSON을 지점으로 읽음 ([경도, 위도] 또는 [경도, 위도, 고도], 시각은 coordTimes)
+func readGeoJSON(t *testing.T, data []byte) (*geoJSONOutput, []models.GeoPoint) {
+	t.Helper()
+	var feature geoJSONOutput
+	if err := json.Unmarshal(data, &feature); err != nil {
+		t.Fatal(err)
+	}
+	if feature.Type != "Feature" || feature.Geometry.Type != "LineString" {
+		t.Fatalf("geojson type = %s/%s", feature.Type, feature.Geometry.Type)
+	}
+	coords, times := feature.Geometry.Coordinates, feature.Properties.CoordTimes
+	if times != nil && len(times) != len(coords) {
+		t.Fatalf("coordTimes = %d, coordinates %d", len(times), len(coords))
+	}
+	points := make([]models.GeoPoint, len(coords))
+	for i, c := range coords {
+		if len(c) != 2 && len(c) != 3 {
+			t.Fatalf("coordinate %d = %v", i, c)
+		}
+		points[i] = models.GeoPoint{Longitude: c[0], Latitude: c[1]}
+		if len(c) == 3 {
+			points[i].Elevation = &c[2]
+		}
+		if times != nil {
+			ts, err := time.Parse(time.RFC3339Nano, times[i])
+			if err != nil {
+				t.Fatal(err)
+			}
+			points[i].Timestamp = &ts
+		}
+	}
+	return &feature, points
+}
+
+func TestGeoJSONRoundTrip(t *testing.T) {
+	ride := testRide()
+	track := FromRide(ride)
+	feature, points := readGeoJSON(t, export(t, FormatGeoJSON, track))
+	checkRoundTrip(t, FormatGeoJSON, points, track.Points)
+	if p := feature.Properties; p.Type != "ride" || p.Name != track.Name || p.StartTime != testStart.Format(time.RFC3339) {
+		t.Errorf("ride properties = %+v", p)
+	}
+
+	route := testRoute(t)
+	track, err := FromRoute(route)
+	if err != nil {
+		t.Fatal(err)
+	}
+	feature, points = readGeoJSON(t, export(t, FormatGeoJSON, track))
+	checkRoundTrip(t, FormatGeoJSON, points, track.Points)
+	if p := feature.Properties; p.Type != "route" || p.Name != route.Name || p.Description != route.Description ||
+		strings.Join(p.Tags, ",") != "river,night" || p.CoordTimes != nil {
+		t.Errorf("route properties = %+v", p)
+	}
+
+	for _, track := range []*Track{bareTrack(), untimedTrack()} {
+		_, points := readGeoJSON(t, export(t, FormatGeoJSON, track))
+		checkRoundTrip(t, FormatGeoJSON, points, track.Points)
+	}
+}
+
+// 태그 없이 로컬 이름으로 매칭하므로 gx:Track, gx:coord도 읽힘
+type kmlOutput struct {
+	Document struct {
+		Name      string `xml:"name"`
+		Placemark struct {
+			Name         string `xml:"name"`
+			Description  string `xml:"description"`
+			ExtendedData []struct {
+				Name  string `xml:"name,attr"`
+				Value string `xml:"value"`
+			} `xml:"ExtendedData>Data"`
+			TimeSpan *struct {
+				Begin string `xml:"begin"`
+				End   string `xml:"end"`
+			} `xml:"TimeSpan"`
+			LineString *struct {
+				AltitudeMode string `xml:"altitudeMode"`
+				Coordinates  string `xml:"coordinates"`
+			} `xml:"LineString"`
+			Track *struct {
+				AltitudeMode string   `xml:"altitudeMode"`
+				When         []string `xml:"when"`
+				Coords       []string `xml:"coord"`
+			} `xml:"Track"`
+		} `xml:"Placemark"`
+	} `xml:"Document"`
+}
+
+func parseKMLCoord(t *testing.T, s, sep string, clamped bool) models.GeoPoint {
+	t.Helper()
+	parts := strings.Split(s, sep)
+	if len(parts) != 3 {
+		t.Fatalf("kml coord %q", s)
+	}
+	var v [3]float64
+	for i, part := range parts {
+		f, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v[i] = f
+	}
+	p := models.GeoPoint{Longitude: v[0], Latitude: v[1]}
+	// clampToGround이면 고도 값은 자리만 채운 0
+	if !clamped {
+		p.Elevation = &v[2]
+	}
+	return p
+}
+
+// 출력한 KML을 지점으로 읽음 (gx:Track은 <when>/<gx:coord>, LineString은 <coordinates>)
+func readKML(t *testing.T, data []byte) (*kmlOutput, []models.GeoPoint) {
+	t.Helper()
+	var doc kmlOutput
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	pm := doc.Document.Placemark
+	var points []models.GeoPoint
+	switch {
+	case pm.Track != nil:
+		if len(pm.Track.When) != len(pm.Track.Coords) {
+			t.Fatalf("when = %d, coord %d", len(pm.Track.When), len(pm.Track.Coords))
+		}
+		for i, c := range pm.Track.Coords {
+			p := parseKMLCoord(t, c, " ", pm.Track.AltitudeMode == "clampToGround")
+			ts, err := time.Parse(time.RFC3339Nano, pm.Track.When[i])
+			if err != nil {
+				t.Fatal(err)
+			}
+			p.Timestamp = &ts
+			points = append(points, p)
+		}
+	case pm.LineString != nil:
+		for _, c := range strings.Fields(pm.LineString.Coordinates) {
+			points = append(points, parseKMLCoord(t, c, ",", pm.LineString.AltitudeMode == "clampToGround"))
+		}
+	default:
+		t.Fatalf("placemark has no geometry: %+v", pm)
+	}
+	return &doc, points
+}
+
+func TestKMLRoundTrip(t *testing.T) {
+	ride := testRide()
+	track := FromRide(ride)
+	doc, points := readKML(t, export(t, FormatKML, track))
+	checkRoundTrip(t, FormatKML, points, track.Points)
+	pm := doc.Document.Placemark
+	if pm.Name != track.Name || pm.Track == nil || pm.TimeSpan == nil {
+		t.Fatalf("ride placemark = %+v", pm)
+	}
+	if pm.TimeSpan.Begin != testStart.Format(time.RFC3339Nano) {
+		t.Errorf("time span begin = %s", pm.TimeSpan.Begin)
+	}
+
+	route := testRoute(t)
+	track, err := FromRoute(route)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, points = readKML(t, export(t, FormatKML, track))
+	checkRoundTrip(t, FormatKML, points, track.Points)
+	pm = doc.Document.Placemark
+	if pm.Name != route.Name || pm.Description != route.Description || pm.LineString == nil || pm.Track != nil {
+		t.Fatalf("route placemark = %+v", pm)
+	}
+	tags := ""
+	for _, d := range pm.ExtendedData {
+		if d.Name == "tags" {
+			tags = d.Value
+		}
+	}
+	if tags != "river,night" {
+		t.Errorf("tags = %q", tags)
+	}
+
+	for _, track := range []*Track{bareTrack(), untimedTrack()} {
+		_, points := readKML(t, export(t, FormatKML, track))
+		checkRoundTrip(t, FormatKML, points, track.Points)
+	}
+}
@@ -0,0 +1,67 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+type geoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   geoJSONGeometry        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type geoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates [][]float64 `json:"coordinates"`
+}
+
+// GeoJSON Feature(LineString)로 출력
+// 좌표는 [경도, 위도, 고도] 순서이며, 지점별 시각은 properties.coordTimes에 저장
+func writeGeoJSON(w io.Writer, t *Track) error {
+	coords := make([][]float64, len(t.Points))
+	var times []string
+	for i, p := range t.Points {
+		coord := []float64{p.Longitude, p.Latitude}
+		if p.Elevation != nil {
+			coord = append(coord, *p.Elevation)
+		}
+		coords[i] = coord
+
+		if p.Timestamp != nil {
+			times = append(times, p.Timestamp.UTC().Format(time.RFC3339Nano))
+		}
+	}
+
+	props := map[string]interface{}{
+		"name":        t.Name,
+		"description": t.Description,
+		"distance_km": t.Distance,
+		"duration_s":  t.Duration.Seconds(),
+	}
+	if t.Activity {
+		props["type"] = "ride"
+		props["start_time"] = t.StartTime.UTC().Format(time.RFC3339)
+	} else {
+		props["type"] = "route"
+	}
+	if len(t.Tags) > 0 {
+		props["tags"] = t.Tags
+	}
+	// 모든 지점에 시각이 있을 때만 포함 (좌표와 인덱스가 일치해야 함)
+	if len(times) == len(t.Points) {
+		props["coordTimes"] = times
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(geoJSONFeature{
+		Type: "Feature",
+		Geometry: geoJSONGeometry{
+			Type:        "LineString",
+			Coordinates: coords,
+		},
+		Properties: props,
+	})
+}
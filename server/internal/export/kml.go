@@ -0,0 +1,149 @@
+package export
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	namespaceKML = "http://www.opengis.net/kml/2.2"
+	namespaceGX  = "http://www.google.com/kml/ext/2.2"
+)
+
+type kmlDocument struct {
+	XMLName  xml.Name `xml:"kml"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	XmlnsGX  string   `xml:"xmlns:gx,attr"`
+	Document kmlInner `xml:"Document"`
+}
+
+type kmlInner struct {
+	Name        string       `xml:"name"`
+	Description string       `xml:"description,omitempty"`
+	Placemark   kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlPlacemark struct {
+	Name         string         `xml:"name"`
+	Description  string         `xml:"description,omitempty"`
+	TimeSpan     *kmlTimeSpan   `xml:"TimeSpan,omitempty"`
+	ExtendedData []kmlData      `xml:"ExtendedData>Data"`
+	LineString   *kmlLineString `xml:"LineString,omitempty"`
+	Track        *kmlTrack      `xml:"gx:Track,omitempty"`
+}
+
+type kmlTimeSpan struct {
+	Begin string `xml:"begin"`
+	End   string `xml:"end"`
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+type kmlLineString struct {
+	Tessellate   int    `xml:"tessellate"`
+	AltitudeMode string `xml:"altitudeMode"`
+	Coordinates  string `xml:"coordinates"`
+}
+
+type kmlTrack struct {
+	AltitudeMode string   `xml:"altitudeMode"`
+	When         []string `xml:"when"`
+	Coords       []string `xml:"gx:coord"`
+}
+
+// KML 2.2로 출력
+// 모든 지점에 시각이 있는 라이드는 gx:Track, 그 외에는 LineString 사용
+func writeKML(w io.Writer, t *Track) error {
+	placemark := kmlPlacemark{
+		Name:        t.Name,
+		Description: t.Description,
+		ExtendedData: []kmlData{
+			{Name: "distance_km", Value: strconv.FormatFloat(t.Distance, 'f', -1, 64)},
+			{Name: "duration_s", Value: strconv.FormatFloat(t.Duration.Seconds(), 'f', -1, 64)},
+		},
+	}
+	if len(t.Tags) > 0 {
+		placemark.ExtendedData = append(placemark.ExtendedData, kmlData{Name: "tags", Value: strings.Join(t.Tags, ",")})
+	}
+
+	altitudeMode := "clampToGround"
+	for _, p := range t.Points {
+		if p.Elevation != nil {
+			altitudeMode = "absolute"
+			break
+		}
+	}
+
+	if t.Activity && allTimestamped(t) {
+		track := &kmlTrack{AltitudeMode: altitudeMode}
+		for _, p := range t.Points {
+			track.When = append(track.When, p.Timestamp.UTC().Format(time.RFC3339Nano))
+			track.Coords = append(track.Coords, kmlCoord(p.Longitude, p.Latitude, p.Elevation, " "))
+		}
+		placemark.Track = track
+		placemark.TimeSpan = &kmlTimeSpan{
+			Begin: track.When[0],
+			End:   track.When[len(track.When)-1],
+		}
+	} else {
+		coords := make([]string, len(t.Points))
+		for i, p := range t.Points {
+			coords[i] = kmlCoord(p.Longitude, p.Latitude, p.Elevation, ",")
+		}
+		placemark.LineString = &kmlLineString{
+			Tessellate:   1,
+			AltitudeMode: altitudeMode,
+			Coordinates:  strings.Join(coords, " "),
+		}
+	}
+
+	doc := kmlDocument{
+		Xmlns:   namespaceKML,
+		XmlnsGX: namespaceGX,
+		Document: kmlInner{
+			Name:        t.Name,
+			Description: t.Description,
+			Placemark:   placemark,
+		},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// KML 좌표 문자열 (LineString은 ',' 구분, gx:coord는 공백 구분)
+func kmlCoord(lon, lat float64, ele *float64, sep string) string {
+	parts := []string{
+		strconv.FormatFloat(lon, 'f', -1, 64),
+		strconv.FormatFloat(lat, 'f', -1, 64),
+	}
+	if ele != nil {
+		parts = append(parts, strconv.FormatFloat(*ele, 'f', -1, 64))
+	} else {
+		parts = append(parts, "0")
+	}
+	return strings.Join(parts, sep)
+}
+
+func allTimestamped(t *Track) bool {
+	for _, p := range t.Points {
+		if p.Timestamp == nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package export
+
+import (
+	"io"
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/tcx"
+)
+
+// 시각 정보가 없는 코스의 트랙포인트 시각을 만들 때 사용하는 가상 속도 (m/s, 약 20km/h)
+const courseVirtualSpeed = 20 / 3.6
+
+func writeTCX(w io.Writer, t *Track) error {
+	return tcx.Write(w, toTCX(t))
+}
+
+func toTCX(t *Track) *tcx.Database {
+	points := toTrackpoints(t)
+
+	if t.Activity {
+		return &tcx.Database{
+			Activities: []tcx.Activity{{
+				Sport: "Biking",
+				ID:    t.StartTime,
+				Notes: t.Description,
+				Laps: []tcx.Lap{{
+					StartTime: t.StartTime,
+					TotalTime: t.Duration,
+					Distance:  t.Distance * 1000,
+					Calories:  int(t.Calories + 0.5),
+					Points:    points,
+				}},
+			}},
+		}
+	}
+
+	return &tcx.Database{
+		Courses: []tcx.Course{{
+			Name:         t.Name,
+			Notes:        t.Description,
+			Distance:     t.Distance * 1000,
+			TotalTime:    t.Duration,
+			Points:       points,
+			CoursePoints: coursePoints(t.Cues, points),
+		}},
+	}
+}
+
+// TCX 트랙포인트는 시각이 필수이므로 시각이 없는 지점은
+// 시작 시각과 가상 속도로 계산한 시각을 사용
+func toTrackpoints(t *Track) []tcx.Trackpoint {
+	base := t.StartTime
+	if base.IsZero() {
+		base = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	cum := analysis.CumulativeDistances(t.Points)
+	points := make([]tcx.Trackpoint, len(t.Points))
+	for i, p := range t.Points {
+		lat, lon, dist := p.Latitude, p.Longitude, cum[i]
+		tp := tcx.Trackpoint{
+			Latitude:  &lat,
+			Longitude: &lon,
+			Altitude:  p.Elevation,
+			Distance:  &dist,
+		}
+		if s := t.Streams; s != nil {
+			tp.HeartRate = streamValue(s.HeartRate, i)
+			tp.Cadence = streamValue(s.Cadence, i)
+			tp.Power = streamValue(s.Power, i)
+			if kmh := streamValue(s.Speed, i); kmh != nil {
+				speed := *kmh / 3.6
+				tp.Speed = &speed
+			}
+		}
+		if p.Timestamp != nil {
+			tp.Time = *p.Timestamp
+		} else {
+			tp.Time = base.Add(time.Duration(dist / courseVirtualSpeed * float64(time.Second)))
+		}
+		points[i] = tp
+	}
+	return points
+}
@@ -0,0 +1,74 @@
+package fit
+
+import (
+	"fmt"
+
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+// ToRide FIT 활동 파일로부터 라이드 생성
+// 위치 정보가 있는 record만 트랙 지점으로 사용하며
+// 심박/케이던스/파워/속도/온도/좌우 밸런스는 같은 인덱스의 센서 스트림으로 저장
+// 통계 필드는 analysis.ApplyToRide로 별도 계산해야 함
+func ToRide(f *File) (*models.Ride, error) {
+	if f.FileID.Type != 0 && f.FileID.Type != FileTypeActivity {
+		return nil, fmt.Errorf("fit file type %d is not an activity", f.FileID.Type)
+	}
+
+	var records []Record
+	for _, r := range f.Records {
+		if r.Latitude != nil && r.Longitude != nil {
+			records = append(records, r)
+		}
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("fit file contains %d positioned records, at least 2 required", len(records))
+	}
+
+	ride := &models.Ride{
+		StartTime: records[0].Timestamp,
+		EndTime:   records[len(records)-1].Timestamp,
+		Locations: make([]models.GeoPoint, len(records)),
+	}
+	streams := models.NewSensorStreams(len(records))
+	for i, r := range records {
+		ts := r.Timestamp
+		ride.Locations[i] = models.GeoPoint{
+			Latitude:  *r.Latitude,
+			Longitude: *r.Longitude,
+			Elevation: r.Altitude,
+			Timestamp: &ts,
+		}
+		streams.HeartRate[i] = r.HeartRate
+		streams.Cadence[i] = r.Cadence
+		streams.Power[i] = r.Power
+		streams.Temperature[i] = r.Temperature
+		if r.Speed != nil {
+			kmh := *r.Speed * 3.6
+			streams.Speed[i] = &kmh
+		}
+		streams.Balance[i] = leftBalance(r.LeftRightBalance)
+	}
+	ride.Streams = streams.Compact()
+
+	if len(f.Sessions) > 0 {
+		session := f.Sessions[0]
+		if !session.StartTime.IsZero() && session.StartTime.Before(ride.StartTime) {
+			ride.StartTime = session.StartTime
+		}
+		for _, s := range f.Sessions {
+			ride.Calories += float64(s.Calories)
+		}
+	}
+	return ride, nil
+}
+
+// 좌우 밸런스 원시값을 왼쪽 다리 비율(%)로 변환
+// 오른쪽 비트가 없으면 어느 쪽 값인지 알 수 없으므로 사용하지 않음
+func leftBalance(raw *int) *float64 {
+	if raw == nil || *raw&0x80 == 0 || *raw&0x7f > 100 {
+		return nil
+	}
+	left := float64(100 - *raw&0x7f)
+	return &left
+}
@@ -0,0 +1,265 @@
+// fit 패키지는 Garmin FIT(Flexible and Interoperable Data Transfer) 활동 파일을 해석합니다.
+// 파일 헤더, 정의/데이터 메시지, 압축 타임스탬프 헤더, 개발자 필드(건너뜀), CRC 검사를 지원하며
+// record/lap/session/file_id 메시지를 읽습니다.
+package fit
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	headerTypeMask       = 0x80 // 압축 타임스탬프 헤더
+	definitionFlag       = 0x40 // 정의 메시지
+	developerDataFlag    = 0x20 // 개발자 필드 포함 정의 메시지
+	localMessageTypeMask = 0x0F
+
+	compressedLocalTypeMask  = 0x60
+	compressedTimeOffsetMask = 0x1F
+
+	maxLocalMessageTypes = 16
+)
+
+// Header FIT 파일 헤더
+type Header struct {
+	Size            uint8
+	ProtocolVersion uint8
+	ProfileVersion  uint16
+	DataSize        uint32
+	DataType        string // ".FIT"
+	CRC             uint16 // 14바이트 헤더에만 존재 (0이면 검사 생략)
+}
+
+// 정의 메시지의 필드 정의
+type fieldDefinition struct {
+	num      uint8
+	size     uint8
+	baseType uint8
+}
+
+// 로컬 메시지 타입별 정의
+type definition struct {
+	globalNum      uint16
+	bigEndian      bool
+	fields         []fieldDefinition
+	developerBytes int // 개발자 필드 전체 크기 (읽지 않고 건너뜀)
+}
+
+// message 해석된 데이터 메시지
+// 값은 필드 번호별로 저장되며 무효값(invalid)인 필드는 포함되지 않음
+type message struct {
+	globalNum uint16
+	fields    map[uint8]value
+}
+
+type value struct {
+	i     int64
+	f     float64
+	float bool
+}
+
+func (v value) int() int64 {
+	if v.float {
+		return int64(v.f)
+	}
+	return v.i
+}
+
+func (v value) float64() float64 {
+	if v.float {
+		return v.f
+	}
+	return float64(v.i)
+}
+
+type decoder struct {
+	data        []byte
+	pos         int
+	definitions [maxLocalMessageTypes]*definition
+	lastTime    uint32
+}
+
+// Decode FIT 파일 해석
+// 헤더와 파일 끝의 CRC를 검사하며, 체인된 FIT 파일은 첫 번째 파일만 읽음
+func Decode(r io.Reader) (*File, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := readHeader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	end := int(header.Size) + int(header.DataSize)
+	if len(data) < end+2 {
+		return nil, fmt.Errorf("fit: truncated file (expected %d bytes, got %d)", end+2, len(data))
+	}
+	if crc := binary.LittleEndian.Uint16(data[end : end+2]); crc != checksum(data[:end]) {
+		return nil, fmt.Errorf("fit: file crc mismatch")
+	}
+
+	d := &decoder{data: data[:end], pos: int(header.Size)}
+	file := &File{Header: *header}
+	for d.pos < len(d.data) {
+		msg, err := d.next()
+		if err != nil {
+			return nil, err
+		}
+		if msg != nil {
+			file.add(msg)
+		}
+	}
+	return file, nil
+}
+
+func readHeader(data []byte) (*Header, error) {
+	if len(data) < 12 {
+		return nil, fmt.Errorf("fit: file too short")
+	}
+
+	h := &Header{
+		Size:            data[0],
+		ProtocolVersion: data[1],
+		ProfileVersion:  binary.LittleEndian.Uint16(data[2:4]),
+		DataSize:        binary.LittleEndian.Uint32(data[4:8]),
+		DataType:        string(data[8:12]),
+	}
+	if h.Size != 12 && h.Size != 14 {
+		return nil, fmt.Errorf("fit: invalid header size %d", h.Size)
+	}
+	if h.DataType != ".FIT" {
+		return nil, fmt.Errorf("fit: invalid data type %q", h.DataType)
+	}
+	if h.ProtocolVersion>>4 > 2 {
+		return nil, fmt.Errorf("fit: unsupported protocol version %d.%d", h.ProtocolVersion>>4, h.ProtocolVersion&0x0F)
+	}
+	if h.Size == 14 {
+		if len(data) < 14 {
+			return nil, fmt.Errorf("fit: file too short")
+		}
+		h.CRC = binary.LittleEndian.Uint16(data[12:14])
+		if h.CRC != 0 && h.CRC != checksum(data[:12]) {
+			return nil, fmt.Errorf("fit: header crc mismatch")
+		}
+	}
+	return h, nil
+}
+
+// 다음 레코드 해석, 정의 메시지인 경우 nil 반환
+func (d *decoder) next() (*message, error) {
+	header, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	// 압축 타임스탬프 헤더: 로컬 타입(2비트) + 직전 타임스탬프로부터의 오프셋(5비트)
+	if header&headerTypeMask != 0 {
+		local := (header & compressedLocalTypeMask) >> 5
+		offset := uint32(header & compressedTimeOffsetMask)
+		d.lastTime += (offset - d.lastTime&compressedTimeOffsetMask) & compressedTimeOffsetMask
+		msg, err := d.readData(local)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := msg.fields[fieldTimestamp]; !ok {
+			msg.fields[fieldTimestamp] = value{i: int64(d.lastTime)}
+		}
+		return msg, nil
+	}
+
+	local := header & localMessageTypeMask
+	if header&definitionFlag != 0 {
+		return nil, d.readDefinition(local, header&developerDataFlag != 0)
+	}
+	return d.readData(local)
+}
+
+func (d *decoder) readDefinition(local uint8, developer bool) error {
+	buf, err := d.read(5)
+	if err != nil {
+		return err
+	}
+
+	def := &definition{bigEndian: buf[1] == 1}
+	if def.bigEndian {
+		def.globalNum = binary.BigEndian.Uint16(buf[2:4])
+	} else {
+		def.globalNum = binary.LittleEndian.Uint16(buf[2:4])
+	}
+
+	fieldCount := int(buf[4])
+	fields, err := d.read(fieldCount * 3)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < fieldCount; i++ {
+		def.fields = append(def.fields, fieldDefinition{
+			num:      fields[i*3],
+			size:     fields[i*3+1],
+			baseType: fields[i*3+2],
+		})
+	}
+
+	if developer {
+		count, err := d.readByte()
+		if err != nil {
+			return err
+		}
+		devFields, err := d.read(int(count) * 3)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < int(count); i++ {
+			def.developerBytes += int(devFields[i*3+1])
+		}
+	}
+
+	d.definitions[local] = def
+	return nil
+}
+
+func (d *decoder) readData(local uint8) (*message, error) {
+	def := d.definitions[local]
+	if def == nil {
+		return nil, fmt.Errorf("fit: data message for undefined local type %d at offset %d", local, d.pos)
+	}
+
+	msg := &message{globalNum: def.globalNum, fields: make(map[uint8]value, len(def.fields))}
+	for _, f := range def.fields {
+		raw, err := d.read(int(f.size))
+		if err != nil {
+			return nil, err
+		}
+		if v, ok := decodeValue(raw, f.baseType, def.bigEndian); ok {
+			msg.fields[f.num] = v
+		}
+	}
+	if _, err := d.read(def.developerBytes); err != nil {
+		return nil, err
+	}
+
+	if ts, ok := msg.fields[fieldTimestamp]; ok {
+		d.lastTime = uint32(ts.i)
+	}
+	return msg, nil
+}
+
+func (d *decoder) read(n int) ([]byte, error) {
+	if d.pos+n > len(d.data) {
+		return nil, fmt.Errorf("fit: unexpected end of data at offset %d", d.pos)
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *decoder) readByte() (byte, error) {
+	b, err := d.read(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
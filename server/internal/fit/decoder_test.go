@@ -0,0 +1,157 @@
+package fit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testdata/activity.fit: file_id 1개, 타임스탬프가 있는 record 1개와
+// 압축 타임스탬프 헤더를 쓰는 record 3개 (오프셋 31, 1, 2로 5비트 경계를 넘음)
+const fixtureStart = 1000000030
+
+func readFixture(t *testing.T) []byte {
+	t.Helper()
+	data, err := os.ReadFile("testdata/activity.fit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestDecodeRejectsInvalidFile(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func([]byte) []byte
+		want   string
+	}{
+		{"data type", func(b []byte) []byte { copy(b[8:12], ".TXT"); return b }, "invalid data type"},
+		{"header size", func(b []byte) []byte { b[0] = 13; return b }, "invalid header size"},
+		{"header crc", func(b []byte) []byte { b[12] ^= 0xFF; return b }, "header crc mismatch"},
+		{"file crc", func(b []byte) []byte { b[len(b)-3] ^= 0x01; return b }, "file crc mismatch"},
+		{"truncated", func(b []byte) []byte { return b[:len(b)-10] }, "truncated"},
+		{"too short", func(b []byte) []byte { return b[:8] }, "too short"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.mutate(readFixture(t))
+			_, err := Decode(bytes.NewReader(data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Decode error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeCompressedTimestamps(t *testing.T) {
+	f, err := Decode(bytes.NewReader(readFixture(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.FileID.Type != FileTypeActivity {
+		t.Errorf("file type = %d, want %d", f.FileID.Type, FileTypeActivity)
+	}
+	if len(f.Records) != 4 {
+		t.Fatalf("records = %d, want 4", len(f.Records))
+	}
+
+	start := fitEpoch.Add(fixtureStart * time.Second)
+	for i, offset := range []int{0, 1, 3, 4} {
+		want := start.Add(time.Duration(offset) * time.Second)
+		if got := f.Records[i].Timestamp; !got.Equal(want) {
+			t.Errorf("record %d timestamp = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestDecodeIgnoresZeroHeaderCRC(t *testing.T) {
+	data := readFixture(t)
+	binary.LittleEndian.PutUint16(data[12:14], 0)
+	// 파일 CRC는 헤더를 포함하므로 다시 계산
+	end := len(data) - 2
+	binary.LittleEndian.PutUint16(data[end:], checksum(data[:end]))
+	if _, err := Decode(bytes.NewReader(data)); err != nil {
+		t.Fatalf("Decode with zero header crc: %v", err)
+	}
+}
+
+func TestToRideSensorStreams(t *testing.T) {
+	f, err := Decode(bytes.NewReader(readFixture(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ride, err := ToRide(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ride.Locations) != 4 {
+		t.Fatalf("locations = %d, want 4", len(ride.Locations))
+	}
+	if got := ride.Locations[0].Latitude; got < 37.49999 || got > 37.50001 {
+		t.Errorf("latitude = %v, want 37.5", got)
+	}
+	if e := ride.Locations[2].Elevation; e == nil || *e != 52 {
+		t.Errorf("elevation = %v, want 52", e)
+	}
+	if want := fitEpoch.Add(fixtureStart * time.Second); !ride.StartTime.Equal(want) {
+		t.Errorf("start time = %v, want %v", ride.StartTime, want)
+	}
+
+	s := ride.Streams
+	if s == nil {
+		t.Fatal("streams = nil")
+	}
+	wantHR := []int{120, 121, 122, -1}
+	for i, want := range wantHR {
+		got := s.HeartRate[i]
+		if want < 0 {
+			if got != nil {
+				t.Errorf("heart rate %d = %d, want nil", i, *got)
+			}
+			continue
+		}
+		if got == nil || *got != want {
+			t.Errorf("heart rate %d = %v, want %d", i, got, want)
+		}
+	}
+	for i, want := range []int{80, 81, 82, 83} {
+		if got := s.Cadence[i]; got == nil || *got != want {
+			t.Errorf("cadence %d = %v, want %d", i, got, want)
+		}
+	}
+	for i, want := range []int{200, 210, 220, 230} {
+		if got := s.Power[i]; got == nil || *got != want {
+			t.Errorf("power %d = %v, want %d", i, got, want)
+		}
+	}
+	for i, want := range []float64{21, 22, 22, 22} {
+		if got := s.Temperature[i]; got == nil || *got != want {
+			t.Errorf("temperature %d = %v, want %v", i, got, want)
+		}
+	}
+	// 0x80|48: 오른쪽 48% → 왼쪽 52%, 0xFF는 무효값
+	if got := s.Balance[0]; got == nil || *got != 52 {
+		t.Errorf("balance 0 = %v, want 52", got)
+	}
+	if s.Balance[1] != nil {
+		t.Errorf("balance 1 = %v, want nil", *s.Balance[1])
+	}
+	if s.Speed != nil {
+		t.Errorf("speed stream = %v, want nil (no speed field)", s.Speed)
+	}
+}
+
+func TestToRideRejectsNonActivity(t *testing.T) {
+	f, err := Decode(bytes.NewReader(readFixture(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.FileID.Type = 6 // course
+	if _, err := ToRide(f); err == nil {
+		t.Fatal("ToRide accepted a non-activity file")
+	}
+}
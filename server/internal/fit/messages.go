@@ -0,0 +1,270 @@
+package fit
+
+import (
+	"time"
+)
+
+// 전역 메시지 번호 (FIT SDK Profile)
+const (
+	mesgFileID  = 0
+	mesgSession = 18
+	mesgLap     = 19
+	mesgRecord  = 20
+)
+
+// 모든 메시지에서 공통으로 사용되는 timestamp 필드 번호
+const fieldTimestamp = 253
+
+// record 메시지 필드 번호
+const (
+	recordPositionLat      = 0
+	recordPositionLong     = 1
+	recordAltitude         = 2
+	recordHeartRate        = 3
+	recordCadence          = 4
+	recordDistance         = 5
+	recordSpeed            = 6
+	recordPower            = 7
+	recordTemperature      = 13
+	recordLeftRightBalance = 30
+	recordEnhancedSpeed    = 73
+	recordEnhancedAltitude = 78
+)
+
+// lap/session 메시지 공통 필드 번호
+const (
+	lapStartTime        = 2
+	lapTotalElapsedTime = 7
+	lapTotalTimerTime   = 8
+	lapTotalDistance    = 9
+	lapTotalCalories    = 11
+	lapAvgHeartRate     = 15
+	lapMaxHeartRate     = 16
+	lapAvgPower         = 19
+	lapMaxPower         = 20
+	lapTotalAscent      = 21
+	lapTotalDescent     = 22
+
+	sessionSport         = 5
+	sessionAvgHeartRate  = 16
+	sessionMaxHeartRate  = 17
+	sessionAvgPower      = 20
+	sessionMaxPower      = 21
+	sessionTotalAscent   = 22
+	sessionTotalDescent  = 23
+	sessionEnhancedSpeed = 124
+)
+
+// file_id 메시지 필드 번호
+const (
+	fileIDType         = 0
+	fileIDManufacturer = 1
+	fileIDProduct      = 2
+	fileIDTimeCreated  = 4
+)
+
+// FileTypeActivity file_id.type 값 중 활동 파일
+const FileTypeActivity = 4
+
+// SportCycling session.sport 값 중 사이클링
+const SportCycling = 2
+
+// FIT 타임스탬프 기준 시각 (1989-12-31 00:00:00 UTC)
+var fitEpoch = time.Date(1989, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// File 해석된 FIT 파일
+type File struct {
+	Header   Header
+	FileID   FileID
+	Records  []Record
+	Laps     []Lap
+	Sessions []Session
+}
+
+// FileID file_id 메시지
+type FileID struct {
+	Type         int
+	Manufacturer int
+	Product      int
+	TimeCreated  time.Time
+}
+
+// Record record 메시지 (1개의 트랙 샘플)
+// 측정되지 않은 값은 nil
+type Record struct {
+	Timestamp   time.Time
+	Latitude    *float64 // 도
+	Longitude   *float64 // 도
+	Altitude    *float64 // 미터
+	HeartRate   *int     // bpm
+	Cadence     *int     // rpm
+	Distance    *float64 // 누적 거리 (미터)
+	Speed       *float64 // m/s
+	Power       *int     // 와트
+	Temperature *float64 // 섭씨
+
+	// 좌우 파워 밸런스 원시값
+	// 0x80 비트가 설정되어 있으면 하위 7비트가 오른쪽 다리 기여율(%)
+	LeftRightBalance *int
+}
+
+// Lap lap 메시지
+type Lap struct {
+	StartTime    time.Time
+	Timestamp    time.Time
+	ElapsedTime  time.Duration
+	TimerTime    time.Duration
+	Distance     float64 // 미터
+	Calories     int
+	AvgHeartRate *int
+	MaxHeartRate *int
+	AvgPower     *int
+	MaxPower     *int
+	TotalAscent  *float64 // 미터
+	TotalDescent *float64 // 미터
+}
+
+// Session session 메시지 (활동 전체 요약)
+type Session struct {
+	Lap
+	Sport    int
+	AvgSpeed *float64 // m/s
+}
+
+// 데이터 메시지를 File에 반영
+func (f *File) add(msg *message) {
+	switch msg.globalNum {
+	case mesgFileID:
+		f.FileID = FileID{
+			Type:         int(msg.intOr(fileIDType, 0)),
+			Manufacturer: int(msg.intOr(fileIDManufacturer, 0)),
+			Product:      int(msg.intOr(fileIDProduct, 0)),
+		}
+		if t, ok := msg.time(fileIDTimeCreated); ok {
+			f.FileID.TimeCreated = t
+		}
+	case mesgRecord:
+		if r, ok := decodeRecord(msg); ok {
+			f.Records = append(f.Records, r)
+		}
+	case mesgLap:
+		f.Laps = append(f.Laps, decodeLap(msg))
+	case mesgSession:
+		s := Session{
+			Lap:   decodeLap(msg),
+			Sport: int(msg.intOr(sessionSport, 0)),
+		}
+		// session은 lap과 일부 필드 번호가 다름
+		s.AvgHeartRate = msg.intPtr(sessionAvgHeartRate)
+		s.MaxHeartRate = msg.intPtr(sessionMaxHeartRate)
+		s.AvgPower = msg.intPtr(sessionAvgPower)
+		s.MaxPower = msg.intPtr(sessionMaxPower)
+		s.TotalAscent = msg.scaled(sessionTotalAscent, 1, 0)
+		s.TotalDescent = msg.scaled(sessionTotalDescent, 1, 0)
+		s.AvgSpeed = msg.scaled(sessionEnhancedSpeed, 1000, 0)
+		f.Sessions = append(f.Sessions, s)
+	}
+}
+
+func decodeRecord(msg *message) (Record, bool) {
+	ts, ok := msg.time(fieldTimestamp)
+	if !ok {
+		return Record{}, false
+	}
+
+	r := Record{
+		Timestamp:        ts,
+		Latitude:         msg.semicircles(recordPositionLat),
+		Longitude:        msg.semicircles(recordPositionLong),
+		HeartRate:        msg.intPtr(recordHeartRate),
+		Cadence:          msg.intPtr(recordCadence),
+		Distance:         msg.scaled(recordDistance, 100, 0),
+		Power:            msg.intPtr(recordPower),
+		Temperature:      msg.scaled(recordTemperature, 1, 0),
+		LeftRightBalance: msg.intPtr(recordLeftRightBalance),
+	}
+
+	// enhanced 필드가 있으면 우선 사용 (더 넓은 범위)
+	r.Altitude = msg.scaled(recordEnhancedAltitude, 5, 500)
+	if r.Altitude == nil {
+		r.Altitude = msg.scaled(recordAltitude, 5, 500)
+	}
+	r.Speed = msg.scaled(recordEnhancedSpeed, 1000, 0)
+	if r.Speed == nil {
+		r.Speed = msg.scaled(recordSpeed, 1000, 0)
+	}
+	return r, true
+}
+
+func decodeLap(msg *message) Lap {
+	lap := Lap{
+		ElapsedTime:  msg.duration(lapTotalElapsedTime),
+		TimerTime:    msg.duration(lapTotalTimerTime),
+		Calories:     int(msg.intOr(lapTotalCalories, 0)),
+		AvgHeartRate: msg.intPtr(lapAvgHeartRate),
+		MaxHeartRate: msg.intPtr(lapMaxHeartRate),
+		AvgPower:     msg.intPtr(lapAvgPower),
+		MaxPower:     msg.intPtr(lapMaxPower),
+		TotalAscent:  msg.scaled(lapTotalAscent, 1, 0),
+		TotalDescent: msg.scaled(lapTotalDescent, 1, 0),
+	}
+	if d := msg.scaled(lapTotalDistance, 100, 0); d != nil {
+		lap.Distance = *d
+	}
+	lap.StartTime, _ = msg.time(lapStartTime)
+	lap.Timestamp, _ = msg.time(fieldTimestamp)
+	return lap
+}
+
+func (m *message) intOr(field uint8, fallback int64) int64 {
+	if v, ok := m.fields[field]; ok {
+		return v.int()
+	}
+	return fallback
+}
+
+func (m *message) intPtr(field uint8) *int {
+	v, ok := m.fields[field]
+	if !ok {
+		return nil
+	}
+	i := int(v.int())
+	return &i
+}
+
+// 값 = 원시값 / scale - offset (FIT Profile의 scale/offset 규칙)
+func (m *message) scaled(field uint8, scale, offset float64) *float64 {
+	v, ok := m.fields[field]
+	if !ok {
+		return nil
+	}
+	f := v.float64()/scale - offset
+	return &f
+}
+
+// 세미서클 단위 좌표를 도 단위로 변환
+func (m *message) semicircles(field uint8) *float64 {
+	v, ok := m.fields[field]
+	if !ok {
+		return nil
+	}
+	deg := float64(v.int()) * (180.0 / (1 << 31))
+	return &deg
+}
+
+// 밀리초 단위(scale 1000) 시간 필드
+func (m *message) duration(field uint8) time.Duration {
+	v, ok := m.fields[field]
+	if !ok {
+		return 0
+	}
+	return time.Duration(v.float64()) * time.Millisecond
+}
+
+func (m *message) time(field uint8) (time.Time, bool) {
+	v, ok := m.fields[field]
+	if !ok {
+		return time.Time{}, false
+	}
+	return fitEpoch.Add(time.Duration(v.int()) * time.Second), true
+}
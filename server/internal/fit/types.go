@@ -0,0 +1,150 @@
+package fit
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// FIT 기본 타입
+const (
+	baseEnum    = 0x00
+	baseSint8   = 0x01
+	baseUint8   = 0x02
+	baseSint16  = 0x83
+	baseUint16  = 0x84
+	baseSint32  = 0x85
+	baseUint32  = 0x86
+	baseString  = 0x07
+	baseFloat32 = 0x88
+	baseFloat64 = 0x89
+	baseUint8z  = 0x0A
+	baseUint16z = 0x8B
+	baseUint32z = 0x8C
+	baseByte    = 0x0D
+	baseSint64  = 0x8E
+	baseUint64  = 0x8F
+	baseUint64z = 0x90
+)
+
+// 필드 원시값을 해석
+// 무효값(타입별 invalid 값)이거나 숫자가 아닌 필드는 false 반환
+// 배열 필드는 첫 번째 원소만 사용
+func decodeValue(raw []byte, baseType uint8, bigEndian bool) (value, bool) {
+	var order binary.ByteOrder = binary.LittleEndian
+	if bigEndian {
+		order = binary.BigEndian
+	}
+
+	switch baseType {
+	case baseEnum, baseUint8, baseByte:
+		if len(raw) < 1 || raw[0] == 0xFF {
+			return value{}, false
+		}
+		return value{i: int64(raw[0])}, true
+	case baseUint8z:
+		if len(raw) < 1 || raw[0] == 0 {
+			return value{}, false
+		}
+		return value{i: int64(raw[0])}, true
+	case baseSint8:
+		if len(raw) < 1 || raw[0] == 0x7F {
+			return value{}, false
+		}
+		return value{i: int64(int8(raw[0]))}, true
+	case baseUint16, baseUint16z:
+		if len(raw) < 2 {
+			return value{}, false
+		}
+		v := order.Uint16(raw)
+		if (baseType == baseUint16 && v == 0xFFFF) || (baseType == baseUint16z && v == 0) {
+			return value{}, false
+		}
+		return value{i: int64(v)}, true
+	case baseSint16:
+		if len(raw) < 2 {
+			return value{}, false
+		}
+		v := order.Uint16(raw)
+		if v == 0x7FFF {
+			return value{}, false
+		}
+		return value{i: int64(int16(v))}, true
+	case baseUint32, baseUint32z:
+		if len(raw) < 4 {
+			return value{}, false
+		}
+		v := order.Uint32(raw)
+		if (baseType == baseUint32 && v == 0xFFFFFFFF) || (baseType == baseUint32z && v == 0) {
+			return value{}, false
+		}
+		return value{i: int64(v)}, true
+	case baseSint32:
+		if len(raw) < 4 {
+			return value{}, false
+		}
+		v := order.Uint32(raw)
+		if v == 0x7FFFFFFF {
+			return value{}, false
+		}
+		return value{i: int64(int32(v))}, true
+	case baseUint64, baseUint64z:
+		if len(raw) < 8 {
+			return value{}, false
+		}
+		v := order.Uint64(raw)
+		if (baseType == baseUint64 && v == math.MaxUint64) || (baseType == baseUint64z && v == 0) {
+			return value{}, false
+		}
+		return value{i: int64(v)}, true
+	case baseSint64:
+		if len(raw) < 8 {
+			return value{}, false
+		}
+		v := order.Uint64(raw)
+		if v == 0x7FFFFFFFFFFFFFFF {
+			return value{}, false
+		}
+		return value{i: int64(v)}, true
+	case baseFloat32:
+		if len(raw) < 4 {
+			return value{}, false
+		}
+		bits := order.Uint32(raw)
+		if bits == 0xFFFFFFFF {
+			return value{}, false
+		}
+		return value{f: float64(math.Float32frombits(bits)), float: true}, true
+	case baseFloat64:
+		if len(raw) < 8 {
+			return value{}, false
+		}
+		bits := order.Uint64(raw)
+		if bits == math.MaxUint64 {
+			return value{}, false
+		}
+		return value{f: math.Float64frombits(bits), float: true}, true
+	}
+
+	// 문자열 등 숫자가 아닌 필드는 사용하지 않음
+	return value{}, false
+}
+
+// FIT CRC-16 (파일/헤더 검사용)
+var crcTable = [16]uint16{
+	0x0000, 0xCC01, 0xD801, 0x1400, 0xF001, 0x3C00, 0x2800, 0xE401,
+	0xA001, 0x6C00, 0x7800, 0xB401, 0x5000, 0x9C01, 0x8801, 0x4400,
+}
+
+func checksum(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		tmp := crcTable[crc&0xF]
+		crc = (crc >> 4) & 0x0FFF
+		crc = crc ^ tmp ^ crcTable[b&0xF]
+
+		tmp = crcTable[crc&0xF]
+		crc = (crc >> 4) & 0x0FFF
+		crc = crc ^ tmp ^ crcTable[(b>>4)&0xF]
+	}
+	return crc
+}
@@ -0,0 +1,86 @@
+package gpx
+
+import (
+	"fmt"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+// 공간 인덱스용 경로 선에서 지점 사이의 최소 간격 (미터)
+const geometrySpacing = 20.0
+
+// FillRoute GPX 경로로부터 Route의 거리, 상승 고도, 시작/종료 지점, 경로 선, 경사 특성과 난이도를 계산
+// points는 g의 트랙 지점 (g.GeoPoints()에 DEM 고도 보정 등을 적용한 값)
+// 이름/설명이 비어 있으면 GPX의 값을 사용하고
+// 트랙에 시각 정보가 있으면 이동 시간을 예상 소요 시간으로 설정
+func FillRoute(route *models.Route, g *GPX, points []models.GeoPoint) error {
+	if len(points) < 2 {
+		return fmt.Errorf("gpx contains %d points, at least 2 required", len(points))
+	}
+
+	m := analysis.Analyze(points)
+	route.Distance = m.Distance
+	route.ElevationGain = m.ElevationGain
+	route.StartPoint = points[0]
+	route.EndPoint = points[len(points)-1]
+	route.Geometry = models.NewGeoJSONLineString(thinPoints(points, geometrySpacing))
+	analysis.ApplyToRoute(route, points)
+	if m.HasTimestamps && m.MovingTime > 0 {
+		route.Duration = m.MovingTime
+	}
+
+	if route.Name == "" {
+		route.Name = g.DisplayName()
+	}
+	if route.Description == "" {
+		route.Description = g.Description
+	}
+	return nil
+}
+
+// 직전에 남긴 지점에서 spacing 미터 이상 떨어진 지점만 남김 (마지막 지점은 항상 포함)
+func thinPoints(points []models.GeoPoint, spacing float64) []models.GeoPoint {
+	kept := []models.GeoPoint{points[0]}
+	for _, p := range points[1 : len(points)-1] {
+		if analysis.Haversine(kept[len(kept)-1], p) >= spacing {
+			kept = append(kept, p)
+		}
+	}
+	return append(kept, points[len(points)-1])
+}
+
+// ToRide GPX 트랙으로부터 라이드 생성
+// 시작/종료 시각은 첫/마지막 지점의 시각을 사용하므로 트랙에 시각 정보가 있어야 함
+// 통계 필드는 analysis.ApplyToRide로 별도 계산해야 함
+func ToRide(g *GPX) (*models.Ride, error) {
+	points := g.GeoPoints()
+	if len(points) < 2 {
+		return nil, fmt.Errorf("gpx contains %d points, at least 2 required", len(points))
+	}
+
+	first, last := points[0], points[len(points)-1]
+	if first.Timestamp == nil || last.Timestamp == nil {
+		return nil, fmt.Errorf("gpx track has no timestamps")
+	}
+
+	src := g.Points()
+	streams := models.NewSensorStreams(len(src))
+	for i, p := range src {
+		streams.HeartRate[i] = p.HeartRate
+		streams.Cadence[i] = p.Cadence
+		streams.Power[i] = p.Power
+		streams.Temperature[i] = p.Temperature
+		if p.Speed != nil {
+			kmh := *p.Speed * 3.6
+			streams.Speed[i] = &kmh
+		}
+	}
+
+	return &models.Ride{
+		StartTime: *first.Timestamp,
+		EndTime:   *last.Timestamp,
+		Locations: points,
+		Streams:   streams.Compact(),
+	}, nil
+}
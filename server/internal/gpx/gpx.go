@@ -0,0 +1,356 @@
+// gpx 패키지는 GPX 1.0/1.1 파일을 해석하고 생성합니다.
+package gpx
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+// GPX 해석된 GPX 문서
+type GPX struct {
+	Version     string
+	Creator     string
+	Name        string
+	Description string
+	Time        *time.Time
+	Keywords    []string
+	Waypoints   []Point
+	Routes      []Route
+	Tracks      []Track
+}
+
+// Track <trk> 요소
+type Track struct {
+	Name        string
+	Description string
+	Type        string
+	Segments    []Segment
+}
+
+// Segment <trkseg> 요소
+type Segment struct {
+	Points []Point
+}
+
+// Route <rte> 요소
+type Route struct {
+	Name        string
+	Description string
+	Points      []Point
+}
+
+// Point <wpt>, <rtept>, <trkpt> 요소
+// 센서 값은 Garmin TrackPointExtension 등 확장 요소에서 읽음
+type Point struct {
+	Latitude    float64
+	Longitude   float64
+	Elevation   *float64
+	Time        *time.Time
+	Name        string
+	Description string
+	Type        string
+	HeartRate   *int     // bpm
+	Cadence     *int     // rpm
+	Power       *int     // 와트
+	Speed       *float64 // m/s
+	Temperature *float64 // 섭씨
+}
+
+// XML 매핑용 구조체
+// 네임스페이스를 지정하지 않아 GPX 1.0/1.1 모두 로컬 이름으로 매칭됨
+type xmlGPX struct {
+	XMLName   xml.Name     `xml:"gpx"`
+	Version   string       `xml:"version,attr"`
+	Creator   string       `xml:"creator,attr"`
+	Name      string       `xml:"name"`     // GPX 1.0
+	Desc      string       `xml:"desc"`     // GPX 1.0
+	Time      string       `xml:"time"`     // GPX 1.0
+	Keywords  string       `xml:"keywords"` // GPX 1.0
+	Metadata  *xmlMetadata `xml:"metadata"`
+	Waypoints []xmlPoint   `xml:"wpt"`
+	Routes    []xmlRoute   `xml:"rte"`
+	Tracks    []xmlTrack   `xml:"trk"`
+}
+
+type xmlMetadata struct {
+	Name     string `xml:"name"`
+	Desc     string `xml:"desc"`
+	Time     string `xml:"time"`
+	Keywords string `xml:"keywords"`
+}
+
+type xmlTrack struct {
+	Name     string       `xml:"name"`
+	Desc     string       `xml:"desc"`
+	Type     string       `xml:"type"`
+	Segments []xmlSegment `xml:"trkseg"`
+}
+
+type xmlSegment struct {
+	Points []xmlPoint `xml:"trkpt"`
+}
+
+type xmlRoute struct {
+	Name   string     `xml:"name"`
+	Desc   string     `xml:"desc"`
+	Points []xmlPoint `xml:"rtept"`
+}
+
+type xmlPoint struct {
+	Lat        string   `xml:"lat,attr"`
+	Lon        string   `xml:"lon,attr"`
+	Ele        string   `xml:"ele"`
+	Time       string   `xml:"time"`
+	Name       string   `xml:"name"`
+	Desc       string   `xml:"desc"`
+	Type       string   `xml:"type"`
+	Extensions *xmlNode `xml:"extensions"`
+}
+
+type xmlNode struct {
+	XMLName xml.Name
+	Content string    `xml:",chardata"`
+	Nodes   []xmlNode `xml:",any"`
+}
+
+// Parse GPX 문서 해석
+func Parse(r io.Reader) (*GPX, error) {
+	var doc xmlGPX
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		// 대부분의 기기는 UTF-8을 사용하며, 선언만 다른 경우 그대로 읽음
+		return input, nil
+	}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid gpx: %w", err)
+	}
+
+	g := &GPX{
+		Version:     doc.Version,
+		Creator:     doc.Creator,
+		Name:        strings.TrimSpace(doc.Name),
+		Description: strings.TrimSpace(doc.Desc),
+	}
+	timeText := doc.Time
+	keywords := doc.Keywords
+	if doc.Metadata != nil {
+		if g.Name == "" {
+			g.Name = strings.TrimSpace(doc.Metadata.Name)
+		}
+		if g.Description == "" {
+			g.Description = strings.TrimSpace(doc.Metadata.Desc)
+		}
+		if timeText == "" {
+			timeText = doc.Metadata.Time
+		}
+		if keywords == "" {
+			keywords = doc.Metadata.Keywords
+		}
+	}
+	for _, k := range strings.Split(keywords, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			g.Keywords = append(g.Keywords, k)
+		}
+	}
+	if t, err := parseTime(timeText); err == nil {
+		g.Time = t
+	}
+
+	var err error
+	if g.Waypoints, err = convertPoints(doc.Waypoints); err != nil {
+		return nil, err
+	}
+
+	for _, rte := range doc.Routes {
+		points, err := convertPoints(rte.Points)
+		if err != nil {
+			return nil, err
+		}
+		g.Routes = append(g.Routes, Route{
+			Name:        strings.TrimSpace(rte.Name),
+			Description: strings.TrimSpace(rte.Desc),
+			Points:      points,
+		})
+	}
+
+	for _, trk := range doc.Tracks {
+		track := Track{
+			Name:        strings.TrimSpace(trk.Name),
+			Description: strings.TrimSpace(trk.Desc),
+			Type:        strings.TrimSpace(trk.Type),
+		}
+		for _, seg := range trk.Segments {
+			points, err := convertPoints(seg.Points)
+			if err != nil {
+				return nil, err
+			}
+			track.Segments = append(track.Segments, Segment{Points: points})
+		}
+		g.Tracks = append(g.Tracks, track)
+	}
+
+	return g, nil
+}
+
+// ParseString 문자열로 된 GPX 문서 해석
+func ParseString(data string) (*GPX, error) {
+	return Parse(strings.NewReader(data))
+}
+
+// Points 경로를 구성하는 지점 목록
+// 트랙 지점이 있으면 모든 트랙/세그먼트를 이어붙이고, 없으면 루트 지점을 사용
+func (g *GPX) Points() []Point {
+	var points []Point
+	for _, trk := range g.Tracks {
+		for _, seg := range trk.Segments {
+			points = append(points, seg.Points...)
+		}
+	}
+	if len(points) > 0 {
+		return points
+	}
+	for _, rte := range g.Routes {
+		points = append(points, rte.Points...)
+	}
+	return points
+}
+
+// GeoPoints 경로 지점을 models.GeoPoint로 변환
+func (g *GPX) GeoPoints() []models.GeoPoint {
+	points := g.Points()
+	result := make([]models.GeoPoint, len(points))
+	for i, p := range points {
+		result[i] = p.GeoPoint()
+	}
+	return result
+}
+
+// DisplayName 문서/트랙/루트 중 처음으로 발견되는 이름
+func (g *GPX) DisplayName() string {
+	if g.Name != "" {
+		return g.Name
+	}
+	for _, trk := range g.Tracks {
+		if trk.Name != "" {
+			return trk.Name
+		}
+	}
+	for _, rte := range g.Routes {
+		if rte.Name != "" {
+			return rte.Name
+		}
+	}
+	return ""
+}
+
+// GeoPoint 지점을 models.GeoPoint로 변환
+func (p Point) GeoPoint() models.GeoPoint {
+	return models.GeoPoint{
+		Latitude:  p.Latitude,
+		Longitude: p.Longitude,
+		Elevation: p.Elevation,
+		Timestamp: p.Time,
+	}
+}
+
+func convertPoints(src []xmlPoint) ([]Point, error) {
+	points := make([]Point, 0, len(src))
+	for _, xp := range src {
+		p, err := convertPoint(xp)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, p)
+	}
+	return points, nil
+}
+
+func convertPoint(xp xmlPoint) (Point, error) {
+	lat, err := strconv.ParseFloat(strings.TrimSpace(xp.Lat), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return Point{}, fmt.Errorf("invalid latitude: %q", xp.Lat)
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(xp.Lon), 64)
+	if err != nil || lon < -180 || lon > 180 {
+		return Point{}, fmt.Errorf("invalid longitude: %q", xp.Lon)
+	}
+
+	p := Point{
+		Latitude:    lat,
+		Longitude:   lon,
+		Elevation:   parseFloat(xp.Ele),
+		Name:        strings.TrimSpace(xp.Name),
+		Description: strings.TrimSpace(xp.Desc),
+		Type:        strings.TrimSpace(xp.Type),
+	}
+	if xp.Time != "" {
+		t, err := parseTime(xp.Time)
+		if err != nil {
+			return Point{}, err
+		}
+		p.Time = t
+	}
+	if xp.Extensions != nil {
+		readExtensions(&p, *xp.Extensions)
+	}
+	return p, nil
+}
+
+// 확장 요소를 재귀적으로 탐색하여 센서 값을 읽음
+// gpxtpx:hr, gpxtpx:cad, gpxtpx:atemp, gpxtpx:speed, pwr:PowerInWatts, power 등을 지원
+func readExtensions(p *Point, node xmlNode) {
+	for _, child := range node.Nodes {
+		value := strings.TrimSpace(child.Content)
+		switch strings.ToLower(child.XMLName.Local) {
+		case "hr", "heartrate":
+			p.HeartRate = parseInt(value)
+		case "cad", "cadence":
+			p.Cadence = parseInt(value)
+		case "power", "powerinwatts", "watts":
+			p.Power = parseInt(value)
+		case "speed":
+			p.Speed = parseFloat(value)
+		case "atemp", "temp", "temperature":
+			p.Temperature = parseFloat(value)
+		}
+		readExtensions(p, child)
+	}
+}
+
+// GPX 시각 해석 (RFC3339, 시간대가 없으면 UTC로 간주)
+func parseTime(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, fmt.Errorf("empty time")
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			t = t.UTC()
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid time: %q", value)
+}
+
+func parseFloat(value string) *float64 {
+	f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return nil
+	}
+	return &f
+}
+
+func parseInt(value string) *int {
+	f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return nil
+	}
+	i := int(f + 0.5)
+	return &i
+}
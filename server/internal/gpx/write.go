@@ -0,0 +1,166 @@
+package gpx
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	namespaceGPX = "http://www.topografix.com/GPX/1/1"
+	namespaceTPE = "http://www.garmin.com/xmlschemas/TrackPointExtension/v1"
+	namespacePWR = "http://www.garmin.com/xmlschemas/PowerExtension/v1"
+	creatorName  = "gobike"
+)
+
+// 출력용 XML 구조체 (GPX 1.1)
+type outGPX struct {
+	XMLName   xml.Name     `xml:"gpx"`
+	Xmlns     string       `xml:"xmlns,attr"`
+	XmlnsTPE  string       `xml:"xmlns:gpxtpx,attr"`
+	XmlnsPWR  string       `xml:"xmlns:pwr,attr"`
+	Version   string       `xml:"version,attr"`
+	Creator   string       `xml:"creator,attr"`
+	Metadata  *outMetadata `xml:"metadata,omitempty"`
+	Waypoints []outPoint   `xml:"wpt"`
+	Routes    []outRoute   `xml:"rte"`
+	Tracks    []outTrack   `xml:"trk"`
+}
+
+type outMetadata struct {
+	Name     string `xml:"name,omitempty"`
+	Desc     string `xml:"desc,omitempty"`
+	Time     string `xml:"time,omitempty"`
+	Keywords string `xml:"keywords,omitempty"`
+}
+
+type outRoute struct {
+	Name   string     `xml:"name,omitempty"`
+	Desc   string     `xml:"desc,omitempty"`
+	Points []outPoint `xml:"rtept"`
+}
+
+type outTrack struct {
+	Name     string       `xml:"name,omitempty"`
+	Desc     string       `xml:"desc,omitempty"`
+	Type     string       `xml:"type,omitempty"`
+	Segments []outSegment `xml:"trkseg"`
+}
+
+type outSegment struct {
+	Points []outPoint `xml:"trkpt"`
+}
+
+type outPoint struct {
+	Lat        string         `xml:"lat,attr"`
+	Lon        string         `xml:"lon,attr"`
+	Ele        string         `xml:"ele,omitempty"`
+	Time       string         `xml:"time,omitempty"`
+	Name       string         `xml:"name,omitempty"`
+	Desc       string         `xml:"desc,omitempty"`
+	Type       string         `xml:"type,omitempty"`
+	Extensions *outExtensions `xml:"extensions,omitempty"`
+}
+
+type outExtensions struct {
+	Power *int    `xml:"pwr:PowerInWatts,omitempty"`
+	TPE   *outTPE `xml:"gpxtpx:TrackPointExtension,omitempty"`
+}
+
+type outTPE struct {
+	Temperature string `xml:"gpxtpx:atemp,omitempty"`
+	HeartRate   *int   `xml:"gpxtpx:hr,omitempty"`
+	Cadence     *int   `xml:"gpxtpx:cad,omitempty"`
+}
+
+// Write GPX 1.1 문서로 출력
+// 센서 값은 Garmin TrackPointExtension/PowerExtension으로 출력
+func Write(w io.Writer, g *GPX) error {
+	doc := outGPX{
+		Xmlns:    namespaceGPX,
+		XmlnsTPE: namespaceTPE,
+		XmlnsPWR: namespacePWR,
+		Version:  "1.1",
+		Creator:  g.Creator,
+	}
+	if doc.Creator == "" {
+		doc.Creator = creatorName
+	}
+
+	if g.Name != "" || g.Description != "" || g.Time != nil || len(g.Keywords) > 0 {
+		doc.Metadata = &outMetadata{
+			Name:     g.Name,
+			Desc:     g.Description,
+			Time:     formatTime(g.Time),
+			Keywords: strings.Join(g.Keywords, ","),
+		}
+	}
+
+	for _, p := range g.Waypoints {
+		doc.Waypoints = append(doc.Waypoints, toOutPoint(p))
+	}
+	for _, rte := range g.Routes {
+		out := outRoute{Name: rte.Name, Desc: rte.Description}
+		for _, p := range rte.Points {
+			out.Points = append(out.Points, toOutPoint(p))
+		}
+		doc.Routes = append(doc.Routes, out)
+	}
+	for _, trk := range g.Tracks {
+		out := outTrack{Name: trk.Name, Desc: trk.Description, Type: trk.Type}
+		for _, seg := range trk.Segments {
+			var outSeg outSegment
+			for _, p := range seg.Points {
+				outSeg.Points = append(outSeg.Points, toOutPoint(p))
+			}
+			out.Segments = append(out.Segments, outSeg)
+		}
+		doc.Tracks = append(doc.Tracks, out)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func toOutPoint(p Point) outPoint {
+	out := outPoint{
+		Lat:  strconv.FormatFloat(p.Latitude, 'f', -1, 64),
+		Lon:  strconv.FormatFloat(p.Longitude, 'f', -1, 64),
+		Time: formatTime(p.Time),
+		Name: p.Name,
+		Desc: p.Description,
+		Type: p.Type,
+	}
+	if p.Elevation != nil {
+		out.Ele = strconv.FormatFloat(*p.Elevation, 'f', -1, 64)
+	}
+
+	if p.HeartRate != nil || p.Cadence != nil || p.Temperature != nil || p.Power != nil {
+		ext := &outExtensions{Power: p.Power}
+		if p.HeartRate != nil || p.Cadence != nil || p.Temperature != nil {
+			ext.TPE = &outTPE{HeartRate: p.HeartRate, Cadence: p.Cadence}
+			if p.Temperature != nil {
+				ext.TPE.Temperature = strconv.FormatFloat(*p.Temperature, 'f', -1, 64)
+			}
+		}
+		out.Extensions = ext
+	}
+	return out
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
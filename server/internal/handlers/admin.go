@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/database"
+	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/logger"
+	"github.com/chrisS41/gobike-server/internal/middleware"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+type AdminHandler struct {
+	users  *database.Collection
+	tokens *database.Collection
+	log    *logger.Log
+}
+
+func NewAdminHandler(users, tokens *database.Collection, log *logger.Log) *AdminHandler {
+	return &AdminHandler{users: users, tokens: tokens, log: log}
+}
+
+type updateStatusRequest struct {
+	Status string `json:"status" binding:"required"`
+	Reason string `json:"reason"`
+}
+
+type updateRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// 사용자 목록 응답 항목 (models.User에서 비밀번호 해시 제외)
+type adminUser struct {
+	ID                    primitive.ObjectID   `bson:"_id" json:"_id"`
+	Email                 string               `bson:"email" json:"email"`
+	Name                  string               `bson:"name" json:"name"`
+	Phone                 string               `bson:"phone" json:"phone"`
+	ProfileImage          string               `bson:"profile_image" json:"profile_image"`
+	Friends               []string             `bson:"friends" json:"friends"`
+	Subscription          *models.Subscription `bson:"subscription" json:"subscription"`
+	CreatedAt             time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt             time.Time            `bson:"updated_at" json:"updated_at"`
+	LastLoginAt           time.Time            `bson:"last_login_at" json:"last_login_at"`
+	Status                string               `bson:"status" json:"status"`
+	Role                  string               `bson:"role" json:"role"`
+	PasswordResetRequired bool                 `bson:"password_reset_required" json:"password_reset_required"`
+}
+
+// 사용자 목록 조회
+// 쿼리: role, status, q(이름/이메일 부분 일치), page, limit
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	filter := bson.M{}
+	if role := c.Query("role"); role != "" {
+		if !models.IsValidRole(role) {
+			c.JSON(
+				http.StatusBadRequest,
+				models.NewErrorResponse(errors.ErrInvalidRole),
+			)
+			return
+		}
+		filter["role"] = role
+	}
+	if status := c.Query("status"); status != "" {
+		if !models.IsValidUserStatus(status) {
+			c.JSON(
+				http.StatusBadRequest,
+				models.NewErrorResponse(errors.ErrInvalidUserStatus),
+			)
+			return
+		}
+		filter["status"] = status
+	}
+	if q := c.Query("q"); q != "" {
+		pattern := primitive.Regex{Pattern: regexp.QuoteMeta(q), Options: "i"}
+		filter["$or"] = bson.A{
+			bson.M{"name": pattern},
+			bson.M{"email": pattern},
+		}
+	}
+
+	page, limit := parsePage(c)
+
+	total, err := h.users.Count(filter)
+	if err != nil {
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToFetchUsers),
+		)
+		return
+	}
+
+	docs, err := h.users.ReadManyPaged(
+		filter,
+		bson.D{{Key: "created_at", Value: -1}},
+		(page-1)*limit,
+		limit,
+	)
+	if err != nil {
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToFetchUsers),
+		)
+		return
+	}
+
+	users := make([]adminUser, 0, len(docs))
+	for _, doc := range docs {
+		var user adminUser
+		bsonBytes, _ := bson.Marshal(doc)
+		bson.Unmarshal(bsonBytes, &user)
+		users = append(users, user)
+	}
+
+	c.JSON(
+		http.StatusOK,
+		models.NewSuccessResponse(gin.H{
+			"users": users,
+			"total": total,
+			"page":  page,
+			"limit": limit,
+		}),
+	)
+}
+
+// 계정 정지/재활성화
+// 정지 시 해당 사용자의 모든 세션을 폐기
+func (h *AdminHandler) UpdateUserStatus(c *gin.Context) {
+	var req updateStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrMissingParams, err.Error()),
+		)
+		return
+	}
+	if !models.IsValidUserStatus(req.Status) {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponse(errors.ErrInvalidUserStatus),
+		)
+		return
+	}
+
+	target, ok := h.loadManagedUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.users.Update(
+		bson.M{"_id": target.ID},
+		bson.M{"$set": bson.M{"status": req.Status, "updated_at": time.Now()}},
+	); err != nil {
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToUpdateUser),
+		)
+		return
+	}
+
+	if req.Status == models.UserStatusSuspended {
+		if _, err := revokeTokens(h.tokens, bson.M{"user_id": target.ID}, revokeReasonSuspended); err != nil {
+			h.log.Error("Failed to revoke tokens of suspended user %s: %v", target.ID.Hex(), err)
+		}
+	}
+
+	h.log.Info("User %s status changed to %s by %s (reason: %s)",
+		target.ID.Hex(), req.Status, middleware.UserID(c).Hex(), req.Reason)
+	c.JSON(
+		http.StatusOK,
+		models.NewSuccessResponse(gin.H{"id": target.ID, "status": req.Status}),
+	)
+}
+
+// 역할 변경 (admin 전용)
+func (h *AdminHandler) UpdateUserRole(c *gin.Context) {
+	var req updateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrMissingParams, err.Error()),
+		)
+		return
+	}
+	if !models.IsValidRole(req.Role) {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponse(errors.ErrInvalidRole),
+		)
+		return
+	}
+
+	target, ok := h.loadManagedUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.users.Update(
+		bson.M{"_id": target.ID},
+		bson.M{"$set": bson.M{"role": req.Role, "updated_at": time.Now()}},
+	); err != nil {
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToUpdateUser),
+		)
+		return
+	}
+
+	// Auth 미들웨어가 요청마다 DB에서 역할을 읽으므로 다음 요청부터 바로 적용됨
+	h.log.Info("User %s role changed from %s to %s by %s",
+		target.ID.Hex(), target.Role, req.Role, middleware.UserID(c).Hex())
+	c.JSON(
+		http.StatusOK,
+		models.NewSuccessResponse(gin.H{"id": target.ID, "role": req.Role}),
+	)
+}
+
+// 비밀번호 재설정 강제
+// 모든 세션을 폐기하고 다음 로그인 후 비밀번호 변경 전까지 API 사용을 제한
+func (h *AdminHandler) ForcePasswordReset(c *gin.Context) {
+	target, ok := h.loadManagedUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.users.Update(
+		bson.M{"_id": target.ID},
+		bson.M{"$set": bson.M{"password_reset_required": true, "updated_at": time.Now()}},
+	); err != nil {
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToUpdateUser),
+		)
+		return
+	}
+
+	if _, err := revokeTokens(h.tokens, bson.M{"user_id": target.ID}, revokeReasonPassword); err != nil {
+		h.log.Error("Failed to revoke tokens of user %s: %v", target.ID.Hex(), err)
+	}
+
+	h.log.Info("Password reset forced for user %s by %s", target.ID.Hex(), middleware.UserID(c).Hex())
+	c.JSON(
+		http.StatusOK,
+		models.NewSuccessResponse(gin.H{"id": target.ID, "password_reset_required": true}),
+	)
+}
+
+// :id 경로 파라미터의 사용자를 조회하고 호출자가 관리할 수 있는 대상인지 확인
+// 자기 자신은 변경할 수 없고, admin이 아니면 rider/club_admin만 관리 가능
+func (h *AdminHandler) loadManagedUser(c *gin.Context) (*models.User, bool) {
+	targetID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrMissingParams, "잘못된 사용자 ID입니다"),
+		)
+		return nil, false
+	}
+
+	var target models.User
+	if err := h.users.ReadOne(bson.M{"_id": targetID}, &target); err != nil {
+		c.JSON(
+			http.StatusNotFound,
+			models.NewErrorResponse(errors.ErrUserNotFound),
+		)
+		return nil, false
+	}
+
+	if target.ID == middleware.UserID(c) {
+		c.JSON(
+			http.StatusForbidden,
+			models.NewErrorResponse(errors.ErrUnauthorized),
+		)
+		return nil, false
+	}
+
+	if middleware.UserRole(c) != models.RoleAdmin &&
+		(target.Role == models.RoleAdmin || target.Role == models.RoleModerator) {
+		c.JSON(
+			http.StatusForbidden,
+			models.NewErrorResponse(errors.ErrUnauthorized),
+		)
+		return nil, false
+	}
+
+	return &target, true
+}
+
+// page, limit 쿼리 파라미터 파싱 (page는 1부터 시작)
+func parsePage(c *gin.Context) (page, limit int64) {
+	page, err := strconv.ParseInt(c.Query("page"), 10, 64)
+	if err != nil || page < 1 {
+		page = 1
+	}
+	limit, err = strconv.ParseInt(c.Query("limit"), 10, 64)
+	if err != nil || limit < 1 {
+		limit = defaultPageSize
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+	return page, limit
+}
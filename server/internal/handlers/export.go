@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/export"
+	"github.com/chrisS41/gobike-server/internal/gpx"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// 주행 기록 내보내기
+// 쿼리: format=gpx|tcx|geojson|kml (기본값 gpx)
+func (h *RideHandler) ExportRide(c *gin.Context) {
+	format, ok := parseExportFormat(c)
+	if !ok {
+		return
+	}
+
+	ride, ok := h.loadOwnedRide(c)
+	if !ok {
+		return
+	}
+	if err := h.tracks.Load(ride, false); err != nil {
+		h.log.Error("Failed to load track of ride %s: %v", ride.ID.Hex(), err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToFetchRides),
+		)
+		return
+	}
+
+	writeExport(c, format, export.FromRide(ride))
+}
+
+// 경로 내보내기
+// 쿼리: format=gpx|tcx|geojson|kml (기본값 gpx),
+// cues=true (회전 안내를 GPX <rtept>, TCX CoursePoint로 함께 출력)
+func (h *RouteHandler) ExportRoute(c *gin.Context) {
+	format, ok := parseExportFormat(c)
+	if !ok {
+		return
+	}
+
+	route, ok := h.loadVisibleRoute(c)
+	if !ok {
+		return
+	}
+
+	track, err := export.FromRoute(route)
+	if err != nil {
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidGPX, err.Error()),
+		)
+		return
+	}
+
+	if c.Query("cues") == "true" && route.GPXData != "" {
+		// FromRoute에서 이미 해석했으므로 오류가 나지 않음
+		if g, err := gpx.ParseString(route.GPXData); err == nil {
+			track.Cues = h.routeCues(g)
+		}
+	}
+
+	writeExport(c, format, track)
+}
+
+func parseExportFormat(c *gin.Context) (export.Format, bool) {
+	format, err := export.ParseFormat(c.DefaultQuery("format", string(export.FormatGPX)))
+	if err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidFormat, err.Error()),
+		)
+		return "", false
+	}
+	return format, true
+}
+
+// 변환 결과를 버퍼에 먼저 만든 뒤 첨부 파일로 응답
+// (변환 중 오류가 나도 JSON 에러 응답을 보낼 수 있도록)
+func writeExport(c *gin.Context, format export.Format, track *export.Track) {
+	var buf bytes.Buffer
+	if err := export.Write(&buf, format, track); err != nil {
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponseWithMessage(errors.ErrFailedToWrite, err.Error()),
+		)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, export.FileName(track.Name, format)))
+	c.Data(http.StatusOK, format.ContentType(), buf.Bytes())
+}
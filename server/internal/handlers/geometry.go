@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/gpx"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/chrisS41/gobike-server/internal/polyline"
+	"github.com/chrisS41/gobike-server/internal/track"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 응답에 포함할 트랙 형식 (geometry 쿼리)
+const (
+	geometryFull       = "full"       // 전체 트랙 (경로는 GPX, 라이드는 정제 전후 지점과 센서 스트림)
+	geometrySimplified = "simplified" // 단순화한 지점 목록
+	geometryPolyline   = "polyline"   // 단순화한 트랙의 인코딩 폴리라인
+	geometryNone       = "none"       // 트랙 제외
+)
+
+type geometryOption struct {
+	mode      string
+	precision int // polyline 정밀도
+}
+
+// geometry, precision 쿼리 파싱 (geometry가 없으면 fallback, precision이 없으면 5)
+// 잘못된 값이면 400 응답 후 false
+func parseGeometry(c *gin.Context, fallback string) (geometryOption, bool) {
+	o := geometryOption{mode: c.DefaultQuery("geometry", fallback), precision: polyline.Precision5}
+	switch o.mode {
+	case geometryFull, geometrySimplified, geometryPolyline, geometryNone:
+	default:
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(
+				errors.ErrInvalidFormat,
+				fmt.Sprintf("geometry는 full, simplified, polyline, none 중 하나여야 합니다: %s", o.mode),
+			),
+		)
+		return o, false
+	}
+
+	if v := c.Query("precision"); v != "" {
+		precision, err := strconv.Atoi(v)
+		if err != nil || !polyline.ValidPrecision(precision) {
+			c.JSON(
+				http.StatusBadRequest,
+				models.NewErrorResponseWithMessage(errors.ErrInvalidFormat, "precision은 5 또는 6이어야 합니다"),
+			)
+			return o, false
+		}
+		o.precision = precision
+	}
+	return o, true
+}
+
+// 라이드 목록 조회 시 읽지 않을 필드
+func (o geometryOption) rideProjection() *options.FindOptions {
+	switch o.mode {
+	case geometrySimplified, geometryPolyline:
+		return options.Find().SetProjection(bson.M{"locations": 0, "raw_locations": 0, "streams": 0})
+	case geometryNone:
+		return options.Find().SetProjection(bson.M{"locations": 0, "raw_locations": 0, "streams": 0, "simplified": 0})
+	}
+	return options.Find()
+}
+
+// 경로 조회 시 읽지 않을 필드 (nil이면 모두 읽음)
+func (o geometryOption) routeFields() bson.M {
+	switch o.mode {
+	case geometrySimplified, geometryPolyline:
+		return bson.M{"gpx_data": 0}
+	case geometryNone:
+		return bson.M{"gpx_data": 0, "geometry": 0}
+	}
+	return nil
+}
+
+func (o geometryOption) routeProjection() *options.FindOptions {
+	if fields := o.routeFields(); fields != nil {
+		return options.Find().SetProjection(fields)
+	}
+	return options.Find()
+}
+
+// 라이드 응답의 트랙 필드를 형식에 맞게 바꿈
+// simplified, polyline은 ride.Simplified를 사용하므로 미리 채워 두어야 함
+func (o geometryOption) applyToRide(ride *models.Ride) {
+	if o.mode == geometryFull {
+		return
+	}
+	ride.Locations = nil
+	ride.RawLocations = nil
+	ride.Streams = nil
+	switch o.mode {
+	case geometryPolyline:
+		ride.Polyline = polyline.Encode(ride.Simplified, o.precision)
+		ride.Simplified = nil
+	case geometryNone:
+		ride.Simplified = nil
+	}
+}
+
+// 경로 응답의 GPX를 형식에 맞는 경로 선으로 바꿈
+func (o geometryOption) applyToRoute(route *models.Route) {
+	if o.mode == geometryFull {
+		return
+	}
+	switch o.mode {
+	case geometrySimplified:
+		route.Simplified = routeLine(route)
+	case geometryPolyline:
+		route.Polyline = polyline.Encode(routeLine(route), o.precision)
+	}
+	route.GPXData = ""
+}
+
+// 경로의 단순화한 선
+// 저장된 공간 인덱스용 선을 사용하고, 없으면 GPX 트랙에서 계산
+func routeLine(route *models.Route) []models.GeoPoint {
+	var points []models.GeoPoint
+	if route.Geometry != nil {
+		for _, c := range route.Geometry.Coordinates {
+			points = append(points, models.GeoPoint{Latitude: c[1], Longitude: c[0]})
+		}
+	} else if route.GPXData != "" {
+		if g, err := gpx.ParseString(route.GPXData); err == nil {
+			points = g.GeoPoints()
+		}
+	}
+	return simplifyTrack(nil, points)
+}
+
+// 표시용 단순화 트랙 (정제 설정의 방식을 따르며, 정제를 하지 않거나 방식이 none이면 기본 허용 오차의 Douglas–Peucker)
+func simplifyTrack(cleaner *track.Cleaner, points []models.GeoPoint) []models.GeoPoint {
+	if len(points) == 0 {
+		return nil
+	}
+	if simplified := cleaner.Simplify(points); simplified != nil {
+		return simplified
+	}
+	return track.Pick(points, track.DouglasPeucker(points, track.DefaultOptions().SimplifyTolerance))
+}
@@ -2,16 +2,25 @@ package handlers
 
 import (
 	"fmt"
+	"net/http"
 	"reflect"
 	"strings"
 
+	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/middleware"
+	"github.com/chrisS41/gobike-server/internal/models"
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type Handlers struct {
-	Users  *UserHandler
-	Routes *RouteHandler
-	Rides  *RideHandler
+	Users    *UserHandler
+	Routes   *RouteHandler
+	Rides    *RideHandler
+	Admin    *AdminHandler
+	Live     *LiveHandler
+	Records  *RecordHandler
+	Segments *SegmentHandler
 }
 
 // 파라미터 파싱 헬퍼 함수
@@ -60,3 +69,29 @@ func parseParams[T any](c *gin.Context, params ...string) (*T, error) {
 
 	return &data, nil
 }
+
+// 경로 파라미터의 사용자 ID가 인증된 사용자 본인인지 확인
+// 본인이 아니면 에러 응답을 보내고 false 반환
+func requireSelf(c *gin.Context, param string) (primitive.ObjectID, bool) {
+	userID, err := primitive.ObjectIDFromHex(c.Param(param))
+	if err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(
+				errors.ErrMissingParams,
+				fmt.Sprintf("잘못된 사용자 ID입니다: %s", c.Param(param)),
+			),
+		)
+		return primitive.NilObjectID, false
+	}
+
+	if userID != middleware.UserID(c) {
+		c.JSON(
+			http.StatusForbidden,
+			models.NewErrorResponse(errors.ErrUnauthorized),
+		)
+		return primitive.NilObjectID, false
+	}
+
+	return userID, true
+}
@@ -0,0 +1,629 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/database"
+	"github.com/chrisS41/gobike-server/internal/elevation"
+	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/live"
+	"github.com/chrisS41/gobike-server/internal/logger"
+	"github.com/chrisS41/gobike-server/internal/middleware"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/chrisS41/gobike-server/internal/track"
+	"github.com/chrisS41/gobike-server/internal/trackstore"
+	"github.com/chrisS41/gobike-server/internal/websocket"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 위치 묶음 하나에 포함할 수 있는 최대 지점 수 (센서 측정값 수도 같음)
+const maxLiveBatchPoints = 1000
+
+// 라이드 생성 시 지점과 이 시간 이상 떨어진 센서 측정값은 그 지점에 사용하지 않음
+const liveSensorMaxGap = 5 * time.Second
+
+type LiveHandler struct {
+	sessions *database.Collection
+	chunks   *database.Collection // 세션 위치 묶음
+	rides    *database.Collection
+	routes   *database.Collection
+	users    *database.Collection
+	records  *database.Collection
+	segments *database.Collection
+	efforts  *database.Collection
+	tracks   *trackstore.Store
+	dem      *elevation.Service
+	cleaner  *track.Cleaner
+	hub      *live.Hub
+	log      *logger.Log
+
+	// 서버 종료 시 닫기 위해 추적하는 WebSocket 연결
+	// (hijack된 연결은 http.Server.Shutdown이 관리하지 않음)
+	connsMu sync.Mutex
+	conns   map[*websocket.Conn]struct{}
+}
+
+func NewLiveHandler(sessions, chunks, rides, routes, users, records, segments, efforts *database.Collection, tracks *trackstore.Store, dem *elevation.Service, cleaner *track.Cleaner, log *logger.Log) *LiveHandler {
+	return &LiveHandler{
+		sessions: sessions,
+		chunks:   chunks,
+		rides:    rides,
+		routes:   routes,
+		users:    users,
+		records:  records,
+		segments: segments,
+		efforts:  efforts,
+		tracks:   tracks,
+		dem:      dem,
+		cleaner:  cleaner,
+		hub:      live.NewHub(),
+		log:      log,
+		conns:    make(map[*websocket.Conn]struct{}),
+	}
+}
+
+// points 이벤트 데이터
+type livePointsEvent struct {
+	Seq    int64             `json:"seq"`
+	From   int64             `json:"from"` // 첫 지점의 세션 내 인덱스
+	Points []models.GeoPoint `json:"points"`
+}
+
+// 위치와 센서 측정값 목록을 제외한 세션 조회용 프로젝션
+var liveSummaryProjection = bson.M{"locations": 0, "sensors": 0}
+
+// 실시간 세션 시작
+// JSON 본문(선택): route_id (따라가는 경로), visibility (private 기본, friends),
+// thresholds (ftp, max_heart_rate: 센서 구간과 강도 계산 기준)
+func (h *LiveHandler) StartSession(c *gin.Context) {
+	var req struct {
+		RouteID    string             `json:"route_id"`
+		Visibility string             `json:"visibility"`
+		Thresholds *models.Thresholds `json:"thresholds"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil && !stderrors.Is(err, io.EOF) {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrMissingParams, err.Error()),
+		)
+		return
+	}
+
+	session := models.LiveSession{
+		UserID:     middleware.UserID(c),
+		Status:     models.LiveStatusActive,
+		Visibility: req.Visibility,
+		Thresholds: req.Thresholds,
+		StartedAt:  time.Now(),
+	}
+	session.UpdatedAt = session.StartedAt
+	if session.Visibility == "" {
+		session.Visibility = models.LiveVisibilityPrivate
+	}
+	if !models.IsValidLiveVisibility(session.Visibility) {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrMissingParams, "잘못된 공개 범위입니다"),
+		)
+		return
+	}
+
+	if t := req.Thresholds; t != nil && (t.FTP < 0 || t.MaxHeartRate < 0) {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrMissingParams, "잘못된 기준값입니다"),
+		)
+		return
+	}
+
+	if req.RouteID != "" {
+		routeID, err := primitive.ObjectIDFromHex(req.RouteID)
+		if err != nil {
+			c.JSON(
+				http.StatusBadRequest,
+				models.NewErrorResponseWithMessage(errors.ErrMissingParams, "잘못된 경로 ID입니다"),
+			)
+			return
+		}
+		if !canLinkRoute(h.routes, h.users, routeID, session.UserID) {
+			c.JSON(
+				http.StatusBadRequest,
+				models.NewErrorResponse(errors.ErrRideRouteNotFound),
+			)
+			return
+		}
+		session.RouteID = routeID
+	}
+
+	var err error
+	session.ID, err = h.sessions.Create(session)
+	if err != nil {
+		// 사용자당 진행 중인 세션은 하나만 허용 (유니크 부분 인덱스)
+		if mongo.IsDuplicateKeyError(err) {
+			c.JSON(
+				http.StatusConflict,
+				models.NewErrorResponse(errors.ErrLiveSessionActive),
+			)
+			return
+		}
+		h.log.Error("Failed to create live session: %v", err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToUpdateLiveSession),
+		)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.NewSuccessResponse(session))
+}
+
+// 진행 중인 본인 세션 조회 (앱 재시작 후 이어서 전송할 때 사용, 위치 목록 제외)
+func (h *LiveHandler) GetActiveSession(c *gin.Context) {
+	var session models.LiveSession
+	err := h.sessions.ReadOne(
+		bson.M{"user_id": middleware.UserID(c), "status": models.LiveStatusActive},
+		&session,
+		options.FindOne().SetProjection(liveSummaryProjection),
+	)
+	if err != nil {
+		c.JSON(
+			http.StatusNotFound,
+			models.NewErrorResponse(errors.ErrLiveSessionNotFound),
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(session))
+}
+
+// 세션 조회 (본인 또는 구독이 허용된 친구)
+func (h *LiveHandler) GetSession(c *gin.Context) {
+	session, ok := h.loadSession(c, true)
+	if !ok || !h.requireWatcher(c, session) {
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(session))
+}
+
+// HTTP로 위치 묶음 전송 (WebSocket을 사용할 수 없을 때의 대체 경로)
+// 이미 저장된 순번은 중복으로 확인 응답하고, 순번이 건너뛰면 409와 함께 마지막 순번을 반환
+func (h *LiveHandler) UploadBatch(c *gin.Context) {
+	sessionID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrMissingParams, "잘못된 세션 ID입니다"),
+		)
+		return
+	}
+
+	var batch models.LiveBatch
+	if err := c.ShouldBindJSON(&batch); err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidLiveBatch, err.Error()),
+		)
+		return
+	}
+
+	ack, status, code := h.appendBatch(sessionID, middleware.UserID(c), &batch)
+	if code != 0 {
+		c.JSON(status, &models.Response{
+			Code:    code,
+			Data:    ack,
+			Message: errors.GetErrorMessage(code),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(ack))
+}
+
+// 세션을 종료하고 저장된 위치로 라이드 생성
+func (h *LiveHandler) FinishSession(c *gin.Context) {
+	session, ok := h.loadSession(c, true)
+	if !ok || !h.requireOwner(c, session) {
+		return
+	}
+	if session.Status != models.LiveStatusActive {
+		c.JSON(
+			http.StatusConflict,
+			models.NewErrorResponse(errors.ErrLiveSessionClosed),
+		)
+		return
+	}
+
+	// 주행 중 경로가 삭제되거나 비공개로 바뀌었으면 경로 연결만 해제하고 라이드는 저장
+	ride := sessionToRide(session)
+	if !ride.RouteID.IsZero() && !canLinkRoute(h.routes, h.users, ride.RouteID, session.UserID) {
+		h.log.Info("Route %s of live session %s is no longer available, saving ride without route", ride.RouteID.Hex(), session.ID.Hex())
+		ride.RouteID = primitive.NilObjectID
+	}
+	if code := validateRide(h.routes, h.users, ride, session.UserID); code != 0 {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponse(code),
+		)
+		return
+	}
+	prepareRideTrack(h.cleaner, h.dem, h.routes, h.log, ride)
+
+	// 읽은 이후 새 묶음이 추가되지 않았을 때만 종료 상태로 전환
+	now := time.Now()
+	var before models.LiveSession
+	err := h.sessions.FindOneAndUpdate(
+		bson.M{"_id": session.ID, "status": models.LiveStatusActive, "last_seq": session.LastSeq},
+		bson.M{"$set": bson.M{"status": models.LiveStatusFinished, "ended_at": now, "updated_at": now}},
+		&before,
+		options.FindOneAndUpdate().SetProjection(liveSummaryProjection),
+	)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(
+				http.StatusConflict,
+				models.NewErrorResponseWithMessage(
+					errors.ErrLiveSessionClosed,
+					"세션이 이미 종료되었거나 종료 중 위치가 추가되었습니다. 세션을 다시 조회해 주세요",
+				),
+			)
+			return
+		}
+		h.log.Error("Failed to finish live session %s: %v", session.ID.Hex(), err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToUpdateLiveSession),
+		)
+		return
+	}
+
+	ride.UserID = session.UserID
+	ride.CreatedAt = now
+	ride.UpdatedAt = now
+	if err := insertRide(h.rides, h.tracks, h.log, ride); err != nil {
+		h.log.Error("Failed to create ride from live session %s: %v", session.ID.Hex(), err)
+		// 다시 종료할 수 있도록 진행 중 상태로 되돌림
+		if err := h.sessions.Update(
+			bson.M{"_id": session.ID},
+			bson.M{"$set": bson.M{"status": models.LiveStatusActive}, "$unset": bson.M{"ended_at": ""}},
+		); err != nil {
+			h.log.Error("Failed to reopen live session %s: %v", session.ID.Hex(), err)
+		}
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToCreateRide),
+		)
+		return
+	}
+
+	if err := h.sessions.Update(
+		bson.M{"_id": session.ID},
+		bson.M{"$set": bson.M{"ride_id": ride.ID}},
+	); err != nil {
+		h.log.Error("Failed to link ride %s to live session %s: %v", ride.ID.Hex(), session.ID.Hex(), err)
+	}
+
+	countRouteRide(h.routes, h.log, ride.RouteID, 1)
+	ride.NewRecords = refreshRecords(h.records, h.rides, h.log, ride.UserID, ride.ID, effortKeys(ride.BestEfforts))
+	ride.SegmentEfforts = matchSegments(h.segments, h.efforts, h.log, ride)
+
+	h.hub.CloseSession(session.ID, live.Event{
+		ID:   session.PointCount,
+		Type: live.EventFinish,
+		Data: gin.H{"ride_id": ride.ID},
+	})
+
+	c.JSON(http.StatusCreated, models.NewSuccessResponse(ride))
+}
+
+// 세션을 라이드로 저장하지 않고 폐기 (저장된 위치도 삭제)
+func (h *LiveHandler) DiscardSession(c *gin.Context) {
+	session, ok := h.loadSession(c, false)
+	if !ok || !h.requireOwner(c, session) {
+		return
+	}
+
+	now := time.Now()
+	var before models.LiveSession
+	err := h.sessions.FindOneAndUpdate(
+		bson.M{"_id": session.ID, "status": models.LiveStatusActive},
+		bson.M{
+			"$set":   bson.M{"status": models.LiveStatusDiscarded, "ended_at": now, "updated_at": now},
+			"$unset": bson.M{"locations": "", "sensors": ""},
+		},
+		&before,
+		options.FindOneAndUpdate().SetProjection(liveSummaryProjection),
+	)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(
+				http.StatusConflict,
+				models.NewErrorResponse(errors.ErrLiveSessionClosed),
+			)
+			return
+		}
+		h.log.Error("Failed to discard live session %s: %v", session.ID.Hex(), err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToUpdateLiveSession),
+		)
+		return
+	}
+
+	if _, err := h.chunks.DeleteMany(bson.M{"session_id": session.ID}); err != nil {
+		h.log.Error("Failed to delete locations of live session %s: %v", session.ID.Hex(), err)
+	}
+	h.hub.CloseSession(session.ID, live.Event{ID: before.PointCount, Type: live.EventDiscard})
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(gin.H{"id": session.ID}))
+}
+
+// Shutdown 구독과 WebSocket 연결을 모두 종료 (서버 종료 시 호출)
+func (h *LiveHandler) Shutdown() {
+	h.hub.Shutdown()
+
+	h.connsMu.Lock()
+	defer h.connsMu.Unlock()
+	for conn := range h.conns {
+		conn.Close(websocket.CloseGoingAway, "server shutting down")
+	}
+}
+
+// 위치 묶음을 저장하고 구독자에게 전달
+// 실패하면 HTTP 상태와 에러 코드를 반환하며, 세션을 찾은 경우 ack에 현재 순번을 채움
+func (h *LiveHandler) appendBatch(sessionID, userID primitive.ObjectID, batch *models.LiveBatch) (models.LiveAck, int, int) {
+	ack := models.LiveAck{Seq: batch.Seq}
+	if code := validateLiveBatch(batch); code != 0 {
+		return ack, http.StatusBadRequest, code
+	}
+
+	var current models.LiveSession
+	err := h.sessions.ReadOne(
+		bson.M{"_id": sessionID, "user_id": userID},
+		&current,
+		options.FindOne().SetProjection(liveSummaryProjection),
+	)
+	if err != nil {
+		return ack, http.StatusNotFound, errors.ErrLiveSessionNotFound
+	}
+	if status, code := liveBatchConflict(&current, batch, &ack); status != 0 {
+		return ack, status, code
+	}
+
+	// 묶음을 먼저 저장한 뒤 순번을 올림
+	// 순번을 올리지 못한 묶음은 last_seq 이후라 읽히지 않으며, 같은 순번을 다시 받으면 덮어씀
+	chunkFilter := bson.M{"session_id": sessionID, "seq": batch.Seq}
+	chunk := models.LiveChunk{
+		SessionID: sessionID,
+		Seq:       batch.Seq,
+		From:      current.PointCount,
+		Points:    batch.Points,
+		Sensors:   batch.Sensors,
+	}
+	if err := h.chunks.Upsert(chunkFilter, chunk); err != nil {
+		h.log.Error("Failed to append live batch to session %s: %v", sessionID.Hex(), err)
+		return ack, http.StatusInternalServerError, errors.ErrFailedToUpdateLiveSession
+	}
+
+	// 직전 순번까지 저장된 진행 중 세션에만 반영 (순번 검사와 반영을 원자적으로 처리)
+	var before models.LiveSession
+	err = h.sessions.FindOneAndUpdate(
+		bson.M{
+			"_id":      sessionID,
+			"user_id":  userID,
+			"status":   models.LiveStatusActive,
+			"last_seq": batch.Seq - 1,
+		},
+		bson.M{
+			"$set": bson.M{"last_seq": batch.Seq, "updated_at": time.Now()},
+			"$inc": bson.M{"point_count": len(batch.Points)},
+		},
+		&before,
+		options.FindOneAndUpdate().SetProjection(liveSummaryProjection),
+	)
+	if err == nil {
+		ack.LastSeq = batch.Seq
+		ack.PointCount = before.PointCount + int64(len(batch.Points))
+		h.hub.Publish(sessionID, live.Event{
+			ID:   ack.PointCount,
+			Type: live.EventPoints,
+			Data: livePointsEvent{Seq: batch.Seq, From: before.PointCount, Points: batch.Points},
+		})
+		return ack, http.StatusOK, 0
+	}
+	if err != mongo.ErrNoDocuments {
+		h.log.Error("Failed to append live batch to session %s: %v", sessionID.Hex(), err)
+		return ack, http.StatusInternalServerError, errors.ErrFailedToUpdateLiveSession
+	}
+
+	// 그 사이 세션이 바뀐 경우 (같은 묶음을 동시에 받았거나 세션이 종료됨)
+	err = h.sessions.ReadOne(
+		bson.M{"_id": sessionID, "user_id": userID},
+		&current,
+		options.FindOne().SetProjection(liveSummaryProjection),
+	)
+	if err != nil {
+		return ack, http.StatusNotFound, errors.ErrLiveSessionNotFound
+	}
+	if current.Status != models.LiveStatusActive {
+		if _, err := h.chunks.DeleteMany(chunkFilter); err != nil {
+			h.log.Error("Failed to delete live batch of closed session %s: %v", sessionID.Hex(), err)
+		}
+	}
+	if status, code := liveBatchConflict(&current, batch, &ack); status != 0 {
+		return ack, status, code
+	}
+	return ack, http.StatusConflict, errors.ErrLiveSequenceGap
+}
+
+// 저장된 세션 상태로 묶음을 이어서 저장할 수 없는 원인 확인
+// 이어서 저장할 묶음이면 상태 0, 이미 저장된 묶음이면 200과 코드 0 (ack.Duplicate 설정)
+func liveBatchConflict(current *models.LiveSession, batch *models.LiveBatch, ack *models.LiveAck) (int, int) {
+	ack.LastSeq = current.LastSeq
+	ack.PointCount = current.PointCount
+
+	switch {
+	case current.Status != models.LiveStatusActive:
+		return http.StatusConflict, errors.ErrLiveSessionClosed
+	case batch.Seq <= current.LastSeq:
+		// 재연결 후 다시 보낸 묶음은 저장하지 않고 확인만 응답
+		ack.Duplicate = true
+		return http.StatusOK, 0
+	case batch.Seq > current.LastSeq+1:
+		return http.StatusConflict, errors.ErrLiveSequenceGap
+	}
+	return 0, 0
+}
+
+// 위치 묶음 검증, 문제가 없으면 0 반환
+func validateLiveBatch(batch *models.LiveBatch) int {
+	if batch.Seq < 1 || len(batch.Points) == 0 || len(batch.Points) > maxLiveBatchPoints {
+		return errors.ErrInvalidLiveBatch
+	}
+
+	var prev *time.Time
+	for _, p := range batch.Points {
+		if p.Latitude < -90 || p.Latitude > 90 || p.Longitude < -180 || p.Longitude > 180 {
+			return errors.ErrInvalidLiveBatch
+		}
+		// 실시간 지점은 시각이 필수이며 묶음 안에서 시간순이어야 함
+		if p.Timestamp == nil || (prev != nil && p.Timestamp.Before(*prev)) {
+			return errors.ErrInvalidLiveBatch
+		}
+		prev = p.Timestamp
+	}
+
+	// 센서 측정값도 시각이 필수이며 묶음 안에서 시간순이어야 함
+	if len(batch.Sensors) > maxLiveBatchPoints {
+		return errors.ErrInvalidLiveBatch
+	}
+	for i, sample := range batch.Sensors {
+		if sample.Time.IsZero() || (i > 0 && sample.Time.Before(batch.Sensors[i-1].Time)) || !validSensorSample(sample) {
+			return errors.ErrInvalidLiveBatch
+		}
+	}
+	return 0
+}
+
+// 세션의 위치로 라이드 생성 (트랙 정제와 통계 필드는 prepareRideTrack으로 별도 계산)
+// 센서 측정값은 지점마다 시각이 가장 가까운 값으로 스트림을 만듦
+func sessionToRide(session *models.LiveSession) *models.Ride {
+	ride := &models.Ride{
+		RouteID:    session.RouteID,
+		StartTime:  session.StartedAt,
+		EndTime:    session.UpdatedAt,
+		Locations:  session.Locations,
+		Streams:    models.AlignSamples(session.Locations, session.Sensors, liveSensorMaxGap),
+		Thresholds: session.Thresholds,
+	}
+	if n := len(session.Locations); n > 0 {
+		if ts := session.Locations[0].Timestamp; ts != nil {
+			ride.StartTime = *ts
+		}
+		if ts := session.Locations[n-1].Timestamp; ts != nil {
+			ride.EndTime = *ts
+		}
+	}
+	return ride
+}
+
+// :id 경로 파라미터의 세션 조회
+// withLocations가 false이면 위치 목록은 읽지 않음
+func (h *LiveHandler) loadSession(c *gin.Context, withLocations bool) (*models.LiveSession, bool) {
+	sessionID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrMissingParams, "잘못된 세션 ID입니다"),
+		)
+		return nil, false
+	}
+
+	opts := options.FindOne()
+	if !withLocations {
+		opts.SetProjection(liveSummaryProjection)
+	}
+
+	var session models.LiveSession
+	if err := h.sessions.ReadOne(bson.M{"_id": sessionID}, &session, opts); err != nil {
+		c.JSON(
+			http.StatusNotFound,
+			models.NewErrorResponse(errors.ErrLiveSessionNotFound),
+		)
+		return nil, false
+	}
+
+	if withLocations {
+		if err := h.loadChunks(&session); err != nil {
+			h.log.Error("Failed to load locations of live session %s: %v", session.ID.Hex(), err)
+			c.JSON(
+				http.StatusInternalServerError,
+				models.NewErrorResponse(errors.ErrDatabaseQuery),
+			)
+			return nil, false
+		}
+	}
+
+	return &session, true
+}
+
+// 세션에 저장된 위치 묶음을 순번 순서로 읽어 session.Locations, session.Sensors에 이어 붙임
+// LastSeq 이후의 묶음(순번을 올리지 못한 묶음)은 제외하며,
+// 묶음 저장 도입 전 세션은 문서에 들어 있던 위치 뒤에 붙임
+func (h *LiveHandler) loadChunks(session *models.LiveSession) error {
+	if session.Locations == nil {
+		session.Locations = []models.GeoPoint{}
+	}
+	return h.chunks.ReadEach(
+		bson.M{"session_id": session.ID, "seq": bson.M{"$lte": session.LastSeq}},
+		func(doc bson.Raw) error {
+			var chunk models.LiveChunk
+			if err := bson.Unmarshal(doc, &chunk); err != nil {
+				return err
+			}
+			session.Locations = append(session.Locations, chunk.Points...)
+			session.Sensors = append(session.Sensors, chunk.Sensors...)
+			return nil
+		},
+		options.Find().SetSort(bson.D{{Key: "seq", Value: 1}}),
+	)
+}
+
+// 세션 소유자인지 확인, 아니면 403 응답
+func (h *LiveHandler) requireOwner(c *gin.Context, session *models.LiveSession) bool {
+	if session.UserID != middleware.UserID(c) {
+		c.JSON(
+			http.StatusForbidden,
+			models.NewErrorResponse(errors.ErrUnauthorized),
+		)
+		return false
+	}
+	return true
+}
+
+// 세션을 볼 수 있는지 확인, 아니면 403 응답
+// 소유자 또는 공개 범위가 friends인 세션 소유자의 친구 목록에 있는 사용자
+func (h *LiveHandler) requireWatcher(c *gin.Context, session *models.LiveSession) bool {
+	viewerID := middleware.UserID(c)
+	if session.UserID == viewerID {
+		return true
+	}
+
+	if session.Visibility == models.LiveVisibilityFriends && isFriendOf(h.users, session.UserID, viewerID) {
+		return true
+	}
+
+	c.JSON(
+		http.StatusForbidden,
+		models.NewErrorResponse(errors.ErrUnauthorized),
+	)
+	return false
+}
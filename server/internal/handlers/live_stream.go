@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/live"
+	"github.com/chrisS41/gobike-server/internal/middleware"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/chrisS41/gobike-server/internal/websocket"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	// WebSocket: 이 시간 동안 아무 프레임도 받지 못하면 연결 종료
+	liveSocketReadTimeout = 60 * time.Second
+	// WebSocket: 연결 유지를 위한 ping 주기
+	liveSocketPingInterval = 25 * time.Second
+	// SSE: 프록시가 유휴 연결을 끊지 않도록 주석 행을 보내는 주기
+	liveStreamKeepAlive = 15 * time.Second
+)
+
+// WebSocket 메시지 종류
+const (
+	liveMessageHello = "hello" // 서버 → 클라이언트: 연결 직후 현재 순번 안내
+	liveMessageBatch = "batch" // 클라이언트 → 서버: 위치 묶음
+	liveMessageAck   = "ack"   // 서버 → 클라이언트: 묶음 저장 확인
+	liveMessageError = "error" // 서버 → 클라이언트: 묶음 처리 실패
+)
+
+// 클라이언트가 보내는 WebSocket 메시지
+type liveSocketRequest struct {
+	Type string `json:"type"`
+	models.LiveBatch
+}
+
+// 서버가 보내는 WebSocket 메시지
+type liveSocketReply struct {
+	Type string `json:"type"`
+	models.LiveAck
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// WebSocket으로 위치 묶음 전송 (세션 소유자만)
+// 연결 직후 hello 메시지로 서버에 저장된 마지막 순번을 알려주므로
+// 재연결한 클라이언트는 그 다음 순번부터 다시 보내면 됨
+//
+//	→ {"type":"batch","seq":3,"points":[...]}
+//	← {"type":"ack","seq":3,"last_seq":3,"point_count":120}
+//	← {"type":"error","seq":5,"last_seq":3,"point_count":120,"code":9016,"message":"..."}
+func (h *LiveHandler) UploadSocket(c *gin.Context) {
+	session, ok := h.loadSession(c, false)
+	if !ok || !h.requireOwner(c, session) {
+		return
+	}
+	if session.Status != models.LiveStatusActive {
+		c.JSON(
+			http.StatusConflict,
+			models.NewErrorResponse(errors.ErrLiveSessionClosed),
+		)
+		return
+	}
+
+	conn, err := websocket.Upgrade(c.Writer, c.Request)
+	if err != nil {
+		h.log.Debug("Live socket upgrade failed: %v", err)
+		return
+	}
+	conn.ReadTimeout = liveSocketReadTimeout
+	h.trackConn(conn, true)
+	defer h.trackConn(conn, false)
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(liveSocketPingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := conn.Ping(); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	hello := liveSocketReply{Type: liveMessageHello}
+	hello.LastSeq = session.LastSeq
+	hello.PointCount = session.PointCount
+	if err := conn.WriteJSON(hello); err != nil {
+		conn.Close(websocket.CloseInternalError, "")
+		return
+	}
+
+	userID := middleware.UserID(c)
+	for {
+		op, data, err := conn.ReadMessage()
+		if err != nil {
+			h.log.Debug("Live socket for session %s closed: %v", session.ID.Hex(), err)
+			conn.Close(websocket.CloseNormal, "")
+			return
+		}
+		if op != websocket.OpText {
+			conn.Close(websocket.CloseUnsupportedData, "text messages only")
+			return
+		}
+
+		var req liveSocketRequest
+		if err := json.Unmarshal(data, &req); err != nil || req.Type != liveMessageBatch {
+			reply := liveSocketReply{Type: liveMessageError, Code: errors.ErrInvalidLiveBatch}
+			reply.Message = errors.GetErrorMessage(errors.ErrInvalidLiveBatch)
+			if conn.WriteJSON(reply) != nil {
+				return
+			}
+			continue
+		}
+
+		ack, _, code := h.appendBatch(session.ID, userID, &req.LiveBatch)
+		reply := liveSocketReply{Type: liveMessageAck, LiveAck: ack}
+		if code != 0 {
+			reply.Type = liveMessageError
+			reply.Code = code
+			reply.Message = errors.GetErrorMessage(code)
+		}
+		if err := conn.WriteJSON(reply); err != nil {
+			return
+		}
+
+		// 종료/폐기된 세션에는 더 보낼 수 없으므로 연결 종료
+		if code == errors.ErrLiveSessionClosed || code == errors.ErrLiveSessionNotFound {
+			conn.Close(websocket.CloseNormal, "session closed")
+			return
+		}
+	}
+}
+
+// Server-Sent Events로 세션 위치 구독 (본인 또는 구독이 허용된 친구)
+// 연결 직후 지금까지의 위치를 points 이벤트로 보내고 이후 추가되는 묶음을 이어서 전달
+// 이벤트 ID는 누적 지점 개수이므로 재연결 시 Last-Event-ID(또는 last_event_id 쿼리)로
+// 이미 받은 지점 이후부터 받을 수 있음
+func (h *LiveHandler) StreamSession(c *gin.Context) {
+	session, ok := h.loadSession(c, false)
+	if !ok || !h.requireWatcher(c, session) {
+		return
+	}
+
+	// 구독 이후에 스냅숏을 읽어야 그 사이에 추가된 묶음을 놓치지 않음
+	events, cancel := h.hub.Subscribe(session.ID)
+	defer cancel()
+
+	var snapshot models.LiveSession
+	if err := h.sessions.ReadOne(bson.M{"_id": session.ID}, &snapshot); err != nil {
+		c.JSON(
+			http.StatusNotFound,
+			models.NewErrorResponse(errors.ErrLiveSessionNotFound),
+		)
+		return
+	}
+	if err := h.loadChunks(&snapshot); err != nil {
+		h.log.Error("Failed to load locations of live session %s: %v", session.ID.Hex(), err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrDatabaseQuery),
+		)
+		return
+	}
+
+	sent := lastEventID(c)
+	total := int64(len(snapshot.Locations))
+	if sent < 0 || sent > total {
+		sent = 0
+	}
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	if sent < total {
+		data := livePointsEvent{Seq: snapshot.LastSeq, From: sent, Points: snapshot.Locations[sent:]}
+		if writeSSE(c.Writer, total, live.EventPoints, data) != nil {
+			return
+		}
+		sent = total
+	}
+
+	switch snapshot.Status {
+	case models.LiveStatusFinished:
+		writeSSE(c.Writer, sent, live.EventFinish, gin.H{"ride_id": snapshot.RideID})
+		c.Writer.Flush()
+		return
+	case models.LiveStatusDiscarded:
+		writeSSE(c.Writer, sent, live.EventDiscard, nil)
+		c.Writer.Flush()
+		return
+	}
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(liveStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := io.WriteString(c.Writer, ": keepalive\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			if data, isPoints := event.Data.(livePointsEvent); isPoints {
+				// 스냅숏에 이미 포함된 지점은 제외
+				if event.ID <= sent {
+					continue
+				}
+				if data.From < sent {
+					data.Points = data.Points[sent-data.From:]
+					data.From = sent
+				}
+				event.Data = data
+			}
+
+			if writeSSE(c.Writer, event.ID, event.Type, event.Data) != nil {
+				return
+			}
+			c.Writer.Flush()
+			sent = event.ID
+
+			if event.Type != live.EventPoints {
+				return
+			}
+		}
+	}
+}
+
+// 재연결한 SSE 클라이언트가 마지막으로 받은 이벤트 ID
+func lastEventID(c *gin.Context) int64 {
+	value := c.GetHeader("Last-Event-ID")
+	if value == "" {
+		value = c.Query("last_event_id")
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// SSE 이벤트 하나를 출력
+func writeSSE(w io.Writer, id int64, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", id, event, payload)
+	return err
+}
+
+func (h *LiveHandler) trackConn(conn *websocket.Conn, add bool) {
+	h.connsMu.Lock()
+	defer h.connsMu.Unlock()
+	if add {
+		h.conns[conn] = struct{}{}
+	} else {
+		delete(h.conns, conn)
+	}
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/database"
+	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/logger"
+	"github.com/chrisS41/gobike-server/internal/middleware"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type RecordHandler struct {
+	records *database.Collection
+	log     *logger.Log
+}
+
+func NewRecordHandler(records *database.Collection, log *logger.Log) *RecordHandler {
+	return &RecordHandler{records: records, log: log}
+}
+
+// 본인의 개인 기록 목록 조회 (history=true이면 갱신 이력 포함)
+func (h *RecordHandler) ListRecords(c *gin.Context) {
+	docs, err := h.records.ReadMany(bson.M{"user_id": middleware.UserID(c)})
+	if err != nil {
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToFetchRecords),
+		)
+		return
+	}
+
+	withHistory := c.Query("history") == "true"
+	records := make([]models.PersonalRecord, 0, len(docs))
+	for _, doc := range docs {
+		var record models.PersonalRecord
+		bsonBytes, _ := bson.Marshal(doc)
+		bson.Unmarshal(bsonBytes, &record)
+		if !withHistory {
+			record.History = nil
+		}
+		records = append(records, record)
+	}
+
+	// 종목 정의 순서로 정렬
+	order := make(map[string]int, len(analysis.EffortDefinitions))
+	for i, d := range analysis.EffortDefinitions {
+		order[d.Key] = i
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return order[records[i].Key] < order[records[j].Key]
+	})
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(records))
+}
+
+// 종목별 개인 기록과 갱신 이력 조회
+func (h *RecordHandler) GetRecord(c *gin.Context) {
+	key := c.Param("key")
+	if _, ok := analysis.FindEffortDefinition(key); !ok {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrMissingParams, "알 수 없는 기록 종목입니다: "+key),
+		)
+		return
+	}
+
+	var record models.PersonalRecord
+	if err := h.records.ReadOne(bson.M{"user_id": middleware.UserID(c), "key": key}, &record); err != nil {
+		c.JSON(
+			http.StatusNotFound,
+			models.NewErrorResponse(errors.ErrRecordNotFound),
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(record))
+}
+
+// 라이드가 저장/수정/삭제된 뒤 keys 종목의 개인 기록을 다시 계산하고
+// rideID 라이드가 현재 최고 기록인 종목 목록을 반환
+// 개인 기록 갱신에 실패해도 라이드 요청은 실패시키지 않고 로그만 남김
+func refreshRecords(records, rides *database.Collection, log *logger.Log, userID, rideID primitive.ObjectID, keys []string) []string {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	best, err := rebuildRecords(records, rides, userID, keys)
+	if err != nil {
+		log.Error("Failed to rebuild personal records for user %s: %v", userID.Hex(), err)
+		return nil
+	}
+
+	var held []string
+	for _, d := range analysis.EffortDefinitions {
+		if record, ok := best[d.Key]; ok && record.Best.RideID == rideID {
+			held = append(held, d.Key)
+		}
+	}
+	return held
+}
+
+// 사용자의 라이드에 저장된 종목별 기록을 시작 시각 순으로 훑어 기록 갱신 이력을 다시 만듦
+// 종목의 기록이 하나도 남지 않으면 해당 개인 기록을 삭제
+func rebuildRecords(records, rides *database.Collection, userID primitive.ObjectID, keys []string) (map[string]models.PersonalRecord, error) {
+	docs, err := rides.Aggregate(bson.A{
+		bson.D{{Key: "$match", Value: bson.M{"user_id": userID, "best_efforts.key": bson.M{"$in": keys}}}},
+		bson.D{{Key: "$project", Value: bson.M{"start_time": 1, "best_efforts": 1}}},
+		bson.D{{Key: "$unwind", Value: "$best_efforts"}},
+		bson.D{{Key: "$match", Value: bson.M{"best_efforts.key": bson.M{"$in": keys}}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "start_time", Value: 1}, {Key: "_id", Value: 1}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	history := make(map[string][]models.RecordEntry, len(keys))
+	for _, doc := range docs {
+		var row struct {
+			RideID    primitive.ObjectID `bson:"_id"`
+			StartTime time.Time          `bson:"start_time"`
+			Effort    models.Effort      `bson:"best_efforts"`
+		}
+		bsonBytes, _ := bson.Marshal(doc)
+		if err := bson.Unmarshal(bsonBytes, &row); err != nil {
+			return nil, err
+		}
+
+		def, ok := analysis.FindEffortDefinition(row.Effort.Key)
+		if !ok {
+			continue
+		}
+		chain := history[def.Key]
+		if len(chain) == 0 || def.Better(row.Effort, chain[len(chain)-1].Effort) {
+			history[def.Key] = append(chain, models.RecordEntry{
+				RideID:     row.RideID,
+				AchievedAt: row.StartTime,
+				Effort:     row.Effort,
+			})
+		}
+	}
+
+	result := make(map[string]models.PersonalRecord, len(keys))
+	now := time.Now()
+	for _, key := range keys {
+		filter := bson.M{"user_id": userID, "key": key}
+		chain := history[key]
+		if len(chain) == 0 {
+			if err := records.Delete(filter); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		record := models.PersonalRecord{
+			UserID:    userID,
+			Key:       key,
+			Best:      chain[len(chain)-1],
+			History:   chain,
+			UpdatedAt: now,
+		}
+		if err := records.Upsert(filter, record); err != nil {
+			return nil, err
+		}
+		result[key] = record
+	}
+	return result, nil
+}
+
+// 종목 키 목록 (중복 제거)
+func effortKeys(efforts ...[]models.Effort) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, list := range efforts {
+		for _, e := range list {
+			if !seen[e.Key] {
+				seen[e.Key] = true
+				keys = append(keys, e.Key)
+			}
+		}
+	}
+	return keys
+}
@@ -1,40 +1,438 @@
 package handlers
 
 import (
+	"math"
+	"net/http"
+	"time"
+
 	"github.com/chrisS41/gobike-server/internal/database"
+	"github.com/chrisS41/gobike-server/internal/elevation"
+	"github.com/chrisS41/gobike-server/internal/errors"
 	"github.com/chrisS41/gobike-server/internal/logger"
+	"github.com/chrisS41/gobike-server/internal/middleware"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/chrisS41/gobike-server/internal/track"
+	"github.com/chrisS41/gobike-server/internal/trackstore"
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type RideHandler struct {
-	rides *database.Collection
-	log   *logger.Log
+	rides    *database.Collection
+	routes   *database.Collection
+	users    *database.Collection
+	records  *database.Collection
+	segments *database.Collection
+	efforts  *database.Collection
+	tracks   *trackstore.Store
+	dem      *elevation.Service
+	cleaner  *track.Cleaner
+	log      *logger.Log
 }
 
-func NewRideHandler(rides *database.Collection, log *logger.Log) *RideHandler {
-	return &RideHandler{rides: rides, log: log}
+func NewRideHandler(rides, routes, users, records, segments, efforts *database.Collection, tracks *trackstore.Store, dem *elevation.Service, cleaner *track.Cleaner, log *logger.Log) *RideHandler {
+	return &RideHandler{
+		rides:    rides,
+		routes:   routes,
+		users:    users,
+		records:  records,
+		segments: segments,
+		efforts:  efforts,
+		tracks:   tracks,
+		dem:      dem,
+		cleaner:  cleaner,
+		log:      log,
+	}
 }
 
+// 주행 기록 생성
 func (h *RideHandler) CreateRide(c *gin.Context) {
-	// TODO: 주행 기록 생성 로직 구현
+	var ride models.Ride
+	if err := c.ShouldBindJSON(&ride); err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidRide, err.Error()),
+		)
+		return
+	}
+
+	if code := validateRide(h.routes, h.users, &ride, middleware.UserID(c)); code != 0 {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponse(code),
+		)
+		return
+	}
+
+	prepareRideTrack(h.cleaner, h.dem, h.routes, h.log, &ride)
+
+	ride.ID = primitive.NilObjectID
+	ride.UserID = middleware.UserID(c)
+	ride.CreatedAt = time.Now()
+	ride.UpdatedAt = ride.CreatedAt
+
+	if err := insertRide(h.rides, h.tracks, h.log, &ride); err != nil {
+		h.log.Error("Failed to create ride: %v", err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToCreateRide),
+		)
+		return
+	}
+
+	countRouteRide(h.routes, h.log, ride.RouteID, 1)
+	ride.NewRecords = refreshRecords(h.records, h.rides, h.log, ride.UserID, ride.ID, effortKeys(ride.BestEfforts))
+	ride.SegmentEfforts = matchSegments(h.segments, h.efforts, h.log, &ride)
+
+	c.JSON(http.StatusCreated, models.NewSuccessResponse(ride))
 }
 
+// 사용자의 주행 기록 목록 조회 (최신순, page/limit 쿼리 지원)
+// geometry=full|simplified|polyline|none으로 트랙 형식 선택 (기본 full, polyline은 precision=5|6)
 func (h *RideHandler) GetUserRides(c *gin.Context) {
-	// TODO: 사용자의 주행 기록 목록 조회 로직 구현
+	userID, ok := requireSelf(c, "userId")
+	if !ok {
+		return
+	}
+	geometry, ok := parseGeometry(c, geometryFull)
+	if !ok {
+		return
+	}
+
+	page, limit := parsePage(c)
+	docs, err := h.rides.ReadManyPaged(
+		bson.M{"user_id": userID},
+		bson.D{{Key: "start_time", Value: -1}},
+		(page-1)*limit,
+		limit,
+		geometry.rideProjection(),
+	)
+	if err != nil {
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToFetchRides),
+		)
+		return
+	}
+
+	rides := make([]models.Ride, 0, len(docs))
+	for _, doc := range docs {
+		var ride models.Ride
+		bsonBytes, _ := bson.Marshal(doc)
+		bson.Unmarshal(bsonBytes, &ride)
+		if err := h.applyGeometry(geometry, &ride); err != nil {
+			h.log.Error("Failed to load track of ride %s: %v", ride.ID.Hex(), err)
+			c.JSON(
+				http.StatusInternalServerError,
+				models.NewErrorResponse(errors.ErrFailedToFetchRides),
+			)
+			return
+		}
+		rides = append(rides, ride)
+	}
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(rides))
 }
 
+// 특정 주행 기록 조회 (geometry 쿼리는 GetUserRides와 같음)
+// 경로와 연결됐지만 비교 결과가 없는 라이드(기능 도입 전 라이드, 경로 수정 후)는 이때 비교해 저장
 func (h *RideHandler) GetRide(c *gin.Context) {
-	// TODO: 특정 주행 기록 조회 로직 구현
-}
+	geometry, ok := parseGeometry(c, geometryFull)
+	if !ok {
+		return
+	}
+	ride, ok := h.loadOwnedRide(c)
+	if !ok {
+		return
+	}
+
+	// 트랙은 전체 형식으로 응답하거나 경로와 비교할 때만 읽음
+	compareRoute := !ride.RouteID.IsZero() && ride.RouteComparison == nil
+	if geometry.mode == geometryFull || compareRoute {
+		if err := h.tracks.Load(ride, geometry.mode == geometryFull); err != nil {
+			h.log.Error("Failed to load track of ride %s: %v", ride.ID.Hex(), err)
+			c.JSON(
+				http.StatusInternalServerError,
+				models.NewErrorResponse(errors.ErrFailedToFetchRides),
+			)
+			return
+		}
+	}
 
-func (h *RideHandler) GetRideStats(c *gin.Context) {
-	// TODO: 주행 기록 통계 조회 로직 구현
+	if compareRoute {
+		compareRideRoute(h.routes, h.log, ride)
+		if ride.RouteComparison != nil {
+			if err := h.rides.Update(
+				bson.M{"_id": ride.ID},
+				bson.M{"$set": bson.M{"route_comparison": ride.RouteComparison}},
+			); err != nil {
+				h.log.Error("Failed to save route comparison of ride %s: %v", ride.ID.Hex(), err)
+			}
+		}
+	}
+	if err := h.applyGeometry(geometry, ride); err != nil {
+		h.log.Error("Failed to load track of ride %s: %v", ride.ID.Hex(), err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToFetchRides),
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(ride))
 }
 
+// 주행 기록 수정
 func (h *RideHandler) UpdateRide(c *gin.Context) {
-	// TODO: 주행 기록 수정 로직 구현
+	existing, ok := h.loadOwnedRide(c)
+	if !ok {
+		return
+	}
+
+	var ride models.Ride
+	if err := c.ShouldBindJSON(&ride); err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidRide, err.Error()),
+		)
+		return
+	}
+
+	if code := validateRide(h.routes, h.users, &ride, existing.UserID); code != 0 {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponse(code),
+		)
+		return
+	}
+
+	prepareRideTrack(h.cleaner, h.dem, h.routes, h.log, &ride)
+
+	// ID, 소유자, 생성 시간은 변경 불가
+	ride.ID = primitive.NilObjectID
+	ride.UserID = existing.UserID
+	ride.CreatedAt = existing.CreatedAt
+	ride.UpdatedAt = time.Now()
+
+	// omitempty로 $set에서 빠지는 필드는 명시적으로 제거
+	// 트랙은 트랙 묶음으로 교체하므로 옮기기 전 형식의 문서에 남은 트랙 필드도 제거
+	update := bson.M{"$set": ride}
+	unset := bson.M{"locations": "", "raw_locations": "", "streams": ""}
+	if ride.RouteID.IsZero() {
+		unset["route_id"] = ""
+	}
+	if len(ride.Simplified) == 0 {
+		unset["simplified"] = ""
+	}
+	if len(ride.Pauses) == 0 {
+		unset["pauses"] = ""
+	}
+	if len(ride.BestEfforts) == 0 {
+		unset["best_efforts"] = ""
+	}
+	if len(ride.Climbs) == 0 {
+		unset["climbs"] = ""
+	}
+	if ride.RouteComparison == nil {
+		unset["route_comparison"] = ""
+	}
+	if ride.Thresholds == nil {
+		unset["thresholds"] = ""
+	}
+	if ride.Sensors == nil {
+		unset["sensors"] = ""
+	}
+	update["$unset"] = unset
+
+	// 문서의 트랙 필드를 지우기 전에 트랙 묶음을 먼저 교체
+	ride.ID = existing.ID
+	if err := h.tracks.Save(&ride); err != nil {
+		h.log.Error("Failed to update track of ride %s: %v", existing.ID.Hex(), err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToUpdateRide),
+		)
+		return
+	}
+
+	if err := h.rides.Update(bson.M{"_id": existing.ID}, update); err != nil {
+		h.log.Error("Failed to update ride %s: %v", existing.ID.Hex(), err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToUpdateRide),
+		)
+		return
+	}
+
+	if ride.RouteID != existing.RouteID {
+		countRouteRide(h.routes, h.log, existing.RouteID, -1)
+		countRouteRide(h.routes, h.log, ride.RouteID, 1)
+	}
+	ride.NewRecords = refreshRecords(h.records, h.rides, h.log, ride.UserID, ride.ID, effortKeys(existing.BestEfforts, ride.BestEfforts))
+	ride.SegmentEfforts = matchSegments(h.segments, h.efforts, h.log, &ride)
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(ride))
 }
 
+// 주행 기록 삭제
 func (h *RideHandler) DeleteRide(c *gin.Context) {
-	// TODO: 주행 기록 삭제 로직 구현
+	ride, ok := h.loadOwnedRide(c)
+	if !ok {
+		return
+	}
+
+	if err := h.rides.Delete(bson.M{"_id": ride.ID}); err != nil {
+		h.log.Error("Failed to delete ride %s: %v", ride.ID.Hex(), err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToDeleteRide),
+		)
+		return
+	}
+
+	if err := h.tracks.Delete(ride.ID); err != nil {
+		h.log.Error("Failed to delete track of ride %s: %v", ride.ID.Hex(), err)
+	}
+	countRouteRide(h.routes, h.log, ride.RouteID, -1)
+
+	// 삭제된 라이드가 포함된 종목의 개인 기록 재계산
+	refreshRecords(h.records, h.rides, h.log, ride.UserID, ride.ID, effortKeys(ride.BestEfforts))
+	clearSegmentEfforts(h.efforts, h.log, ride.ID)
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(gin.H{"id": ride.ID}))
+}
+
+// 라이드 응답의 트랙을 geometry 형식으로 바꿈
+// full이면 트랙 묶음을 읽고, 단순화 트랙이 없는 라이드(단순화 설정이 none)는 트랙을 읽어 단순화하고 저장
+func (h *RideHandler) applyGeometry(geometry geometryOption, ride *models.Ride) error {
+	switch geometry.mode {
+	case geometryFull:
+		if ride.Locations == nil {
+			return h.tracks.Load(ride, true)
+		}
+	case geometrySimplified, geometryPolyline:
+		if len(ride.Simplified) > 0 {
+			break
+		}
+		if ride.Locations == nil {
+			if err := h.tracks.Load(ride, false); err != nil {
+				return err
+			}
+		}
+		ride.Simplified = simplifyTrack(h.cleaner, ride.Locations)
+		if len(ride.Simplified) > 0 {
+			if err := h.rides.Update(
+				bson.M{"_id": ride.ID},
+				bson.M{"$set": bson.M{"simplified": ride.Simplified}},
+			); err != nil {
+				h.log.Error("Failed to save simplified track of ride %s: %v", ride.ID.Hex(), err)
+			}
+		}
+	}
+	geometry.applyToRide(ride)
+	return nil
+}
+
+// :id 경로 파라미터의 주행 기록을 조회하고 호출자 소유인지 확인
+func (h *RideHandler) loadOwnedRide(c *gin.Context) (*models.Ride, bool) {
+	rideID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrMissingParams, "잘못된 라이드 ID입니다"),
+		)
+		return nil, false
+	}
+
+	var ride models.Ride
+	if err := h.rides.ReadOne(bson.M{"_id": rideID}, &ride); err != nil {
+		c.JSON(
+			http.StatusNotFound,
+			models.NewErrorResponse(errors.ErrRideNotFound),
+		)
+		return nil, false
+	}
+
+	if ride.UserID != middleware.UserID(c) {
+		c.JSON(
+			http.StatusForbidden,
+			models.NewErrorResponse(errors.ErrUnauthorized),
+		)
+		return nil, false
+	}
+
+	return &ride, true
+}
+
+// 주행 기록 입력값 검증, 문제가 없으면 0 반환
+// 연결된 경로가 있으면 routes 컬렉션에 존재하고 userID 사용자가 볼 수 있는지 확인
+func validateRide(routes, users *database.Collection, ride *models.Ride, userID primitive.ObjectID) int {
+	if ride.StartTime.IsZero() || ride.EndTime.IsZero() || !ride.StartTime.Before(ride.EndTime) {
+		return errors.ErrInvalidRideTime
+	}
+
+	if len(ride.Locations) == 0 {
+		return errors.ErrInvalidRideLocations
+	}
+	for _, p := range ride.Locations {
+		if p.Latitude < -90 || p.Latitude > 90 || p.Longitude < -180 || p.Longitude > 180 ||
+			(p.Accuracy != nil && *p.Accuracy < 0) {
+			return errors.ErrInvalidRideLocations
+		}
+	}
+
+	if !ride.Streams.Aligned(len(ride.Locations)) || !validSensorStreams(ride.Streams) {
+		return errors.ErrInvalidRideStreams
+	}
+	if t := ride.Thresholds; t != nil && (t.FTP < 0 || t.MaxHeartRate < 0) {
+		return errors.ErrInvalidRideStreams
+	}
+
+	if !ride.RouteID.IsZero() {
+		if !canLinkRoute(routes, users, ride.RouteID, userID) {
+			return errors.ErrRideRouteNotFound
+		}
+	}
+
+	return 0
+}
+
+// userID 사용자가 라이드를 연결할 수 있는 경로인지 확인 (존재하고 볼 수 있는 경로)
+func canLinkRoute(routes, users *database.Collection, routeID, userID primitive.ObjectID) bool {
+	var route models.Route
+	if err := routes.ReadOne(bson.M{"_id": routeID}, &route); err != nil {
+		return false
+	}
+	return canViewRoute(users, &route, userID)
+}
+
+// 센서 측정값 범위 검증 (음수 불가, 좌우 밸런스는 0~100%)
+func validSensorStreams(s *models.SensorStreams) bool {
+	if s == nil {
+		return true
+	}
+	return inRange(s.HeartRate, 0, math.MaxInt) && inRange(s.Cadence, 0, math.MaxInt) &&
+		inRange(s.Power, 0, math.MaxInt) && inRange(s.Speed, 0, math.Inf(1)) &&
+		inRange(s.Balance, 0, 100)
+}
+
+// 센서 측정값 하나의 범위 검증 (실시간 측정값용)
+func validSensorSample(sample models.SensorSample) bool {
+	return validSensorStreams(&models.SensorStreams{
+		HeartRate: []*int{sample.HeartRate},
+		Cadence:   []*int{sample.Cadence},
+		Power:     []*int{sample.Power},
+		Speed:     []*float64{sample.Speed},
+		Balance:   []*float64{sample.Balance},
+	})
+}
+
+func inRange[T int | float64](values []*T, lo, hi T) bool {
+	for _, v := range values {
+		if v != nil && (*v < lo || *v > hi) {
+			return false
+		}
+	}
+	return true
 }
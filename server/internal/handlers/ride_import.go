@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/fit"
+	"github.com/chrisS41/gobike-server/internal/gpx"
+	"github.com/chrisS41/gobike-server/internal/middleware"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/chrisS41/gobike-server/internal/tcx"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 업로드 가능한 라이드 파일 최대 크기
+const maxRideFileSize = 32 << 20
+
+// GPX 파일로 주행 기록 생성
+// multipart 폼: file (GPX 파일, 필수), route_id (연결할 경로 ID, 선택),
+// ftp, max_heart_rate (센서 구간과 강도 계산 기준, 선택)
+func (h *RideHandler) ImportGPX(c *gin.Context) {
+	h.importRide(c, func(r io.Reader) (*models.Ride, int, error) {
+		g, err := gpx.Parse(r)
+		if err != nil {
+			return nil, errors.ErrInvalidGPX, err
+		}
+		ride, err := gpx.ToRide(g)
+		if err != nil {
+			return nil, errors.ErrInvalidRideFile, err
+		}
+		return ride, 0, nil
+	})
+}
+
+// FIT 파일(Garmin, Wahoo 등 기기 기록)로 주행 기록 생성
+// multipart 폼: file (FIT 파일, 필수), route_id (연결할 경로 ID, 선택),
+// ftp, max_heart_rate (센서 구간과 강도 계산 기준, 선택)
+func (h *RideHandler) ImportFIT(c *gin.Context) {
+	h.importRide(c, func(r io.Reader) (*models.Ride, int, error) {
+		f, err := fit.Decode(r)
+		if err != nil {
+			return nil, errors.ErrInvalidRideFile, err
+		}
+		ride, err := fit.ToRide(f)
+		if err != nil {
+			return nil, errors.ErrInvalidRideFile, err
+		}
+		return ride, 0, nil
+	})
+}
+
+// TCX 파일로 주행 기록 생성
+// multipart 폼: file (TCX 파일, 필수), route_id (연결할 경로 ID, 선택),
+// ftp, max_heart_rate (센서 구간과 강도 계산 기준, 선택)
+func (h *RideHandler) ImportTCX(c *gin.Context) {
+	h.importRide(c, func(r io.Reader) (*models.Ride, int, error) {
+		db, err := tcx.Parse(r)
+		if err != nil {
+			return nil, errors.ErrInvalidRideFile, err
+		}
+		ride, err := tcx.ToRide(db)
+		if err != nil {
+			return nil, errors.ErrInvalidRideFile, err
+		}
+		return ride, 0, nil
+	})
+}
+
+// 업로드된 file 필드를 읽어 convert로 라이드로 변환한 뒤 저장
+// convert는 실패 시 응답에 사용할 에러 코드를 함께 반환
+func (h *RideHandler) importRide(c *gin.Context, convert func(io.Reader) (*models.Ride, int, error)) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxRideFileSize)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrMissingParams, err.Error()),
+		)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidRideFile, err.Error()),
+		)
+		return
+	}
+	defer file.Close()
+
+	ride, code, err := convert(file)
+	if err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(code, err.Error()),
+		)
+		return
+	}
+
+	h.createImportedRide(c, ride)
+}
+
+// 파일에서 변환한 라이드를 검증하고 저장
+// 폼의 route_id가 있으면 경로와 연결하고, ftp/max_heart_rate가 있으면 기준값으로 저장
+func (h *RideHandler) createImportedRide(c *gin.Context, ride *models.Ride) {
+	thresholds, ok := parseThresholdsForm(c)
+	if !ok {
+		return
+	}
+	ride.Thresholds = thresholds
+
+	if routeID := c.PostForm("route_id"); routeID != "" {
+		id, err := primitive.ObjectIDFromHex(routeID)
+		if err != nil {
+			c.JSON(
+				http.StatusBadRequest,
+				models.NewErrorResponseWithMessage(errors.ErrMissingParams, "잘못된 경로 ID입니다"),
+			)
+			return
+		}
+		ride.RouteID = id
+	}
+
+	if code := validateRide(h.routes, h.users, ride, middleware.UserID(c)); code != 0 {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponse(code),
+		)
+		return
+	}
+
+	prepareRideTrack(h.cleaner, h.dem, h.routes, h.log, ride)
+
+	ride.UserID = middleware.UserID(c)
+	ride.CreatedAt = time.Now()
+	ride.UpdatedAt = ride.CreatedAt
+
+	if err := insertRide(h.rides, h.tracks, h.log, ride); err != nil {
+		h.log.Error("Failed to create imported ride: %v", err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToCreateRide),
+		)
+		return
+	}
+
+	countRouteRide(h.routes, h.log, ride.RouteID, 1)
+	ride.NewRecords = refreshRecords(h.records, h.rides, h.log, ride.UserID, ride.ID, effortKeys(ride.BestEfforts))
+	ride.SegmentEfforts = matchSegments(h.segments, h.efforts, h.log, ride)
+
+	c.JSON(http.StatusCreated, models.NewSuccessResponse(ride))
+}
+
+// 폼의 ftp, max_heart_rate 기준값 (둘 다 없으면 nil)
+// 양의 정수가 아니면 400 응답 후 false
+func parseThresholdsForm(c *gin.Context) (*models.Thresholds, bool) {
+	var t models.Thresholds
+	for _, f := range []struct {
+		name  string
+		value *int
+	}{{"ftp", &t.FTP}, {"max_heart_rate", &t.MaxHeartRate}} {
+		v := c.PostForm(f.name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			c.JSON(
+				http.StatusBadRequest,
+				models.NewErrorResponseWithMessage(errors.ErrMissingParams, f.name+"는 양의 정수여야 합니다"),
+			)
+			return nil, false
+		}
+		*f.value = n
+	}
+	if t == (models.Thresholds{}) {
+		return nil, true
+	}
+	return &t, true
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"github.com/chrisS41/gobike-server/internal/compare"
+	"github.com/chrisS41/gobike-server/internal/database"
+	"github.com/chrisS41/gobike-server/internal/gpx"
+	"github.com/chrisS41/gobike-server/internal/logger"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// 라이드를 연결된 경로와 비교해 ride.RouteComparison에 채움 (연결된 경로나 경로 트랙이 없으면 nil)
+// 경로를 읽지 못해도 라이드 요청은 실패시키지 않고 로그만 남김
+// 경로의 GPX 웨이포인트는 체크포인트로 사용
+func compareRideRoute(routes *database.Collection, log *logger.Log, ride *models.Ride) {
+	ride.RouteComparison = nil
+	if ride.RouteID.IsZero() {
+		return
+	}
+
+	var route models.Route
+	if err := routes.ReadOne(bson.M{"_id": ride.RouteID}, &route); err != nil {
+		log.Error("Failed to load route %s to compare ride: %v", ride.RouteID.Hex(), err)
+		return
+	}
+	if route.GPXData == "" {
+		return
+	}
+	g, err := gpx.ParseString(route.GPXData)
+	if err != nil {
+		log.Error("Failed to parse gpx of route %s: %v", route.ID.Hex(), err)
+		return
+	}
+
+	var checkpoints []compare.Checkpoint
+	for _, wp := range g.Waypoints {
+		name := wp.Name
+		if name == "" {
+			name = defaultPOIName
+		}
+		checkpoints = append(checkpoints, compare.Checkpoint{Name: name, Point: wp.GeoPoint()})
+	}
+	ride.RouteComparison = compare.Compare(g.GeoPoints(), checkpoints, ride.Locations)
+}
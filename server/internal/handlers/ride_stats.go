@@ -0,0 +1,502 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 통계 집계 단위
+const (
+	granularityWeek  = "week"
+	granularityMonth = "month"
+	granularityYear  = "year"
+)
+
+// 한 번에 조회할 수 있는 최대 구간 수 (차트용)
+const maxStatsPeriods = 1000
+
+// 통계 조회 조건
+type statsQuery struct {
+	From        time.Time // 포함
+	To          time.Time // 제외
+	Granularity string
+	Location    *time.Location
+}
+
+// 조회 기간에 포함되는 마지막 시각 (To는 제외이므로 날짜만 지정한 to=2024-12-31도 2024년에 속함)
+func (q *statsQuery) lastInstant() time.Time {
+	return q.To.Add(-time.Nanosecond)
+}
+
+// 기간 합계와 평균
+// 합계 필드는 집계 파이프라인 결과에서 읽고, 평균 필드는 합계로부터 계산
+type statsSummary struct {
+	RideCount     int64         `bson:"ride_count" json:"ride_count"`
+	Distance      float64       `bson:"distance" json:"distance"`             // 킬로미터
+	Duration      time.Duration `bson:"duration" json:"duration"`             // 전체 경과 시간
+	MovingTime    time.Duration `bson:"moving_time" json:"moving_time"`       // 이동 시간
+	ElevationGain float64       `bson:"elevation_gain" json:"elevation_gain"` // 미터
+	Calories      float64       `bson:"calories" json:"calories"`
+	MaxSpeed      float64       `bson:"max_speed" json:"max_speed"`       // km/h
+	LongestRide   float64       `bson:"longest_ride" json:"longest_ride"` // 킬로미터
+
+	AvgDistance      float64       `bson:"-" json:"avg_distance"`       // 라이드당 킬로미터
+	AvgMovingTime    time.Duration `bson:"-" json:"avg_moving_time"`    // 라이드당 이동 시간
+	AvgElevationGain float64       `bson:"-" json:"avg_elevation_gain"` // 라이드당 미터
+	AvgSpeed         float64       `bson:"-" json:"avg_speed"`          // km/h (이동 시간 기준)
+}
+
+// 구간별 통계
+type statsPeriod struct {
+	Key   string    `bson:"-" json:"period"` // 2024, 2024-05, 2024-W18
+	Start time.Time `bson:"-" json:"start"`
+	ID    struct {
+		Year  int `bson:"year"`
+		Month int `bson:"month,omitempty"`
+		Week  int `bson:"week,omitempty"`
+	} `bson:"_id" json:"-"`
+	statsSummary `bson:",inline"`
+}
+
+// 연속 주행 기록
+type statsStreaks struct {
+	CurrentDays     int        `json:"current_days"` // 오늘(또는 어제)까지 연속으로 주행한 일수
+	LongestDays     int        `json:"longest_days"` // 가장 길었던 연속 주행 일수
+	LongestDaysFrom *time.Time `json:"longest_days_from,omitempty"`
+	LongestDaysTo   *time.Time `json:"longest_days_to,omitempty"`
+	CurrentWeeks    int        `json:"current_weeks"` // 이번 주(또는 지난주)까지 연속으로 주행한 주 수
+	LongestWeeks    int        `json:"longest_weeks"`
+}
+
+// 전년 동기 대비
+// 조회 종료 시점이 속한 해의 1월 1일부터 종료 시점까지와, 전년의 같은 기간을 비교
+type statsYearOverYear struct {
+	Year         int                 `json:"year"`
+	PreviousYear int                 `json:"previous_year"`
+	Through      time.Time           `json:"through"` // 비교 기간의 마지막 시각 (올해 기준, 포함)
+	Current      statsSummary        `json:"current"`
+	Previous     statsSummary        `json:"previous"`
+	Change       map[string]*float64 `json:"change"` // 항목별 증감률(%), 전년 값이 0이면 null
+}
+
+// 집계 파이프라인 $facet 결과
+type statsFacets struct {
+	Totals  []statsSummary `bson:"totals"`
+	Periods []statsPeriod  `bson:"periods"`
+	Days    []struct {
+		ID string `bson:"_id"`
+	} `bson:"days"`
+	Current  []statsSummary `bson:"yoy_current"`
+	Previous []statsSummary `bson:"yoy_previous"`
+}
+
+// 사용자의 주행 기록 통계 조회
+// 쿼리: from, to (YYYY-MM-DD 또는 RFC3339, 날짜만 지정한 to는 그 날짜까지 포함),
+// granularity (week, month 기본, year), tz (IANA 시간대, 기본 UTC)
+func (h *RideHandler) GetRideStats(c *gin.Context) {
+	userID, ok := requireSelf(c, "id")
+	if !ok {
+		return
+	}
+
+	q, err := parseStatsQuery(c)
+	if err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidStatsQuery, err.Error()),
+		)
+		return
+	}
+
+	docs, err := h.rides.Aggregate(rideStatsPipeline(userID, q))
+	if err != nil || len(docs) == 0 {
+		h.log.Error("Failed to aggregate ride stats for user %s: %v", userID.Hex(), err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToFetchRideStat),
+		)
+		return
+	}
+
+	var facets statsFacets
+	bsonBytes, _ := bson.Marshal(docs[0])
+	if err := bson.Unmarshal(bsonBytes, &facets); err != nil {
+		h.log.Error("Failed to decode ride stats: %v", err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToFetchRideStat),
+		)
+		return
+	}
+
+	days := make([]string, len(facets.Days))
+	for i, d := range facets.Days {
+		days[i] = d.ID
+	}
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(gin.H{
+		"from":           q.From,
+		"to":             q.To,
+		"granularity":    q.Granularity,
+		"timezone":       q.Location.String(),
+		"totals":         firstSummary(facets.Totals),
+		"periods":        fillPeriods(facets.Periods, q),
+		"streaks":        computeStreaks(days, q),
+		"year_over_year": yearOverYear(facets, q),
+	}))
+}
+
+func parseStatsQuery(c *gin.Context) (*statsQuery, error) {
+	q := &statsQuery{Granularity: granularityMonth, Location: time.UTC}
+
+	if g := c.Query("granularity"); g != "" {
+		switch g {
+		case granularityWeek, granularityMonth, granularityYear:
+			q.Granularity = g
+		default:
+			return nil, fmt.Errorf("granularity는 week, month, year 중 하나여야 합니다")
+		}
+	}
+
+	if tz := c.Query("tz"); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil || tz == "Local" {
+			return nil, fmt.Errorf("알 수 없는 시간대입니다: %s", tz)
+		}
+		q.Location = loc
+	}
+
+	var err error
+	q.To = time.Now().In(q.Location)
+	if v := c.Query("to"); v != "" {
+		if q.To, err = parseStatsTime(v, q.Location, true); err != nil {
+			return nil, err
+		}
+	}
+
+	// 기본 조회 기간: week/month는 최근 1년, year는 최근 5년
+	if q.Granularity == granularityYear {
+		q.From = truncatePeriod(q.To.AddDate(-4, 0, 0), q.Granularity)
+	} else {
+		q.From = truncatePeriod(q.To.AddDate(-1, 0, 0), q.Granularity)
+	}
+	if v := c.Query("from"); v != "" {
+		if q.From, err = parseStatsTime(v, q.Location, false); err != nil {
+			return nil, err
+		}
+	}
+
+	if !q.From.Before(q.To) {
+		return nil, fmt.Errorf("from은 to보다 빨라야 합니다")
+	}
+
+	periods := 0
+	for t := truncatePeriod(q.From, q.Granularity); t.Before(q.To); t = nextPeriod(t, q.Granularity) {
+		if periods++; periods > maxStatsPeriods {
+			return nil, fmt.Errorf("조회 구간이 너무 많습니다 (최대 %d개)", maxStatsPeriods)
+		}
+	}
+
+	return q, nil
+}
+
+// 날짜(YYYY-MM-DD) 또는 RFC3339 시각 해석
+// 날짜만 지정한 종료 시각은 그 날짜를 포함하도록 다음 날 0시로 변환
+func parseStatsTime(value string, loc *time.Location, end bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.In(loc), nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("잘못된 날짜 형식입니다: %s", value)
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// 통계 집계 파이프라인
+// 사용자 라이드를 한 번만 읽어 $facet으로 기간 합계, 구간별 합계, 주행 일자, 전년 동기 비교를 함께 계산
+func rideStatsPipeline(userID primitive.ObjectID, q *statsQuery) bson.A {
+	tz := q.Location.String()
+	inRange := func(from, to time.Time) bson.D {
+		return bson.D{{Key: "$match", Value: bson.M{"start_time": bson.M{"$gte": from, "$lt": to}}}}
+	}
+
+	var groupKey bson.M
+	switch q.Granularity {
+	case granularityWeek:
+		groupKey = bson.M{
+			"year": bson.M{"$isoWeekYear": bson.M{"date": "$start_time", "timezone": tz}},
+			"week": bson.M{"$isoWeek": bson.M{"date": "$start_time", "timezone": tz}},
+		}
+	case granularityMonth:
+		groupKey = bson.M{
+			"year":  bson.M{"$year": bson.M{"date": "$start_time", "timezone": tz}},
+			"month": bson.M{"$month": bson.M{"date": "$start_time", "timezone": tz}},
+		}
+	default:
+		groupKey = bson.M{
+			"year": bson.M{"$year": bson.M{"date": "$start_time", "timezone": tz}},
+		}
+	}
+
+	yoyTo := q.To
+	yoyFrom := time.Date(q.lastInstant().Year(), 1, 1, 0, 0, 0, 0, q.Location)
+	prevFrom := yoyFrom.AddDate(-1, 0, 0)
+	prevTo := yoyTo.AddDate(-1, 0, 0)
+
+	// 연속 주행 계산은 조회 시작 시점과 무관하게 종료 시점까지의 전체 기록을 사용
+	streakTo := q.To
+	if now := time.Now(); streakTo.After(now) {
+		streakTo = now
+	}
+
+	return bson.A{
+		bson.D{{Key: "$match", Value: bson.M{"user_id": userID}}},
+		bson.D{{Key: "$facet", Value: bson.M{
+			"totals": bson.A{
+				inRange(q.From, q.To),
+				bson.D{{Key: "$group", Value: summaryGroup(nil)}},
+			},
+			"periods": bson.A{
+				inRange(q.From, q.To),
+				bson.D{{Key: "$group", Value: summaryGroup(groupKey)}},
+			},
+			"days": bson.A{
+				bson.D{{Key: "$match", Value: bson.M{"start_time": bson.M{"$lt": streakTo}}}},
+				bson.D{{Key: "$group", Value: bson.M{
+					"_id": bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$start_time", "timezone": tz}},
+				}}},
+				bson.D{{Key: "$sort", Value: bson.M{"_id": 1}}},
+			},
+			"yoy_current": bson.A{
+				inRange(yoyFrom, yoyTo),
+				bson.D{{Key: "$group", Value: summaryGroup(nil)}},
+			},
+			"yoy_previous": bson.A{
+				inRange(prevFrom, prevTo),
+				bson.D{{Key: "$group", Value: summaryGroup(nil)}},
+			},
+		}}},
+	}
+}
+
+// statsSummary 합계 필드를 계산하는 $group 단계
+// 통계 필드가 추가되기 전의 라이드는 값이 없을 수 있으므로 0으로 취급
+func summaryGroup(id interface{}) bson.M {
+	sum := func(field string) bson.M {
+		return bson.M{"$sum": bson.M{"$ifNull": bson.A{"$" + field, 0}}}
+	}
+	return bson.M{
+		"_id":            id,
+		"ride_count":     bson.M{"$sum": 1},
+		"distance":       sum("distance"),
+		"duration":       sum("duration"),
+		"moving_time":    sum("moving_time"),
+		"elevation_gain": sum("elevation_gain"),
+		"calories":       sum("calories"),
+		"max_speed":      bson.M{"$max": "$max_speed"},
+		"longest_ride":   bson.M{"$max": "$distance"},
+	}
+}
+
+// 합계로부터 평균 계산
+func (s *statsSummary) computeAverages() {
+	if s.RideCount > 0 {
+		s.AvgDistance = s.Distance / float64(s.RideCount)
+		s.AvgMovingTime = s.MovingTime / time.Duration(s.RideCount)
+		s.AvgElevationGain = s.ElevationGain / float64(s.RideCount)
+	}
+	// 이동 시간이 없는 오래된 기록은 전체 경과 시간 기준
+	hours := s.MovingTime.Hours()
+	if hours <= 0 {
+		hours = s.Duration.Hours()
+	}
+	if hours > 0 {
+		s.AvgSpeed = s.Distance / hours
+	}
+}
+
+func firstSummary(summaries []statsSummary) statsSummary {
+	var s statsSummary
+	if len(summaries) > 0 {
+		s = summaries[0]
+	}
+	s.computeAverages()
+	return s
+}
+
+// 조회 기간의 모든 구간을 순서대로 반환 (라이드가 없는 구간은 0)
+func fillPeriods(found []statsPeriod, q *statsQuery) []statsPeriod {
+	byKey := make(map[string]statsPeriod, len(found))
+	for _, p := range found {
+		byKey[periodKey(p.ID.Year, p.ID.Month, p.ID.Week, q.Granularity)] = p
+	}
+
+	periods := make([]statsPeriod, 0)
+	for start := truncatePeriod(q.From, q.Granularity); start.Before(q.To); start = nextPeriod(start, q.Granularity) {
+		var year, month, week int
+		if q.Granularity == granularityWeek {
+			year, week = start.ISOWeek()
+		} else {
+			year, month = start.Year(), int(start.Month())
+		}
+
+		key := periodKey(year, month, week, q.Granularity)
+		p := byKey[key]
+		p.Key = key
+		p.Start = start
+		p.computeAverages()
+		periods = append(periods, p)
+	}
+	return periods
+}
+
+func periodKey(year, month, week int, granularity string) string {
+	switch granularity {
+	case granularityWeek:
+		return fmt.Sprintf("%04d-W%02d", year, week)
+	case granularityMonth:
+		return fmt.Sprintf("%04d-%02d", year, month)
+	}
+	return fmt.Sprintf("%04d", year)
+}
+
+// t가 속한 구간의 시작 시각 (주는 월요일 시작)
+func truncatePeriod(t time.Time, granularity string) time.Time {
+	y, m, d := t.Date()
+	switch granularity {
+	case granularityWeek:
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(y, m, d-offset, 0, 0, 0, 0, t.Location())
+	case granularityMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	}
+	return time.Date(y, 1, 1, 0, 0, 0, 0, t.Location())
+}
+
+func nextPeriod(t time.Time, granularity string) time.Time {
+	switch granularity {
+	case granularityWeek:
+		return t.AddDate(0, 0, 7)
+	case granularityMonth:
+		return t.AddDate(0, 1, 0)
+	}
+	return t.AddDate(1, 0, 0)
+}
+
+// 주행 일자 목록(오름차순, YYYY-MM-DD)으로 연속 주행 기록 계산
+func computeStreaks(days []string, q *statsQuery) statsStreaks {
+	var s statsStreaks
+	if len(days) == 0 {
+		return s
+	}
+
+	dates := make([]time.Time, 0, len(days))
+	for _, d := range days {
+		if t, err := time.ParseInLocation("2006-01-02", d, q.Location); err == nil {
+			dates = append(dates, t)
+		}
+	}
+	if len(dates) == 0 {
+		return s
+	}
+
+	// 기준일: 조회 종료 시점과 현재 중 빠른 날
+	ref := q.lastInstant()
+	if now := time.Now().In(q.Location); ref.After(now) {
+		ref = now
+	}
+	today := time.Date(ref.Year(), ref.Month(), ref.Day(), 0, 0, 0, 0, q.Location)
+
+	// 일 단위
+	run, runStart := 1, dates[0]
+	for i := 1; i <= len(dates); i++ {
+		if i < len(dates) && sameDay(dates[i-1].AddDate(0, 0, 1), dates[i]) {
+			run++
+			continue
+		}
+		if run > s.LongestDays {
+			from, to := runStart, dates[i-1]
+			s.LongestDays, s.LongestDaysFrom, s.LongestDaysTo = run, &from, &to
+		}
+		if i < len(dates) {
+			run, runStart = 1, dates[i]
+		}
+	}
+	last := dates[len(dates)-1]
+	if sameDay(last, today) || sameDay(last.AddDate(0, 0, 1), today) {
+		s.CurrentDays = run
+	}
+
+	// 주 단위 (월요일 시작)
+	var weeks []time.Time
+	for _, d := range dates {
+		w := truncatePeriod(d, granularityWeek)
+		if len(weeks) == 0 || !sameDay(weeks[len(weeks)-1], w) {
+			weeks = append(weeks, w)
+		}
+	}
+	run = 1
+	for i := 1; i <= len(weeks); i++ {
+		if i < len(weeks) && sameDay(weeks[i-1].AddDate(0, 0, 7), weeks[i]) {
+			run++
+			continue
+		}
+		if run > s.LongestWeeks {
+			s.LongestWeeks = run
+		}
+		if i < len(weeks) {
+			run = 1
+		}
+	}
+	thisWeek := truncatePeriod(today, granularityWeek)
+	lastWeek := weeks[len(weeks)-1]
+	if sameDay(lastWeek, thisWeek) || sameDay(lastWeek.AddDate(0, 0, 7), thisWeek) {
+		s.CurrentWeeks = run
+	}
+
+	return s
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+func yearOverYear(facets statsFacets, q *statsQuery) statsYearOverYear {
+	through := q.lastInstant()
+	yoy := statsYearOverYear{
+		Year:         through.Year(),
+		PreviousYear: through.Year() - 1,
+		Through:      through,
+		Current:      firstSummary(facets.Current),
+		Previous:     firstSummary(facets.Previous),
+	}
+
+	change := func(current, previous float64) *float64 {
+		if previous == 0 {
+			return nil
+		}
+		pct := (current - previous) / previous * 100
+		return &pct
+	}
+	yoy.Change = map[string]*float64{
+		"ride_count":     change(float64(yoy.Current.RideCount), float64(yoy.Previous.RideCount)),
+		"distance":       change(yoy.Current.Distance, yoy.Previous.Distance),
+		"moving_time":    change(float64(yoy.Current.MovingTime), float64(yoy.Previous.MovingTime)),
+		"elevation_gain": change(yoy.Current.ElevationGain, yoy.Previous.ElevationGain),
+		"calories":       change(yoy.Current.Calories, yoy.Previous.Calories),
+	}
+	return yoy
+}
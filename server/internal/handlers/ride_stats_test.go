@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func statsContext(query string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/api/users/me/rides/stats?"+query, nil)
+	return c
+}
+
+func date(loc *time.Location, y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+func TestParseStatsQuery(t *testing.T) {
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		t.Skip("시간대 데이터 없음:", err)
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		from, to    time.Time
+		granularity string
+	}{
+		{
+			name:        "날짜만 지정한 to는 그 날짜 포함",
+			query:       "from=2024-01-01&to=2024-12-31",
+			from:        date(time.UTC, 2024, 1, 1),
+			to:          date(time.UTC, 2025, 1, 1),
+			granularity: granularityMonth,
+		},
+		{
+			name:        "시간대",
+			query:       "from=2024-03-01&to=2024-03-31&tz=Asia/Seoul&granularity=week",
+			from:        date(seoul, 2024, 3, 1),
+			to:          date(seoul, 2024, 4, 1),
+			granularity: granularityWeek,
+		},
+		{
+			name:        "RFC3339 시각은 그대로",
+			query:       "from=2024-01-01T00:00:00Z&to=2024-06-15T12:00:00Z&granularity=year",
+			from:        date(time.UTC, 2024, 1, 1),
+			to:          time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC),
+			granularity: granularityYear,
+		},
+		{
+			name:        "기본 시작은 1년 전 구간 시작",
+			query:       "to=2024-06-14",
+			from:        date(time.UTC, 2023, 6, 1),
+			to:          date(time.UTC, 2024, 6, 15),
+			granularity: granularityMonth,
+		},
+		{
+			name:        "year 단위 기본 시작은 5년 구간",
+			query:       "to=2024-06-14&granularity=year",
+			from:        date(time.UTC, 2020, 1, 1),
+			to:          date(time.UTC, 2024, 6, 15),
+			granularity: granularityYear,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := parseStatsQuery(statsContext(tt.query))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !q.From.Equal(tt.from) || !q.To.Equal(tt.to) {
+				t.Errorf("range = %v ~ %v, want %v ~ %v", q.From, q.To, tt.from, tt.to)
+			}
+			if q.Granularity != tt.granularity {
+				t.Errorf("granularity = %q, want %q", q.Granularity, tt.granularity)
+			}
+		})
+	}
+}
+
+func TestParseStatsQueryRejectsInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"granularity", "granularity=day", "granularity"},
+		{"시간대", "tz=Mars/Olympus", "시간대"},
+		{"Local 시간대", "tz=Local", "시간대"},
+		{"날짜 형식", "to=2024/12/31", "날짜 형식"},
+		{"from이 to 이후", "from=2024-05-01&to=2024-04-30", "from은 to보다"},
+		{"from과 to가 같음", "from=2024-05-01T00:00:00Z&to=2024-05-01T00:00:00Z", "from은 to보다"},
+		{"구간 수 초과", "from=2000-01-01&to=2030-12-31&granularity=week", "조회 구간이 너무 많습니다"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseStatsQuery(statsContext(tt.query))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFillPeriods(t *testing.T) {
+	q := &statsQuery{
+		From:        date(time.UTC, 2024, 1, 15),
+		To:          date(time.UTC, 2024, 4, 1),
+		Granularity: granularityMonth,
+		Location:    time.UTC,
+	}
+	var feb statsPeriod
+	feb.ID.Year, feb.ID.Month = 2024, 2
+	feb.RideCount, feb.Distance, feb.MovingTime = 2, 60, 2*time.Hour
+
+	periods := fillPeriods([]statsPeriod{feb}, q)
+	wantKeys := []string{"2024-01", "2024-02", "2024-03"}
+	if len(periods) != len(wantKeys) {
+		t.Fatalf("periods = %d, want %d", len(periods), len(wantKeys))
+	}
+	for i, p := range periods {
+		if p.Key != wantKeys[i] {
+			t.Errorf("periods[%d].Key = %q, want %q", i, p.Key, wantKeys[i])
+		}
+	}
+	if !periods[0].Start.Equal(date(time.UTC, 2024, 1, 1)) {
+		t.Errorf("첫 구간 시작 = %v, want 2024-01-01 (구간 시작으로 내림)", periods[0].Start)
+	}
+	if periods[0].RideCount != 0 || periods[2].RideCount != 0 {
+		t.Error("라이드가 없는 구간은 0이어야 함")
+	}
+	if p := periods[1]; p.RideCount != 2 || p.AvgDistance != 30 || p.AvgSpeed != 30 {
+		t.Errorf("2월 = count %d, avg distance %v, avg speed %v, want 2, 30, 30", p.RideCount, p.AvgDistance, p.AvgSpeed)
+	}
+}
+
+func TestFillPeriodsISOWeeks(t *testing.T) {
+	// 2024-12-30(월)은 ISO 기준 2025년 1주
+	q := &statsQuery{
+		From:        date(time.UTC, 2024, 12, 25),
+		To:          date(time.UTC, 2025, 1, 7),
+		Granularity: granularityWeek,
+		Location:    time.UTC,
+	}
+	var week1 statsPeriod
+	week1.ID.Year, week1.ID.Week = 2025, 1
+	week1.RideCount = 1
+
+	periods := fillPeriods([]statsPeriod{week1}, q)
+	wantKeys := []string{"2024-W52", "2025-W01", "2025-W02"}
+	if len(periods) != len(wantKeys) {
+		t.Fatalf("periods = %d, want %d", len(periods), len(wantKeys))
+	}
+	for i, p := range periods {
+		if p.Key != wantKeys[i] {
+			t.Errorf("periods[%d].Key = %q, want %q", i, p.Key, wantKeys[i])
+		}
+		if p.Start.Weekday() != time.Monday {
+			t.Errorf("periods[%d].Start = %v, want 월요일", i, p.Start)
+		}
+	}
+	if periods[1].RideCount != 1 {
+		t.Errorf("2025-W01 count = %d, want 1", periods[1].RideCount)
+	}
+}
+
+func TestComputeStreaks(t *testing.T) {
+	// 기준일 2024-03-20(수), 조회 종료는 날짜만 지정한 to=2024-03-20
+	q := &statsQuery{
+		From:        date(time.UTC, 2024, 1, 1),
+		To:          date(time.UTC, 2024, 3, 21),
+		Granularity: granularityMonth,
+		Location:    time.UTC,
+	}
+
+	tests := []struct {
+		name         string
+		days         []string
+		currentDays  int
+		longestDays  int
+		longestFrom  string
+		currentWeeks int
+		longestWeeks int
+	}{
+		{
+			name:         "기록 없음",
+			days:         nil,
+			currentDays:  0,
+			longestDays:  0,
+			currentWeeks: 0,
+			longestWeeks: 0,
+		},
+		{
+			name:         "오늘까지 연속",
+			days:         []string{"2024-03-01", "2024-03-02", "2024-03-03", "2024-03-04", "2024-03-18", "2024-03-19", "2024-03-20"},
+			currentDays:  3,
+			longestDays:  4,
+			longestFrom:  "2024-03-01",
+			currentWeeks: 1, // 3/11 주에는 주행 없음
+			longestWeeks: 2, // 2/26 주, 3/4 주
+		},
+		{
+			name:         "어제까지 연속이면 현재 연속으로 인정",
+			days:         []string{"2024-03-12", "2024-03-18", "2024-03-19"},
+			currentDays:  2,
+			longestDays:  2,
+			longestFrom:  "2024-03-18",
+			currentWeeks: 2,
+			longestWeeks: 2,
+		},
+		{
+			name:         "그저께에서 끊김",
+			days:         []string{"2024-03-17", "2024-03-18"},
+			currentDays:  0,
+			longestDays:  2,
+			longestFrom:  "2024-03-17",
+			currentWeeks: 2, // 3/11 주(일요일 3/17), 3/18 주
+			longestWeeks: 2,
+		},
+		{
+			name:         "지난주까지 연속 주",
+			days:         []string{"2024-02-28", "2024-03-06", "2024-03-13"},
+			currentDays:  0,
+			longestDays:  1,
+			longestFrom:  "2024-02-28",
+			currentWeeks: 3,
+			longestWeeks: 3,
+		},
+		{
+			name:         "잘못된 날짜는 무시",
+			days:         []string{"bad", "2024-03-20"},
+			currentDays:  1,
+			longestDays:  1,
+			longestFrom:  "2024-03-20",
+			currentWeeks: 1,
+			longestWeeks: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := computeStreaks(tt.days, q)
+			if s.CurrentDays != tt.currentDays || s.LongestDays != tt.longestDays {
+				t.Errorf("days = current %d, longest %d, want %d, %d", s.CurrentDays, s.LongestDays, tt.currentDays, tt.longestDays)
+			}
+			if s.CurrentWeeks != tt.currentWeeks || s.LongestWeeks != tt.longestWeeks {
+				t.Errorf("weeks = current %d, longest %d, want %d, %d", s.CurrentWeeks, s.LongestWeeks, tt.currentWeeks, tt.longestWeeks)
+			}
+			if tt.longestFrom != "" {
+				if s.LongestDaysFrom == nil || s.LongestDaysFrom.Format("2006-01-02") != tt.longestFrom {
+					t.Errorf("longest from = %v, want %s", s.LongestDaysFrom, tt.longestFrom)
+				}
+			}
+		})
+	}
+}
+
+func TestYearOverYearDateOnlyTo(t *testing.T) {
+	q, err := parseStatsQuery(statsContext("from=2024-01-01&to=2024-12-31"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	previous := []statsSummary{{RideCount: 4, Distance: 100}}
+	current := []statsSummary{{RideCount: 5, Distance: 150}}
+	yoy := yearOverYear(statsFacets{Current: current, Previous: previous}, q)
+	if yoy.Year != 2024 || yoy.PreviousYear != 2023 {
+		t.Errorf("years = %d/%d, want 2024/2023", yoy.Year, yoy.PreviousYear)
+	}
+	if want := q.To.Add(-time.Nanosecond); !yoy.Through.Equal(want) {
+		t.Errorf("through = %v, want %v", yoy.Through, want)
+	}
+	if c := yoy.Change["ride_count"]; c == nil || *c != 25 {
+		t.Errorf("ride_count change = %v, want 25", c)
+	}
+	if c := yoy.Change["calories"]; c != nil {
+		t.Errorf("calories change = %v, want nil (전년 값 0)", *c)
+	}
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/database"
+	"github.com/chrisS41/gobike-server/internal/elevation"
+	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/logger"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/chrisS41/gobike-server/internal/track"
+	"github.com/chrisS41/gobike-server/internal/trackstore"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 입력된 라이드 트랙을 정제하고(원본은 RawLocations에 보관) DEM 고도로 보정한 뒤
+// 표시용 단순화 트랙, 통계 필드, 경로 비교 결과를 채움
+func prepareRideTrack(cleaner *track.Cleaner, dem *elevation.Service, routes *database.Collection, log *logger.Log, ride *models.Ride) {
+	if result := cleaner.ApplyToRide(ride); result.Rejected > 0 {
+		log.Debug("Rejected %d of %d track points as outliers", result.Rejected, len(ride.RawLocations))
+	}
+	dem.Apply(ride.Locations)
+	ride.PointCount = len(ride.Locations)
+	ride.Simplified = cleaner.Simplify(ride.Locations)
+	analysis.ApplyToRide(ride)
+	compareRideRoute(routes, log, ride)
+}
+
+// 라이드 문서를 만들고 트랙을 트랙 묶음으로 저장 (ride.ID를 채움)
+// 트랙 저장에 실패하면 만든 문서를 지워 트랙 없는 라이드가 남지 않도록 함
+func insertRide(rides *database.Collection, tracks *trackstore.Store, log *logger.Log, ride *models.Ride) error {
+	id, err := rides.Create(ride)
+	if err != nil {
+		return err
+	}
+	ride.ID = id
+	if err := tracks.Save(ride); err != nil {
+		if err := rides.Delete(bson.M{"_id": id}); err != nil {
+			log.Error("Failed to remove ride %s without track: %v", id.Hex(), err)
+		}
+		tracks.Delete(id)
+		ride.ID = primitive.NilObjectID
+		return err
+	}
+	return nil
+}
+
+// 라이드의 전체 해상도 트랙을 묶음 단위로 스트리밍 (NDJSON, 한 줄에 models.TrackChunk 하나)
+// 쿼리: kind=clean(기본, 정제한 트랙과 센서 스트림)|raw(정제 전 입력 트랙)
+func (h *RideHandler) GetRideTrack(c *gin.Context) {
+	kind := c.DefaultQuery("kind", models.TrackKindClean)
+	if kind != models.TrackKindClean && kind != models.TrackKindRaw {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidFormat, "kind는 clean 또는 raw여야 합니다"),
+		)
+		return
+	}
+
+	ride, ok := h.loadOwnedRide(c)
+	if !ok {
+		return
+	}
+
+	// 첫 묶음을 쓰기 전에 실패하면 오류 응답, 그 뒤에는 연결을 끊어 불완전한 응답임을 알림
+	started := false
+	enc := json.NewEncoder(c.Writer)
+	err := h.tracks.Each(ride.ID, kind, func(chunk *models.TrackChunk) error {
+		if !started {
+			c.Header("Content-Type", "application/x-ndjson")
+			c.Status(http.StatusOK)
+			started = true
+		}
+		if err := enc.Encode(chunk); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	})
+	if err != nil {
+		h.log.Error("Failed to stream track of ride %s: %v", ride.ID.Hex(), err)
+		if !started {
+			c.JSON(
+				http.StatusInternalServerError,
+				models.NewErrorResponse(errors.ErrFailedToFetchRides),
+			)
+		} else {
+			c.Abort()
+		}
+		return
+	}
+	if !started {
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Status(http.StatusOK)
+		c.Writer.WriteHeaderNow()
+	}
+}
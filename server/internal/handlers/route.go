@@ -1,13 +1,19 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/chrisS41/gobike-server/internal/analysis"
 	"github.com/chrisS41/gobike-server/internal/database"
+	"github.com/chrisS41/gobike-server/internal/elevation"
 	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/gpx"
 	"github.com/chrisS41/gobike-server/internal/logger"
+	"github.com/chrisS41/gobike-server/internal/middleware"
 	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/chrisS41/gobike-server/internal/routing"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
@@ -16,11 +22,15 @@ import (
 
 type RouteHandler struct {
 	routes *database.Collection
+	rides  *database.Collection
+	users  *database.Collection
+	dem    *elevation.Service
+	roads  *routing.Graph // 경로 탐색용 도로망 (없으면 경로 탐색 불가)
 	log    *logger.Log
 }
 
-func NewRouteHandler(routes *database.Collection, log *logger.Log) *RouteHandler {
-	return &RouteHandler{routes: routes, log: log}
+func NewRouteHandler(routes, rides, users *database.Collection, dem *elevation.Service, roads *routing.Graph, log *logger.Log) *RouteHandler {
+	return &RouteHandler{routes: routes, rides: rides, users: users, dem: dem, roads: roads, log: log}
 }
 
 func (h *RouteHandler) CreateRoute(c *gin.Context) {
@@ -33,6 +43,18 @@ func (h *RouteHandler) CreateRoute(c *gin.Context) {
 		return
 	}
 
+	if code, err := prepareRoute(&route, h.dem); code != 0 {
+		resp := models.NewErrorResponse(code)
+		if err != nil {
+			resp = models.NewErrorResponseWithMessage(code, err.Error())
+		}
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	route.ID = primitive.NilObjectID
+	route.UserID = middleware.UserID(c)
+	route.RideCount = 0
 	route.CreatedAt = time.Now()
 	route.UpdatedAt = time.Now()
 
@@ -49,12 +71,44 @@ func (h *RouteHandler) CreateRoute(c *gin.Context) {
 	c.JSON(http.StatusCreated, route)
 }
 
+// 사용자의 경로 목록 조회 (최신순, page/limit 쿼리 지원)
+// 본인 목록이면 모든 경로, 다른 사용자의 목록이면 호출자가 볼 수 있는 경로만 반환
+// geometry=full|simplified|polyline|none으로 경로 선 형식 선택 (기본 full은 GPX, polyline은 precision=5|6)
 func (h *RouteHandler) GetUserRoutes(c *gin.Context) {
-	userID, _ := primitive.ObjectIDFromHex(c.Param("userId"))
+	ownerID, err := primitive.ObjectIDFromHex(c.Param("userId"))
+	if err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(
+				errors.ErrMissingParams,
+				fmt.Sprintf("잘못된 사용자 ID입니다: %s", c.Param("userId")),
+			),
+		)
+		return
+	}
+
+	filter := bson.M{"user_id": ownerID}
+	if viewerID := middleware.UserID(c); viewerID != ownerID {
+		visible := []string{models.RouteVisibilityPublic}
+		if isFriendOf(h.users, ownerID, viewerID) {
+			visible = append(visible, models.RouteVisibilityFriends)
+		}
+		filter["visibility"] = bson.M{"$in": visible}
+	}
+
+	geometry, ok := parseGeometry(c, geometryFull)
+	if !ok {
+		return
+	}
 
-	cursor, err := h.routes.ReadMany(bson.M{
-		"user_id": userID,
-	})
+	page, limit := parsePage(c)
+	docs, err := h.routes.ReadManyPaged(
+		filter,
+		bson.D{{Key: "created_at", Value: -1}},
+		(page-1)*limit,
+		limit,
+		geometry.routeProjection(),
+	)
 	if err != nil {
 		c.JSON(
 			http.StatusInternalServerError,
@@ -63,49 +117,282 @@ func (h *RouteHandler) GetUserRoutes(c *gin.Context) {
 		return
 	}
 
-	var routes []models.Route
-	for _, doc := range cursor {
+	routes := make([]models.Route, 0, len(docs))
+	for _, doc := range docs {
 		var route models.Route
 		bsonBytes, _ := bson.Marshal(doc)
 		bson.Unmarshal(bsonBytes, &route)
+		geometry.applyToRoute(&route)
 		routes = append(routes, route)
 	}
 
 	c.JSON(http.StatusOK, routes)
 }
 
+// 특정 경로 조회 (공개 범위 안의 사용자만, geometry 쿼리는 GetUserRoutes와 같음)
 func (h *RouteHandler) GetRoute(c *gin.Context) {
-	route, err := parseParams[models.Route](c, "id")
+	geometry, ok := parseGeometry(c, geometryFull)
+	if !ok {
+		return
+	}
+	route, ok := h.loadVisibleRoute(c)
+	if !ok {
+		return
+	}
+	geometry.applyToRoute(route)
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(route))
+}
+
+// 경로 수정 (만든 사용자만)
+// GPX 데이터가 있으면 거리, 상승 고도, 시작/종료 지점을 다시 계산
+func (h *RouteHandler) UpdateRoute(c *gin.Context) {
+	existing, ok := h.loadOwnedRoute(c)
+	if !ok {
+		return
+	}
+
+	var route models.Route
+	if err := c.ShouldBindJSON(&route); err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidRoute, err.Error()),
+		)
+		return
+	}
+
+	if code, err := prepareRoute(&route, h.dem); code != 0 {
+		resp := models.NewErrorResponse(code)
+		if err != nil {
+			resp = models.NewErrorResponseWithMessage(code, err.Error())
+		}
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	// ID, 소유자, 라이드 수, 생성 시간은 변경 불가
+	route.ID = primitive.NilObjectID
+	route.UserID = existing.UserID
+	route.RideCount = existing.RideCount
+	route.CreatedAt = existing.CreatedAt
+	route.UpdatedAt = time.Now()
+
+	// omitempty로 $set에서 빠지는 공간 필드는 명시적으로 제거
+	update := bson.M{"$set": route}
+	unset := bson.M{}
+	if route.StartLocation == nil {
+		unset["start_location"] = ""
+	}
+	if route.Geometry == nil {
+		unset["geometry"] = ""
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	if err := h.routes.Update(bson.M{"_id": existing.ID}, update); err != nil {
+		h.log.Error("Failed to update route %s: %v", existing.ID.Hex(), err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToUpdateRoute),
+		)
+		return
+	}
+
+	// 경로 트랙이 바뀌면 이 경로를 따라 달린 라이드의 비교 결과는 다음 조회 때 다시 계산
+	if route.GPXData != existing.GPXData {
+		if _, err := h.rides.UpdateMany(
+			bson.M{"route_id": existing.ID},
+			bson.M{"$unset": bson.M{"route_comparison": ""}},
+		); err != nil {
+			h.log.Error("Failed to reset route comparisons of route %s: %v", existing.ID.Hex(), err)
+		}
+	}
+
+	route.ID = existing.ID
+	c.JSON(http.StatusOK, models.NewSuccessResponse(route))
+}
+
+// 경로 삭제 (만든 사용자만)
+// 이 경로를 따라 달린 라이드는 남기고 경로 연결만 해제
+func (h *RouteHandler) DeleteRoute(c *gin.Context) {
+	route, ok := h.loadOwnedRoute(c)
+	if !ok {
+		return
+	}
+
+	if err := h.routes.Delete(bson.M{"_id": route.ID}); err != nil {
+		h.log.Error("Failed to delete route %s: %v", route.ID.Hex(), err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToDeleteRoute),
+		)
+		return
+	}
+
+	if _, err := h.rides.UpdateMany(
+		bson.M{"route_id": route.ID},
+		bson.M{"$unset": bson.M{"route_id": "", "route_comparison": ""}},
+	); err != nil {
+		h.log.Error("Failed to unlink rides from route %s: %v", route.ID.Hex(), err)
+	}
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(gin.H{"id": route.ID}))
+}
+
+// :id 경로 파라미터의 경로 조회
+func (h *RouteHandler) loadRoute(c *gin.Context) (*models.Route, bool) {
+	routeID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
 		c.JSON(
 			http.StatusBadRequest,
-			models.NewErrorResponseWithMessage(
-				errors.ErrMissingParams,
-				err.Error(),
-			),
+			models.NewErrorResponseWithMessage(errors.ErrMissingParams, "잘못된 경로 ID입니다"),
 		)
+		return nil, false
+	}
+
+	var route models.Route
+	if err := h.routes.ReadOne(bson.M{"_id": routeID}, &route); err != nil {
+		c.JSON(
+			http.StatusNotFound,
+			models.NewErrorResponse(errors.ErrRouteNotFound),
+		)
+		return nil, false
+	}
+	return &route, true
+}
+
+// :id 경로를 조회하고 호출자가 볼 수 있는지 확인
+func (h *RouteHandler) loadVisibleRoute(c *gin.Context) (*models.Route, bool) {
+	route, ok := h.loadRoute(c)
+	if !ok {
+		return nil, false
+	}
+
+	if !canViewRoute(h.users, route, middleware.UserID(c)) {
+		c.JSON(
+			http.StatusForbidden,
+			models.NewErrorResponse(errors.ErrUnauthorized),
+		)
+		return nil, false
+	}
+	return route, true
+}
+
+// :id 경로를 조회하고 호출자 소유인지 확인
+func (h *RouteHandler) loadOwnedRoute(c *gin.Context) (*models.Route, bool) {
+	route, ok := h.loadRoute(c)
+	if !ok {
+		return nil, false
+	}
+
+	if route.UserID != middleware.UserID(c) {
+		c.JSON(
+			http.StatusForbidden,
+			models.NewErrorResponse(errors.ErrUnauthorized),
+		)
+		return nil, false
+	}
+	return route, true
+}
+
+// 경로 입력값 검증 및 GPX 기반 값 계산, 문제가 없으면 0 반환
+// GPX 해석에 실패하면 원인 오류도 함께 반환
+// 공개 범위를 지정하지 않으면 본인만 볼 수 있음
+// DEM이 설정되어 있으면 GPX 트랙의 고도를 DEM 값으로 채우거나 교정한 뒤 계산
+func prepareRoute(route *models.Route, dem *elevation.Service) (int, error) {
+	if route.Visibility == "" {
+		route.Visibility = models.RouteVisibilityPrivate
+	}
+	if !models.IsValidRouteVisibility(route.Visibility) {
+		return errors.ErrInvalidRouteVisibility, nil
+	}
+
+	// 난이도는 정의된 값만 허용 (이전에 쓰던 한국어 값은 변환)
+	if route.Difficulty != "" {
+		difficulty, ok := models.NormalizeDifficulty(route.Difficulty)
+		if !ok {
+			return errors.ErrInvalidDifficulty, nil
+		}
+		route.Difficulty = difficulty
+	}
+
+	// 경사 특성은 트랙에서만 계산할 수 있음
+	route.AvgGrade, route.MaxGrade, route.Climbs = 0, 0, nil
+	if route.Distance > 0 {
+		route.Difficulty = analysis.RateDifficulty(route.Distance, route.ElevationGain, analysis.Profile{})
+	}
+
+	// GPX 데이터가 있으면 거리, 상승 고도, 시작/종료 지점, 경로 선, 경사 특성과 난이도를 자동 계산
+	route.Geometry = nil
+	if route.GPXData != "" {
+		g, err := gpx.ParseString(route.GPXData)
+		if err == nil {
+			points := g.GeoPoints()
+			dem.Apply(points)
+			err = gpx.FillRoute(route, g, points)
+		}
+		if err != nil {
+			return errors.ErrInvalidGPX, err
+		}
+	}
+
+	// 시작 지점이 지정된 경로만 주변 검색 대상
+	route.StartLocation = nil
+	if route.StartPoint.Latitude != 0 || route.StartPoint.Longitude != 0 {
+		if route.StartPoint.Latitude < -90 || route.StartPoint.Latitude > 90 ||
+			route.StartPoint.Longitude < -180 || route.StartPoint.Longitude > 180 {
+			return errors.ErrInvalidRoute, nil
+		}
+		start := models.NewGeoJSONPoint(route.StartPoint)
+		route.StartLocation = &start
+	}
+	return 0, nil
+}
+
+// 경로의 라이드 수를 delta만큼 변경 (경로가 연결되지 않은 라이드이면 무시)
+// 라이드 요청은 실패시키지 않고 로그만 남김
+func countRouteRide(routes *database.Collection, log *logger.Log, routeID primitive.ObjectID, delta int64) {
+	if routeID.IsZero() {
 		return
 	}
+	if err := routes.Update(bson.M{"_id": routeID}, bson.M{"$inc": bson.M{"ride_count": delta}}); err != nil {
+		log.Error("Failed to update ride count of route %s: %v", routeID.Hex(), err)
+	}
+}
 
-	// TODO: 라우트 조회 로직 구현
-	c.JSON(
-		http.StatusOK,
-		models.NewSuccessResponse(route.ID),
-	)
+// MigrateRouteDifficulty 난이도가 자유 입력이던 때의 값(쉬움/보통/어려움)으로 저장된 경로를
+// 정의된 난이도 값으로 바꾸고 바꾼 경로 수를 반환 (여러 번 실행해도 안전함)
+func MigrateRouteDifficulty(routes *database.Collection) (int64, error) {
+	var total int64
+	for legacy, difficulty := range models.LegacyDifficulties {
+		n, err := routes.UpdateMany(
+			bson.M{"difficulty": legacy},
+			bson.M{"$set": bson.M{"difficulty": difficulty}},
+		)
+		if err != nil {
+			return total, fmt.Errorf("migrate route difficulty %q: %w", legacy, err)
+		}
+		total += n
+	}
+	return total, nil
 }
 
-func (h *RouteHandler) UpdateRoute(c *gin.Context) {
-	// TODO: 라우트 업데이트 로직 구현
-	c.JSON(
-		http.StatusOK,
-		models.NewSuccessResponse("route updated"),
-	)
+// viewerID 사용자가 경로를 볼 수 있는지 확인
+// 소유자는 항상, 공개 경로는 모두, 친구 공개 경로는 소유자의 친구만 볼 수 있음
+// 공개 범위가 도입되기 전에 만들어진 경로(공개 범위 없음)는 공개로 취급
+func canViewRoute(users *database.Collection, route *models.Route, viewerID primitive.ObjectID) bool {
+	switch route.Visibility {
+	case models.RouteVisibilityPublic, "":
+		return true
+	case models.RouteVisibilityFriends:
+		return route.UserID == viewerID || isFriendOf(users, route.UserID, viewerID)
+	}
+	return route.UserID == viewerID
 }
 
-func (h *RouteHandler) DeleteRoute(c *gin.Context) {
-	// TODO: 라우트 삭제 로직 구현
-	c.JSON(
-		http.StatusOK,
-		models.NewSuccessResponse("route deleted"),
-	)
+// viewerID가 ownerID 사용자의 친구 목록에 있는지 확인
+func isFriendOf(users *database.Collection, ownerID, viewerID primitive.ObjectID) bool {
+	count, err := users.Count(bson.M{"_id": ownerID, "friends": viewerID})
+	return err == nil && count > 0
 }
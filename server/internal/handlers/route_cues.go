@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/export"
+	"github.com/chrisS41/gobike-server/internal/gpx"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// 이름 없는 GPX 웨이포인트의 안내 문구
+const defaultPOIName = "관심 지점"
+
+// 경로의 회전 안내 큐시트 (공개 범위 안의 사용자만)
+// 쿼리: format=json|html (기본값 json, html은 인쇄용 표)
+// 도로망이 있으면 도로 이름을, DEM이 있으면 DEM 고도로 찾은 오르막을 반영하며 GPX 웨이포인트는 관심 지점으로 안내
+func (h *RouteHandler) GetRouteCues(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "html" {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidFormat, "format은 json 또는 html이어야 합니다"),
+		)
+		return
+	}
+
+	route, ok := h.loadVisibleRoute(c)
+	if !ok {
+		return
+	}
+
+	if route.GPXData == "" {
+		c.JSON(
+			http.StatusNotFound,
+			models.NewErrorResponse(errors.ErrNoRouteTrack),
+		)
+		return
+	}
+	g, err := gpx.ParseString(route.GPXData)
+	if err != nil {
+		h.log.Error("Failed to parse gpx of route %s: %v", route.ID.Hex(), err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidGPX, err.Error()),
+		)
+		return
+	}
+
+	sheet := &models.CueSheet{
+		RouteID:  route.ID.Hex(),
+		Name:     route.Name,
+		Distance: route.Distance,
+		Cues:     h.routeCues(g),
+	}
+	if len(sheet.Cues) == 0 {
+		c.JSON(
+			http.StatusNotFound,
+			models.NewErrorResponse(errors.ErrNoRouteTrack),
+		)
+		return
+	}
+
+	if format == "json" {
+		c.JSON(http.StatusOK, models.NewSuccessResponse(sheet))
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := export.WriteCueSheetHTML(&buf, sheet); err != nil {
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponseWithMessage(errors.ErrFailedToWrite, err.Error()),
+		)
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", buf.Bytes())
+}
+
+// GPX 트랙의 큐시트 안내 (안내의 인덱스는 g.GeoPoints() 기준)
+func (h *RouteHandler) routeCues(g *gpx.GPX) []models.Cue {
+	points := g.GeoPoints()
+	h.dem.Apply(points)
+
+	var pois []analysis.POI
+	for _, wp := range g.Waypoints {
+		name := wp.Name
+		if name == "" {
+			name = defaultPOIName
+		}
+		pois = append(pois, analysis.POI{Point: wp.GeoPoint(), Name: name, Note: wp.Description})
+	}
+
+	return analysis.CueSheet(points, h.roads.StreetNames(points), analysis.AnalyzeProfile(points).Climbs, pois)
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/chrisS41/gobike-server/internal/compare"
+	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/gpx"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// 주행 중 경로 이탈 확인 요청
+type routeDeviationRequest struct {
+	Latitude  *float64 `json:"latitude" binding:"required"`
+	Longitude *float64 `json:"longitude" binding:"required"`
+	Progress  *float64 `json:"progress"` // 직전 응답의 route_distance (킬로미터, 선택)
+}
+
+// 주행 중 현재 위치의 경로 이탈 확인 (공개 범위 안의 사용자만)
+// 내비게이션 중 앱이 위치를 받을 때마다 호출하며, 직전 응답의 route_distance를 progress로 보내면
+// 같은 길을 여러 번 지나는 경로에서도 진행 위치를 이어감
+func (h *RouteHandler) CheckDeviation(c *gin.Context) {
+	var req routeDeviationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrMissingParams, err.Error()),
+		)
+		return
+	}
+	position := models.GeoPoint{Latitude: *req.Latitude, Longitude: *req.Longitude}
+	if position.Latitude < -90 || position.Latitude > 90 || position.Longitude < -180 || position.Longitude > 180 {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrMissingParams, "현재 위치의 좌표가 올바르지 않습니다"),
+		)
+		return
+	}
+
+	route, ok := h.loadVisibleRoute(c)
+	if !ok {
+		return
+	}
+
+	if route.GPXData == "" {
+		c.JSON(
+			http.StatusNotFound,
+			models.NewErrorResponse(errors.ErrNoRouteTrack),
+		)
+		return
+	}
+	g, err := gpx.ParseString(route.GPXData)
+	if err != nil {
+		h.log.Error("Failed to parse gpx of route %s: %v", route.ID.Hex(), err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidGPX, err.Error()),
+		)
+		return
+	}
+
+	progress := -1.0
+	if req.Progress != nil {
+		progress = *req.Progress * 1000
+	}
+	deviation := compare.Locate(g.GeoPoints(), position, progress)
+	if deviation == nil {
+		c.JSON(
+			http.StatusNotFound,
+			models.NewErrorResponse(errors.ErrNoRouteTrack),
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(deviation))
+}
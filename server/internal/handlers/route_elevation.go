@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/gpx"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// 고도 그래프의 기본/최대 지점 수
+const (
+	defaultProfileSamples = 500
+	maxProfileSamples     = 2000
+)
+
+// 경로의 거리-고도 그래프 (공개 범위 안의 사용자만)
+// 쿼리: samples (그래프 지점 수, 기본값 500)
+// DEM이 경로 전체를 덮으면 DEM 고도를, 아니면 GPX 고도(빈 지점은 DEM으로 채움)를 사용
+func (h *RouteHandler) GetRouteElevation(c *gin.Context) {
+	samples := defaultProfileSamples
+	if v := c.Query("samples"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 2 {
+			c.JSON(
+				http.StatusBadRequest,
+				models.NewErrorResponseWithMessage(errors.ErrMissingParams, "잘못된 samples입니다: "+v),
+			)
+			return
+		}
+		samples = min(n, maxProfileSamples)
+	}
+
+	route, ok := h.loadVisibleRoute(c)
+	if !ok {
+		return
+	}
+
+	if route.GPXData == "" {
+		c.JSON(
+			http.StatusNotFound,
+			models.NewErrorResponse(errors.ErrNoElevationData),
+		)
+		return
+	}
+	g, err := gpx.ParseString(route.GPXData)
+	if err != nil {
+		h.log.Error("Failed to parse gpx of route %s: %v", route.ID.Hex(), err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidGPX, err.Error()),
+		)
+		return
+	}
+
+	points := g.GeoPoints()
+	source := models.ElevationSourceDevice
+	if h.dem.Apply(points) {
+		source = models.ElevationSourceDEM
+	}
+
+	profile, ok := analysis.ElevationProfile(points, samples)
+	if !ok {
+		c.JSON(
+			http.StatusNotFound,
+			models.NewErrorResponse(errors.ErrNoElevationData),
+		)
+		return
+	}
+	profile.Source = source
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(profile))
+}
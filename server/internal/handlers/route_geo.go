@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/chrisS41/gobike-server/internal/database"
+	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/middleware"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// 주변 경로 검색 기본/최대 반경 (킬로미터)
+	defaultRouteRadius = 10.0
+	maxRouteRadius     = 100.0
+	// 영역 검색에서 허용하는 경도 폭의 상한 (도, 미만만 허용)
+	// 2dsphere는 다각형 변을 대권으로 해석하므로 너무 넓은 영역은 의도와 달라짐
+	maxBBoxSpan = 180.0
+)
+
+// 시작 지점이 lat/lon에서 radius(km) 안에 있는 경로 (가까운 순, page/limit 쿼리 지원)
+// 공간 검색은 모두 routeAttributeFilter의 속성 필터와 geometry 쿼리(GetUserRoutes와 같음)를 함께 사용할 수 있음
+func (h *RouteHandler) NearbyRoutes(c *gin.Context) {
+	lat, errLat := strconv.ParseFloat(c.Query("lat"), 64)
+	lon, errLon := strconv.ParseFloat(c.Query("lon"), 64)
+	if errLat != nil || errLon != nil || lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrMissingParams, "lat/lon이 올바르지 않습니다"),
+		)
+		return
+	}
+
+	radius := defaultRouteRadius
+	if v := c.Query("radius"); v != "" {
+		r, err := strconv.ParseFloat(v, 64)
+		if err != nil || r <= 0 {
+			c.JSON(
+				http.StatusBadRequest,
+				models.NewErrorResponseWithMessage(errors.ErrMissingParams, "잘못된 반경입니다: "+v),
+			)
+			return
+		}
+		radius = math.Min(r, maxRouteRadius)
+	}
+
+	// $nearSphere는 거리순으로 정렬된 결과를 반환하므로 별도 정렬을 지정하지 않음
+	h.findRoutes(c, bson.M{"start_location": bson.M{"$nearSphere": bson.M{
+		"$geometry":    models.NewGeoJSONPoint(models.GeoPoint{Latitude: lat, Longitude: lon}),
+		"$maxDistance": radius * 1000,
+	}}}, nil)
+}
+
+// 시작 지점이 bbox(minLon,minLat,maxLon,maxLat) 영역 안에 있는 경로 (지도 화면 영역 조회용)
+func (h *RouteHandler) RoutesWithin(c *gin.Context) {
+	box, ok := parseBBox(c)
+	if !ok {
+		return
+	}
+
+	h.findRoutes(c, bson.M{"start_location": bson.M{"$geoWithin": bson.M{"$geometry": box}}}, bson.D{{Key: "created_at", Value: -1}})
+}
+
+// 경로 선이 bbox(minLon,minLat,maxLon,maxLat) 영역을 지나는 경로
+func (h *RouteHandler) RoutesThrough(c *gin.Context) {
+	box, ok := parseBBox(c)
+	if !ok {
+		return
+	}
+
+	h.findRoutes(c, bson.M{"geometry": bson.M{"$geoIntersects": bson.M{"$geometry": box}}}, bson.D{{Key: "created_at", Value: -1}})
+}
+
+// 공간 조건에 속성 필터와 공개 범위 조건을 더해 경로를 조회하고 응답
+func (h *RouteHandler) findRoutes(c *gin.Context, geo bson.M, sort interface{}) {
+	attrs, ok := routeAttributeFilter(c)
+	if !ok {
+		return
+	}
+	geometry, ok := parseGeometry(c, geometryFull)
+	if !ok {
+		return
+	}
+
+	visible, err := visibleRoutesFilter(h.users, middleware.UserID(c))
+	if err != nil {
+		h.log.Error("Failed to resolve route visibility: %v", err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToFetchRoutes),
+		)
+		return
+	}
+
+	filter := bson.M{}
+	for k, v := range geo {
+		filter[k] = v
+	}
+	for k, v := range attrs {
+		filter[k] = v
+	}
+	for k, v := range visible {
+		filter[k] = v
+	}
+
+	page, limit := parsePage(c)
+	docs, err := h.routes.ReadManyPaged(filter, sort, (page-1)*limit, limit, geometry.routeProjection())
+	if err != nil {
+		h.log.Error("Failed to query routes: %v", err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToFetchRoutes),
+		)
+		return
+	}
+
+	routes := make([]models.Route, 0, len(docs))
+	for _, doc := range docs {
+		var route models.Route
+		bsonBytes, _ := bson.Marshal(doc)
+		bson.Unmarshal(bsonBytes, &route)
+		geometry.applyToRoute(&route)
+		routes = append(routes, route)
+	}
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(routes))
+}
+
+// 경로 속성 필터 쿼리 파라미터
+// difficulty: 난이도, min_distance/max_distance: 거리 범위 (km),
+// min_elevation/max_elevation: 상승 고도 범위 (m), tags: 쉼표로 구분한 태그 (모두 포함)
+func routeAttributeFilter(c *gin.Context) (bson.M, bool) {
+	filter := bson.M{}
+
+	if v := c.Query("difficulty"); v != "" {
+		difficulty, ok := models.NormalizeDifficulty(v)
+		if !ok {
+			c.JSON(
+				http.StatusBadRequest,
+				models.NewErrorResponseWithMessage(errors.ErrInvalidDifficulty, "알 수 없는 난이도입니다: "+v),
+			)
+			return nil, false
+		}
+		filter["difficulty"] = difficulty
+	}
+
+	ranges := []struct{ param, field, op string }{
+		{"min_distance", "distance", "$gte"},
+		{"max_distance", "distance", "$lte"},
+		{"min_elevation", "elevation_gain", "$gte"},
+		{"max_elevation", "elevation_gain", "$lte"},
+	}
+	for _, r := range ranges {
+		v := c.Query(r.param)
+		if v == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(v, 64)
+		if err != nil || value < 0 {
+			c.JSON(
+				http.StatusBadRequest,
+				models.NewErrorResponseWithMessage(errors.ErrMissingParams, fmt.Sprintf("잘못된 %s입니다: %s", r.param, v)),
+			)
+			return nil, false
+		}
+		cond, _ := filter[r.field].(bson.M)
+		if cond == nil {
+			cond = bson.M{}
+			filter[r.field] = cond
+		}
+		cond[r.op] = value
+	}
+
+	if v := c.Query("tags"); v != "" {
+		var tags []string
+		for _, tag := range strings.Split(v, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+		if len(tags) > 0 {
+			filter["tags"] = bson.M{"$all": tags}
+		}
+	}
+
+	return filter, true
+}
+
+// viewerID 사용자가 볼 수 있는 경로 조건 (canViewRoute와 같은 규칙)
+// 본인 경로, 공개 경로, 공개 범위가 없는 기존 경로, viewerID를 친구로 등록한 사용자의 친구 공개 경로
+func visibleRoutesFilter(users *database.Collection, viewerID primitive.ObjectID) (bson.M, error) {
+	docs, err := users.ReadMany(bson.M{"friends": viewerID})
+	if err != nil {
+		return nil, err
+	}
+	owners := make([]primitive.ObjectID, 0, len(docs))
+	for _, doc := range docs {
+		if id, ok := doc["_id"].(primitive.ObjectID); ok {
+			owners = append(owners, id)
+		}
+	}
+
+	return bson.M{"$or": bson.A{
+		bson.M{"user_id": viewerID},
+		bson.M{"visibility": models.RouteVisibilityPublic},
+		bson.M{"visibility": bson.M{"$exists": false}},
+		bson.M{"visibility": models.RouteVisibilityFriends, "user_id": bson.M{"$in": owners}},
+	}}, nil
+}
+
+// bbox 쿼리 파라미터(minLon,minLat,maxLon,maxLat)를 GeoJSON Polygon으로 변환
+// 날짜 변경선을 가로지르는 영역은 지원하지 않음
+func parseBBox(c *gin.Context) (models.GeoJSONPolygon, bool) {
+	parts := strings.Split(c.Query("bbox"), ",")
+	var v [4]float64
+	valid := len(parts) == 4
+	for i := 0; valid && i < 4; i++ {
+		var err error
+		v[i], err = strconv.ParseFloat(strings.TrimSpace(parts[i]), 64)
+		valid = err == nil
+	}
+
+	lo := models.GeoPoint{Longitude: v[0], Latitude: v[1]}
+	hi := models.GeoPoint{Longitude: v[2], Latitude: v[3]}
+	if !valid ||
+		lo.Longitude < -180 || hi.Longitude > 180 || lo.Latitude < -90 || hi.Latitude > 90 ||
+		lo.Longitude >= hi.Longitude || lo.Latitude >= hi.Latitude ||
+		hi.Longitude-lo.Longitude >= maxBBoxSpan {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrMissingParams, "bbox는 minLon,minLat,maxLon,maxLat 형식이어야 합니다"),
+		)
+		return models.GeoJSONPolygon{}, false
+	}
+	return models.NewGeoJSONBox(lo, hi), true
+}
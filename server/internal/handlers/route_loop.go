@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/chrisS41/gobike-server/internal/routing"
+	"github.com/gin-gonic/gin"
+)
+
+// 순환 경로 요청
+// 출발 지점으로 돌아오는 distance(킬로미터) 길이의 경로 후보를 탐색
+type routeLoopRequest struct {
+	Start       models.GeoPoint `json:"start" binding:"required"`
+	Distance    float64         `json:"distance" binding:"required"` // 킬로미터
+	Elevation   string          `json:"elevation"`                   // flat, hilly (비우면 기본)
+	Surface     string          `json:"surface"`                     // paved, unpaved (비우면 기본)
+	Count       int             `json:"count"`                       // 후보 수 (기본 3, 최대 5)
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Tags        []string        `json:"tags"`
+	Visibility  string          `json:"visibility"`
+}
+
+// 순환 경로 후보
+type routeLoop struct {
+	Route   models.Route `json:"route"`
+	Overlap float64      `json:"overlap"` // 같은 도로를 다시 지나는 거리의 비율 (0~1)
+	Bearing float64      `json:"bearing"` // 출발 지점에서 본 경로의 방위 (도)
+}
+
+// 출발 지점으로 돌아오는 순환 경로 후보 탐색
+// 같은 도로를 되도록 다시 지나지 않고 목표 거리에 가까운 순으로 여러 후보를 반환
+// (후보의 route는 그대로 /routes/create에 보내 저장할 수 있음)
+func (h *RouteHandler) PlanLoops(c *gin.Context) {
+	if h.roads == nil {
+		c.JSON(
+			http.StatusServiceUnavailable,
+			models.NewErrorResponse(errors.ErrRoutePlannerDisabled),
+		)
+		return
+	}
+
+	var req routeLoopRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidLoopOptions, err.Error()),
+		)
+		return
+	}
+	if req.Start.Latitude < -90 || req.Start.Latitude > 90 || req.Start.Longitude < -180 || req.Start.Longitude > 180 {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidLoopOptions, "출발 지점의 좌표가 올바르지 않습니다"),
+		)
+		return
+	}
+	if req.Count < 0 || req.Count > routing.MaxLoopCount {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidLoopOptions, fmt.Sprintf("후보 수는 1~%d개여야 합니다", routing.MaxLoopCount)),
+		)
+		return
+	}
+
+	loops, err := h.roads.Loops(routing.LoopOptions{
+		Start:     req.Start,
+		Distance:  req.Distance * 1000,
+		Elevation: req.Elevation,
+		Surface:   req.Surface,
+		Count:     req.Count,
+	})
+	if err != nil {
+		status, code, message := http.StatusBadRequest, errors.ErrInvalidLoopOptions, err.Error()
+		switch {
+		case stderrors.Is(err, routing.ErrInvalidPreference):
+			message = "경사 선호는 flat, hilly 중 하나, 노면 선호는 paved, unpaved 중 하나여야 합니다"
+		case stderrors.Is(err, routing.ErrLoopDistance):
+			message = fmt.Sprintf("거리는 %.0f~%.0fkm여야 합니다", routing.MinLoopDistance/1000, routing.MaxLoopDistance/1000)
+		case stderrors.Is(err, routing.ErrNoRoad):
+			message = "출발 지점 근처에 자전거가 다닐 수 있는 도로가 없습니다"
+		case stderrors.Is(err, routing.ErrNoLoop):
+			status, code = http.StatusNotFound, errors.ErrNoRouteFound
+			message = "출발 지점으로 돌아오는 경로를 찾을 수 없습니다"
+		}
+		c.JSON(status, models.NewErrorResponseWithMessage(code, message))
+		return
+	}
+
+	result := make([]routeLoop, 0, len(loops))
+	for _, loop := range loops {
+		route, code, err := h.routeFromPath(loop.Points, req.Name, req.Description, req.Tags, req.Visibility)
+		if code != 0 {
+			status := http.StatusBadRequest
+			if code == errors.ErrFailedToWrite {
+				status = http.StatusInternalServerError
+			}
+			resp := models.NewErrorResponse(code)
+			if err != nil {
+				resp = models.NewErrorResponseWithMessage(code, err.Error())
+			}
+			c.JSON(status, resp)
+			return
+		}
+		result = append(result, routeLoop{
+			Route:   route,
+			Overlap: math.Round(loop.Overlap*1000) / 1000,
+			Bearing: loop.Bearing,
+		})
+	}
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(result))
+}
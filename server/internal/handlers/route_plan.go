@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"bytes"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/export"
+	"github.com/chrisS41/gobike-server/internal/middleware"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/chrisS41/gobike-server/internal/routing"
+	"github.com/gin-gonic/gin"
+)
+
+// 경로 계획 요청
+// 경유지를 순서대로 지나는 자전거 경로를 탐색하고, save가 true이면 경로로 저장
+type routePlanRequest struct {
+	Waypoints   []models.GeoPoint `json:"waypoints" binding:"required"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Tags        []string          `json:"tags"`
+	Visibility  string            `json:"visibility"`
+	Save        bool              `json:"save"`
+}
+
+// 경유지를 잇는 자전거 경로 계획
+// 탐색한 경로를 GPX로 만들어 거리, 상승 고도, 경로 선, 난이도 등을 채운 경로를 반환
+// (저장하지 않은 경로는 그대로 /routes/create에 보내 저장할 수 있음)
+func (h *RouteHandler) PlanRoute(c *gin.Context) {
+	if h.roads == nil {
+		c.JSON(
+			http.StatusServiceUnavailable,
+			models.NewErrorResponse(errors.ErrRoutePlannerDisabled),
+		)
+		return
+	}
+
+	var req routePlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidWaypoints, err.Error()),
+		)
+		return
+	}
+	for i, wp := range req.Waypoints {
+		if wp.Latitude < -90 || wp.Latitude > 90 || wp.Longitude < -180 || wp.Longitude > 180 {
+			c.JSON(
+				http.StatusBadRequest,
+				models.NewErrorResponseWithMessage(errors.ErrInvalidWaypoints, fmt.Sprintf("경유지 %d의 좌표가 올바르지 않습니다", i)),
+			)
+			return
+		}
+	}
+
+	path, index, err := h.roads.Route(req.Waypoints)
+	if err != nil {
+		status, code, message := http.StatusBadRequest, errors.ErrInvalidWaypoints, err.Error()
+		switch {
+		case stderrors.Is(err, routing.ErrTooFewWaypoints):
+			message = "경유지는 출발지와 도착지를 포함해 2개 이상이어야 합니다"
+		case stderrors.Is(err, routing.ErrTooManyWaypoints):
+			message = fmt.Sprintf("경유지는 최대 %d개까지 지정할 수 있습니다", routing.MaxWaypoints)
+		case stderrors.Is(err, routing.ErrTooFar):
+			message = fmt.Sprintf("경유지 사이 거리의 합은 %.0fkm 이하여야 합니다", routing.MaxPlanDistance/1000)
+		case stderrors.Is(err, routing.ErrNoRoad):
+			message = fmt.Sprintf("경유지 %d 근처에 자전거가 다닐 수 있는 도로가 없습니다", index)
+		case stderrors.Is(err, routing.ErrNoPath):
+			status, code = http.StatusNotFound, errors.ErrNoRouteFound
+			message = fmt.Sprintf("경유지 %d까지 이어지는 경로가 없습니다", index)
+		}
+		c.JSON(status, models.NewErrorResponseWithMessage(code, message))
+		return
+	}
+
+	route, code, err := h.routeFromPath(path.Points, req.Name, req.Description, req.Tags, req.Visibility)
+	if code != 0 {
+		status := http.StatusBadRequest
+		if code == errors.ErrFailedToWrite {
+			status = http.StatusInternalServerError
+		}
+		resp := models.NewErrorResponse(code)
+		if err != nil {
+			resp = models.NewErrorResponseWithMessage(code, err.Error())
+		}
+		c.JSON(status, resp)
+		return
+	}
+
+	if !req.Save {
+		c.JSON(http.StatusOK, models.NewSuccessResponse(route))
+		return
+	}
+
+	route.UserID = middleware.UserID(c)
+	route.CreatedAt = time.Now()
+	route.UpdatedAt = route.CreatedAt
+	route.ID, err = h.routes.Create(route)
+	if err != nil {
+		h.log.Error("Failed to save planned route: %v", err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToCreateRoute),
+		)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.NewSuccessResponse(route))
+}
+
+// 탐색한 경로의 지점으로 GPX를 만들고 거리, 상승 고도, 경로 선, 난이도 등을 채운 경로
+// 실패하면 오류 코드 (GPX 작성 실패는 ErrFailedToWrite)
+func (h *RouteHandler) routeFromPath(points []models.GeoPoint, name, description string, tags []string, visibility string) (models.Route, int, error) {
+	var buf bytes.Buffer
+	if err := export.Write(&buf, export.FormatGPX, &export.Track{
+		Name:        name,
+		Description: description,
+		Tags:        tags,
+		Points:      points,
+	}); err != nil {
+		h.log.Error("Failed to write planned route: %v", err)
+		return models.Route{}, errors.ErrFailedToWrite, err
+	}
+
+	route := models.Route{
+		Name:        name,
+		Description: description,
+		Tags:        tags,
+		Visibility:  visibility,
+		GPXData:     buf.String(),
+	}
+	if code, err := prepareRoute(&route, h.dem); code != 0 {
+		return models.Route{}, code, err
+	}
+	return route, 0, nil
+}
@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/middleware"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 경로 검색 정렬 기준
+const (
+	routeSortRelevance  = "relevance"  // 검색어 일치도 (q가 있을 때만)
+	routeSortDistance   = "distance"   // 짧은 거리순
+	routeSortPopularity = "popularity" // 라이드 수가 많은 순
+	routeSortNewest     = "newest"     // 최신순
+)
+
+// 패싯으로 반환하는 태그의 최대 개수 (많은 순)
+const maxTagFacets = 50
+
+// 경로 검색 응답
+type routeSearchResult struct {
+	Routes     []models.Route    `json:"routes"`
+	Total      int64             `json:"total"`                 // 필터에 맞는 전체 경로 수
+	NextCursor string            `json:"next_cursor,omitempty"` // 다음 페이지 커서 (마지막 페이지이면 생략)
+	Facets     routeSearchFacets `json:"facets"`
+}
+
+// 필터 UI용 패싯 (필터에 맞는 경로 기준 개수)
+type routeSearchFacets struct {
+	Tags       []facetCount `json:"tags"`
+	Difficulty []facetCount `json:"difficulty"`
+}
+
+type facetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// 페이지 커서: 마지막으로 받은 경로의 정렬 값과 ID
+// 정렬 기준이 바뀌면 이어서 조회할 수 없으므로 정렬 기준도 함께 저장
+type routeCursor struct {
+	Sort  string  `json:"s"`
+	Value float64 `json:"v"`
+	ID    string  `json:"id"`
+}
+
+// 경로 검색
+// q: 이름/설명 전문 검색, sort: relevance|distance|popularity|newest (q가 있으면 relevance, 없으면 newest가 기본),
+// cursor: 이전 응답의 next_cursor, limit: 페이지 크기, geometry: 경로 선 형식 (GetUserRoutes와 같지만 기본 none)
+// routeAttributeFilter의 속성 필터를 함께 사용할 수 있으며, 호출자가 볼 수 있는 경로만 검색
+func (h *RouteHandler) SearchRoutes(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+
+	sortBy := c.Query("sort")
+	if sortBy == "" {
+		sortBy = routeSortNewest
+		if query != "" {
+			sortBy = routeSortRelevance
+		}
+	}
+
+	// 정렬 값으로 사용할 식과 방향 (newest는 생성 시각이 담긴 _id만으로 정렬)
+	var sortExpr interface{}
+	direction := -1
+	switch sortBy {
+	case routeSortRelevance:
+		if query == "" {
+			c.JSON(
+				http.StatusBadRequest,
+				models.NewErrorResponseWithMessage(errors.ErrInvalidSearchQuery, "relevance 정렬에는 검색어가 필요합니다"),
+			)
+			return
+		}
+		sortExpr = bson.M{"$meta": "textScore"}
+	case routeSortDistance:
+		sortExpr = bson.M{"$ifNull": bson.A{"$distance", 0}}
+		direction = 1
+	case routeSortPopularity:
+		sortExpr = bson.M{"$ifNull": bson.A{"$ride_count", 0}}
+	case routeSortNewest:
+	default:
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidSearchQuery, "알 수 없는 정렬 기준입니다: "+sortBy),
+		)
+		return
+	}
+
+	attrs, ok := routeAttributeFilter(c)
+	if !ok {
+		return
+	}
+	geometry, ok := parseGeometry(c, geometryNone)
+	if !ok {
+		return
+	}
+	visible, err := visibleRoutesFilter(h.users, middleware.UserID(c))
+	if err != nil {
+		h.log.Error("Failed to resolve route visibility: %v", err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToFetchRoutes),
+		)
+		return
+	}
+
+	match := bson.M{}
+	if query != "" {
+		match["$text"] = bson.M{"$search": query}
+	}
+	for k, v := range attrs {
+		match[k] = v
+	}
+	for k, v := range visible {
+		match[k] = v
+	}
+
+	_, limit := parsePage(c)
+
+	// 결과 페이지: 커서 이후 항목을 정렬하여 limit+1개 조회 (다음 페이지 존재 여부 확인용)
+	results := bson.A{}
+	if v := c.Query("cursor"); v != "" {
+		cursor, err := decodeRouteCursor(v, sortBy)
+		if err != nil {
+			c.JSON(
+				http.StatusBadRequest,
+				models.NewErrorResponseWithMessage(errors.ErrInvalidSearchQuery, "잘못된 커서입니다"),
+			)
+			return
+		}
+		results = append(results, bson.D{{Key: "$match", Value: cursorCondition(cursor, sortExpr != nil, direction)}})
+	}
+	sort := bson.D{{Key: "_id", Value: direction}}
+	if sortExpr != nil {
+		sort = append(bson.D{{Key: "sort_value", Value: direction}}, sort...)
+	}
+	results = append(results,
+		bson.D{{Key: "$sort", Value: sort}},
+		bson.D{{Key: "$limit", Value: limit + 1}},
+	)
+	if fields := geometry.routeFields(); fields != nil {
+		results = append(results, bson.D{{Key: "$project", Value: fields}})
+	}
+
+	pipeline := bson.A{bson.D{{Key: "$match", Value: match}}}
+	if sortExpr != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.M{"sort_value": sortExpr}}})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$facet", Value: bson.M{
+		"results": results,
+		"total":   bson.A{bson.D{{Key: "$count", Value: "count"}}},
+		"tags": bson.A{
+			bson.D{{Key: "$unwind", Value: "$tags"}},
+			bson.D{{Key: "$group", Value: bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}}},
+			bson.D{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+			bson.D{{Key: "$limit", Value: maxTagFacets}},
+		},
+		"difficulty": bson.A{
+			bson.D{{Key: "$group", Value: bson.M{"_id": "$difficulty", "count": bson.M{"$sum": 1}}}},
+			bson.D{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+		},
+	}}})
+
+	docs, err := h.routes.Aggregate(pipeline)
+	if err != nil || len(docs) != 1 {
+		h.log.Error("Failed to search routes: %v", err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToFetchRoutes),
+		)
+		return
+	}
+
+	var facet struct {
+		Results []struct {
+			models.Route `bson:",inline"`
+			SortValue    float64 `bson:"sort_value"`
+		} `bson:"results"`
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+		Tags []struct {
+			Value string `bson:"_id"`
+			Count int64  `bson:"count"`
+		} `bson:"tags"`
+		Difficulty []struct {
+			Value string `bson:"_id"`
+			Count int64  `bson:"count"`
+		} `bson:"difficulty"`
+	}
+	bsonBytes, _ := bson.Marshal(docs[0])
+	if err := bson.Unmarshal(bsonBytes, &facet); err != nil {
+		h.log.Error("Failed to decode route search result: %v", err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToFetchRoutes),
+		)
+		return
+	}
+
+	result := routeSearchResult{
+		Routes: make([]models.Route, 0, len(facet.Results)),
+		Facets: routeSearchFacets{
+			Tags:       make([]facetCount, 0, len(facet.Tags)),
+			Difficulty: make([]facetCount, 0, len(facet.Difficulty)),
+		},
+	}
+	if len(facet.Total) > 0 {
+		result.Total = facet.Total[0].Count
+	}
+	for _, t := range facet.Tags {
+		result.Facets.Tags = append(result.Facets.Tags, facetCount{Value: t.Value, Count: t.Count})
+	}
+	for _, d := range facet.Difficulty {
+		result.Facets.Difficulty = append(result.Facets.Difficulty, facetCount{Value: d.Value, Count: d.Count})
+	}
+
+	for i, r := range facet.Results {
+		if int64(i) == limit {
+			last := facet.Results[i-1]
+			result.NextCursor = encodeRouteCursor(routeCursor{Sort: sortBy, Value: last.SortValue, ID: last.ID.Hex()})
+			break
+		}
+		geometry.applyToRoute(&r.Route)
+		result.Routes = append(result.Routes, r.Route)
+	}
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(result))
+}
+
+// 커서 이후 항목 조건: 정렬 값이 뒤에 오거나, 같으면 _id가 뒤에 오는 항목
+func cursorCondition(cursor routeCursor, hasValue bool, direction int) bson.M {
+	op := "$lt"
+	if direction > 0 {
+		op = "$gt"
+	}
+	id, _ := primitive.ObjectIDFromHex(cursor.ID)
+	if !hasValue {
+		return bson.M{"_id": bson.M{op: id}}
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{"sort_value": bson.M{op: cursor.Value}},
+		bson.M{"sort_value": cursor.Value, "_id": bson.M{op: id}},
+	}}
+}
+
+func encodeRouteCursor(cursor routeCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// 커서를 해석하고 현재 정렬 기준으로 만든 커서인지 확인
+func decodeRouteCursor(value, sortBy string) (routeCursor, error) {
+	var cursor routeCursor
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, err
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return cursor, err
+	}
+	if cursor.Sort != sortBy {
+		return cursor, fmt.Errorf("cursor was issued for sort %q", cursor.Sort)
+	}
+	if _, err := primitive.ObjectIDFromHex(cursor.ID); err != nil {
+		return cursor, err
+	}
+	return cursor, nil
+}
@@ -0,0 +1,592 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/database"
+	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/gpx"
+	"github.com/chrisS41/gobike-server/internal/logger"
+	"github.com/chrisS41/gobike-server/internal/middleware"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/chrisS41/gobike-server/internal/segment"
+	"github.com/chrisS41/gobike-server/internal/trackstore"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// 구간 최소 거리 (미터), 너무 짧은 구간은 GPS 오차가 기록을 좌우함
+	minSegmentDistance = 200.0
+	// 주변 구간 검색 기본/최대 반경 (킬로미터)
+	defaultSegmentRadius = 5.0
+	maxSegmentRadius     = 50.0
+)
+
+// 순위표 기간/범위
+const (
+	leaderboardPeriodAll    = "all"
+	leaderboardPeriodYear   = "year"
+	leaderboardScopeAll     = "all"
+	leaderboardScopeFriends = "friends"
+)
+
+type SegmentHandler struct {
+	segments *database.Collection
+	efforts  *database.Collection
+	rides    *database.Collection
+	routes   *database.Collection
+	users    *database.Collection
+	tracks   *trackstore.Store
+	log      *logger.Log
+}
+
+func NewSegmentHandler(segments, efforts, rides, routes, users *database.Collection, tracks *trackstore.Store, log *logger.Log) *SegmentHandler {
+	return &SegmentHandler{
+		segments: segments,
+		efforts:  efforts,
+		rides:    rides,
+		routes:   routes,
+		users:    users,
+		tracks:   tracks,
+		log:      log,
+	}
+}
+
+// 구간 생성 요청
+type createSegmentRequest struct {
+	Name        string               `json:"name" binding:"required"`
+	Description string               `json:"description"`
+	Source      models.SegmentSource `json:"source"`
+}
+
+// 구간 수정 요청 (이름과 설명만 변경 가능)
+type updateSegmentRequest struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+// 경로 또는 본인 라이드의 일부(source.start_index ~ source.end_index)를 구간으로 생성
+// 원본이 라이드이면 그 라이드의 구간 기록도 함께 만듦
+// (그 외 이미 업로드된 라이드는 다시 매칭하지 않고 이후 업로드부터 매칭됨)
+func (h *SegmentHandler) CreateSegment(c *gin.Context) {
+	var req createSegmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidSegment, err.Error()),
+		)
+		return
+	}
+
+	userID := middleware.UserID(c)
+	track, ride, code := h.loadSourceTrack(req.Source, userID)
+	if code != 0 {
+		status := http.StatusBadRequest
+		if code == errors.ErrSegmentSourceNotFound {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.NewErrorResponse(code))
+		return
+	}
+
+	src := req.Source
+	if src.StartIndex < 0 || src.EndIndex >= len(track) || src.StartIndex >= src.EndIndex {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidSegment, "구간 범위가 원본 지점 범위를 벗어났습니다"),
+		)
+		return
+	}
+
+	// 시각 정보는 원본 라이드에만 의미가 있으므로 제거
+	points := make([]models.GeoPoint, 0, src.EndIndex-src.StartIndex+1)
+	for _, p := range track[src.StartIndex : src.EndIndex+1] {
+		p.Timestamp = nil
+		points = append(points, p)
+	}
+
+	distance := analysis.TrackDistance(points)
+	if distance < minSegmentDistance {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidSegment, "구간은 200m 이상이어야 합니다"),
+		)
+		return
+	}
+
+	seg := models.Segment{
+		UserID:        userID,
+		Name:          req.Name,
+		Description:   req.Description,
+		Source:        src,
+		Points:        points,
+		Distance:      distance / 1000,
+		StartLocation: models.NewGeoJSONPoint(points[0]),
+		EndLocation:   models.NewGeoJSONPoint(points[len(points)-1]),
+		CreatedAt:     time.Now(),
+	}
+	seg.UpdatedAt = seg.CreatedAt
+	seg.ElevationGain, _ = analysis.ElevationChange(points)
+	first, last := points[0].Elevation, points[len(points)-1].Elevation
+	if first != nil && last != nil {
+		seg.AvgGrade = math.Round((*last-*first)/distance*1000) / 10
+	}
+
+	var err error
+	seg.ID, err = h.segments.Create(seg)
+	if err != nil {
+		h.log.Error("Failed to create segment: %v", err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToCreateSegment),
+		)
+		return
+	}
+
+	if ride != nil {
+		seg.EffortCount = int64(len(recordSegmentEfforts(h.efforts, h.log, ride, []models.Segment{seg})))
+	}
+
+	c.JSON(http.StatusCreated, models.NewSuccessResponse(seg))
+}
+
+// 구간 목록 조회
+// lat/lon이 있으면 시작 지점이 반경 radius(km) 안에 있는 구간, mine=true이면 본인이 만든 구간
+func (h *SegmentHandler) ListSegments(c *gin.Context) {
+	filter := bson.M{}
+	if c.Query("mine") == "true" {
+		filter["user_id"] = middleware.UserID(c)
+	}
+
+	if c.Query("lat") != "" || c.Query("lon") != "" {
+		lat, errLat := strconv.ParseFloat(c.Query("lat"), 64)
+		lon, errLon := strconv.ParseFloat(c.Query("lon"), 64)
+		if errLat != nil || errLon != nil || lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+			c.JSON(
+				http.StatusBadRequest,
+				models.NewErrorResponseWithMessage(errors.ErrMissingParams, "잘못된 위치입니다"),
+			)
+			return
+		}
+		radius := defaultSegmentRadius
+		if v, err := strconv.ParseFloat(c.Query("radius"), 64); err == nil && v > 0 {
+			radius = math.Min(v, maxSegmentRadius)
+		}
+		filter["start_location"] = bson.M{"$geoWithin": bson.M{
+			"$centerSphere": bson.A{bson.A{lon, lat}, radius * 1000 / analysis.EarthRadius},
+		}}
+	} else if len(filter) == 0 {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrMissingParams, "lat/lon 또는 mine=true가 필요합니다"),
+		)
+		return
+	}
+
+	page, limit := parsePage(c)
+	docs, err := h.segments.ReadManyPaged(filter, bson.D{{Key: "created_at", Value: -1}}, (page-1)*limit, limit)
+	if err != nil {
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToFetchSegments),
+		)
+		return
+	}
+
+	segments := make([]models.Segment, 0, len(docs))
+	for _, doc := range docs {
+		var seg models.Segment
+		bsonBytes, _ := bson.Marshal(doc)
+		bson.Unmarshal(bsonBytes, &seg)
+		segments = append(segments, seg)
+	}
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(segments))
+}
+
+// 구간 조회 (기록 수 포함)
+func (h *SegmentHandler) GetSegment(c *gin.Context) {
+	seg, ok := h.loadSegment(c)
+	if !ok {
+		return
+	}
+
+	seg.EffortCount, _ = h.efforts.Count(bson.M{"segment_id": seg.ID})
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(seg))
+}
+
+// 구간 이름/설명 수정 (만든 사용자만)
+// 구간 경로가 바뀌면 기존 기록과 비교할 수 없으므로 경로는 수정할 수 없음
+func (h *SegmentHandler) UpdateSegment(c *gin.Context) {
+	seg, ok := h.loadSegment(c)
+	if !ok {
+		return
+	}
+	if seg.UserID != middleware.UserID(c) {
+		c.JSON(
+			http.StatusForbidden,
+			models.NewErrorResponse(errors.ErrUnauthorized),
+		)
+		return
+	}
+
+	var req updateSegmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrInvalidSegment, err.Error()),
+		)
+		return
+	}
+
+	set := bson.M{"updated_at": time.Now()}
+	if req.Name != nil {
+		if *req.Name == "" {
+			c.JSON(
+				http.StatusBadRequest,
+				models.NewErrorResponseWithMessage(errors.ErrInvalidSegment, "구간 이름은 비워둘 수 없습니다"),
+			)
+			return
+		}
+		set["name"] = *req.Name
+		seg.Name = *req.Name
+	}
+	if req.Description != nil {
+		set["description"] = *req.Description
+		seg.Description = *req.Description
+	}
+
+	if err := h.segments.Update(bson.M{"_id": seg.ID}, bson.M{"$set": set}); err != nil {
+		h.log.Error("Failed to update segment %s: %v", seg.ID.Hex(), err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToUpdateSegment),
+		)
+		return
+	}
+	seg.UpdatedAt = set["updated_at"].(time.Time)
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(seg))
+}
+
+// 구간과 구간 기록 삭제 (만든 사용자 또는 moderator 이상)
+func (h *SegmentHandler) DeleteSegment(c *gin.Context) {
+	seg, ok := h.loadSegment(c)
+	if !ok {
+		return
+	}
+	role := middleware.UserRole(c)
+	if seg.UserID != middleware.UserID(c) && role != models.RoleModerator && role != models.RoleAdmin {
+		c.JSON(
+			http.StatusForbidden,
+			models.NewErrorResponse(errors.ErrUnauthorized),
+		)
+		return
+	}
+
+	if err := h.segments.Delete(bson.M{"_id": seg.ID}); err != nil {
+		h.log.Error("Failed to delete segment %s: %v", seg.ID.Hex(), err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToDeleteSegment),
+		)
+		return
+	}
+	if _, err := h.efforts.DeleteMany(bson.M{"segment_id": seg.ID}); err != nil {
+		h.log.Error("Failed to delete efforts of segment %s: %v", seg.ID.Hex(), err)
+	}
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(gin.H{"id": seg.ID}))
+}
+
+// 본인의 구간 기록 이력 (최신순, page/limit 쿼리 지원)
+func (h *SegmentHandler) GetSegmentEfforts(c *gin.Context) {
+	seg, ok := h.loadSegment(c)
+	if !ok {
+		return
+	}
+
+	page, limit := parsePage(c)
+	docs, err := h.efforts.ReadManyPaged(
+		bson.M{"segment_id": seg.ID, "user_id": middleware.UserID(c)},
+		bson.D{{Key: "start_time", Value: -1}},
+		(page-1)*limit,
+		limit,
+	)
+	if err != nil {
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToFetchSegments),
+		)
+		return
+	}
+
+	efforts := make([]models.SegmentEffort, 0, len(docs))
+	for _, doc := range docs {
+		var effort models.SegmentEffort
+		bsonBytes, _ := bson.Marshal(doc)
+		bson.Unmarshal(bsonBytes, &effort)
+		efforts = append(efforts, effort)
+	}
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(efforts))
+}
+
+// 구간 순위표 (사용자별 최고 기록순, page/limit 쿼리 지원)
+// period=all|year (year 쿼리로 연도 지정, 기본은 올해), scope=all|friends (친구와 본인만)
+func (h *SegmentHandler) GetLeaderboard(c *gin.Context) {
+	seg, ok := h.loadSegment(c)
+	if !ok {
+		return
+	}
+
+	match := bson.M{"segment_id": seg.ID}
+
+	switch c.DefaultQuery("period", leaderboardPeriodAll) {
+	case leaderboardPeriodAll:
+	case leaderboardPeriodYear:
+		year := time.Now().Year()
+		if v := c.Query("year"); v != "" {
+			y, err := strconv.Atoi(v)
+			if err != nil || y < 1900 || y > 9999 {
+				c.JSON(
+					http.StatusBadRequest,
+					models.NewErrorResponseWithMessage(errors.ErrInvalidLeaderboardType, "잘못된 연도입니다: "+v),
+				)
+				return
+			}
+			year = y
+		}
+		from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		match["start_time"] = bson.M{"$gte": from, "$lt": from.AddDate(1, 0, 0)}
+	default:
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponse(errors.ErrInvalidLeaderboardType),
+		)
+		return
+	}
+
+	switch c.DefaultQuery("scope", leaderboardScopeAll) {
+	case leaderboardScopeAll:
+	case leaderboardScopeFriends:
+		ids, err := friendIDs(h.users, middleware.UserID(c))
+		if err != nil {
+			c.JSON(
+				http.StatusInternalServerError,
+				models.NewErrorResponse(errors.ErrFailedToFetchSegments),
+			)
+			return
+		}
+		match["user_id"] = bson.M{"$in": ids}
+	default:
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponse(errors.ErrInvalidLeaderboardType),
+		)
+		return
+	}
+
+	page, limit := parsePage(c)
+	skip := (page - 1) * limit
+	docs, err := h.efforts.Aggregate(bson.A{
+		bson.D{{Key: "$match", Value: match}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "elapsed_time", Value: 1}, {Key: "start_time", Value: 1}}}},
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":      "$user_id",
+			"effort":   bson.M{"$first": "$$ROOT"},
+			"attempts": bson.M{"$sum": 1},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "effort.elapsed_time", Value: 1}, {Key: "effort.start_time", Value: 1}}}},
+		bson.D{{Key: "$skip", Value: skip}},
+		bson.D{{Key: "$limit", Value: limit}},
+		bson.D{{Key: "$lookup", Value: bson.M{
+			"from":         database.COL_NAME_USERS,
+			"localField":   "_id",
+			"foreignField": "_id",
+			"as":           "user",
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"effort":    1,
+			"attempts":  1,
+			"user_name": bson.M{"$arrayElemAt": bson.A{"$user.name", 0}},
+		}}},
+	})
+	if err != nil {
+		h.log.Error("Failed to build leaderboard for segment %s: %v", seg.ID.Hex(), err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToFetchSegments),
+		)
+		return
+	}
+
+	entries := make([]models.LeaderboardEntry, 0, len(docs))
+	for i, doc := range docs {
+		var row struct {
+			UserID   primitive.ObjectID   `bson:"_id"`
+			UserName string               `bson:"user_name"`
+			Effort   models.SegmentEffort `bson:"effort"`
+			Attempts int                  `bson:"attempts"`
+		}
+		bsonBytes, _ := bson.Marshal(doc)
+		bson.Unmarshal(bsonBytes, &row)
+		entries = append(entries, models.LeaderboardEntry{
+			Rank:     int(skip) + i + 1,
+			UserID:   row.UserID.Hex(),
+			UserName: row.UserName,
+			Effort:   row.Effort,
+			Attempts: row.Attempts,
+		})
+	}
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(entries))
+}
+
+// :id 경로 파라미터의 구간 조회
+func (h *SegmentHandler) loadSegment(c *gin.Context) (*models.Segment, bool) {
+	segmentID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrMissingParams, "잘못된 구간 ID입니다"),
+		)
+		return nil, false
+	}
+
+	var seg models.Segment
+	if err := h.segments.ReadOne(bson.M{"_id": segmentID}, &seg); err != nil {
+		c.JSON(
+			http.StatusNotFound,
+			models.NewErrorResponse(errors.ErrSegmentNotFound),
+		)
+		return nil, false
+	}
+	return &seg, true
+}
+
+// 구간 원본의 트랙 지점 (원본이 라이드이면 라이드도 반환)
+// 경로는 볼 수 있는 것, 라이드는 본인 것만 사용할 수 있음
+func (h *SegmentHandler) loadSourceTrack(src models.SegmentSource, userID primitive.ObjectID) ([]models.GeoPoint, *models.Ride, int) {
+	switch src.Type {
+	case models.SegmentSourceRoute:
+		var route models.Route
+		if err := h.routes.ReadOne(bson.M{"_id": src.ID}, &route); err != nil || !canViewRoute(h.users, &route, userID) {
+			return nil, nil, errors.ErrSegmentSourceNotFound
+		}
+		if route.GPXData == "" {
+			return nil, nil, errors.ErrInvalidSegment
+		}
+		g, err := gpx.ParseString(route.GPXData)
+		if err != nil {
+			return nil, nil, errors.ErrInvalidGPX
+		}
+		return g.GeoPoints(), nil, 0
+
+	case models.SegmentSourceRide:
+		var ride models.Ride
+		if err := h.rides.ReadOne(bson.M{"_id": src.ID, "user_id": userID}, &ride); err != nil {
+			return nil, nil, errors.ErrSegmentSourceNotFound
+		}
+		if err := h.tracks.Load(&ride, false); err != nil {
+			h.log.Error("Failed to load track of ride %s: %v", ride.ID.Hex(), err)
+			return nil, nil, errors.ErrSegmentSourceNotFound
+		}
+		return ride.Locations, &ride, 0
+	}
+	return nil, nil, errors.ErrInvalidSegment
+}
+
+// 사용자 본인과 친구의 ID 목록
+func friendIDs(users *database.Collection, userID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	var user models.User
+	if err := users.ReadOne(bson.M{"_id": userID}, &user); err != nil {
+		return nil, err
+	}
+
+	ids := []primitive.ObjectID{userID}
+	for _, hex := range user.Friends {
+		if id, err := primitive.ObjectIDFromHex(hex); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// 라이드를 주변 구간과 매칭하여 구간 기록을 새로 만듦 (라이드의 기존 구간 기록은 삭제)
+// 라이드 범위 안에 시작/종료 지점이 있는 구간만 공간 인덱스로 조회하여 비교
+// 구간 기록 갱신에 실패해도 라이드 요청은 실패시키지 않고 로그만 남김
+func matchSegments(segments, efforts *database.Collection, log *logger.Log, ride *models.Ride) []models.SegmentEffort {
+	clearSegmentEfforts(efforts, log, ride.ID)
+	if len(ride.Locations) < 2 {
+		return nil
+	}
+
+	// 게이트 반경만큼 넓힌 라이드 범위
+	lo, hi := analysis.Bounds(ride.Locations)
+	latPad := segment.GateRadius / analysis.EarthRadius * 180 / math.Pi
+	lonPad := latPad / math.Max(0.01, math.Cos(math.Max(math.Abs(lo.Latitude), math.Abs(hi.Latitude))*math.Pi/180))
+	lo.Latitude, lo.Longitude = lo.Latitude-latPad, lo.Longitude-lonPad
+	hi.Latitude, hi.Longitude = hi.Latitude+latPad, hi.Longitude+lonPad
+	box := bson.M{"$geoWithin": bson.M{"$geometry": models.NewGeoJSONBox(lo, hi)}}
+
+	docs, err := segments.ReadMany(bson.M{"start_location": box, "end_location": box})
+	if err != nil {
+		log.Error("Failed to find segments near ride %s: %v", ride.ID.Hex(), err)
+		return nil
+	}
+
+	candidates := make([]models.Segment, 0, len(docs))
+	for _, doc := range docs {
+		var seg models.Segment
+		bsonBytes, _ := bson.Marshal(doc)
+		bson.Unmarshal(bsonBytes, &seg)
+		candidates = append(candidates, seg)
+	}
+	return recordSegmentEfforts(efforts, log, ride, candidates)
+}
+
+// candidates 구간 각각에 대해 라이드의 통과 기록을 찾아 저장
+func recordSegmentEfforts(efforts *database.Collection, log *logger.Log, ride *models.Ride, candidates []models.Segment) []models.SegmentEffort {
+	var result []models.SegmentEffort
+	now := time.Now()
+	for _, seg := range candidates {
+		for _, m := range segment.Find(seg.Points, ride.Locations) {
+			effort := models.SegmentEffort{
+				SegmentID:   seg.ID,
+				UserID:      ride.UserID,
+				RideID:      ride.ID,
+				StartTime:   *ride.Locations[m.StartIndex].Timestamp,
+				ElapsedTime: m.ElapsedTime,
+				Distance:    m.Distance / 1000,
+				AvgSpeed:    m.Distance / 1000 / m.ElapsedTime.Hours(),
+				StartIndex:  m.StartIndex,
+				EndIndex:    m.EndIndex,
+				CreatedAt:   now,
+			}
+
+			var err error
+			effort.ID, err = efforts.Create(effort)
+			if err != nil {
+				log.Error("Failed to save effort on segment %s for ride %s: %v", seg.ID.Hex(), ride.ID.Hex(), err)
+				continue
+			}
+			result = append(result, effort)
+		}
+	}
+	return result
+}
+
+// 라이드의 구간 기록 삭제
+func clearSegmentEfforts(efforts *database.Collection, log *logger.Log, rideID primitive.ObjectID) {
+	if _, err := efforts.DeleteMany(bson.M{"ride_id": rideID}); err != nil {
+		log.Error("Failed to delete segment efforts of ride %s: %v", rideID.Hex(), err)
+	}
+}
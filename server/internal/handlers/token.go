@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/config"
+	"github.com/chrisS41/gobike-server/internal/database"
+	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/middleware"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// 리프레시 토큰 폐기 사유
+const (
+	revokeReasonRotated   = "rotated"
+	revokeReasonLogout    = "logout"
+	revokeReasonLogoutAll = "logout_all"
+	revokeReasonReuse     = "reuse_detected"
+	revokeReasonSuspended = "account_suspended"
+	revokeReasonPassword  = "password_changed"
+)
+
+type refreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// 로그인/토큰 갱신 시 발급되는 토큰 쌍
+type tokenPair struct {
+	AccessToken      string    `json:"token"`
+	ExpiresIn        int64     `json:"expires_in"` // 액세스 토큰 유효 시간 (초)
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+// 토큰 갱신
+// 사용된 리프레시 토큰은 폐기되고 같은 패밀리의 새 토큰이 발급됨
+// 이미 회전된 토큰이 다시 사용되면 탈취로 간주하여 패밀리 전체를 폐기
+func (h *UserHandler) RefreshToken(c *gin.Context) {
+	var req refreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrMissingParams, err.Error()),
+		)
+		return
+	}
+
+	hash := hashToken(req.RefreshToken)
+	now := time.Now()
+
+	var current models.RefreshToken
+	err := h.tokens.FindOneAndUpdate(
+		bson.M{"token_hash": hash, "revoked": false, "expires_at": bson.M{"$gt": now}},
+		bson.M{"$set": bson.M{"revoked": true, "revoked_at": now, "revoked_reason": revokeReasonRotated}},
+		&current,
+	)
+	if err == mongo.ErrNoDocuments {
+		h.rejectRefreshToken(c, hash)
+		return
+	}
+	if err != nil {
+		h.log.Error("Failed to rotate refresh token: %v", err)
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrDatabaseQuery),
+		)
+		return
+	}
+
+	var user models.User
+	if err := h.users.ReadOne(bson.M{"_id": current.UserID}, &user); err != nil {
+		c.JSON(
+			http.StatusUnauthorized,
+			models.NewErrorResponse(errors.ErrUserNotFound),
+		)
+		return
+	}
+
+	if user.Status == models.UserStatusSuspended {
+		if _, err := revokeTokens(h.tokens, bson.M{"user_id": user.ID}, revokeReasonSuspended); err != nil {
+			h.log.Error("Failed to revoke tokens of suspended user %s: %v", user.ID.Hex(), err)
+		}
+		c.JSON(
+			http.StatusForbidden,
+			models.NewErrorResponse(errors.ErrAccountSuspended),
+		)
+		return
+	}
+
+	pair, next, err := h.issueTokens(c, user, current.FamilyID)
+	if err != nil {
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToGenerateToken),
+		)
+		return
+	}
+
+	if err := h.tokens.Update(
+		bson.M{"_id": current.ID},
+		bson.M{"$set": bson.M{"replaced_by": next.ID}},
+	); err != nil {
+		h.log.Warn("Failed to link rotated refresh token %s: %v", current.ID.Hex(), err)
+	}
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(pair))
+}
+
+// 로그아웃 (현재 기기)
+// 전달된 리프레시 토큰이 속한 패밀리를 폐기
+func (h *UserHandler) Logout(c *gin.Context) {
+	var req refreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrMissingParams, err.Error()),
+		)
+		return
+	}
+
+	userID := middleware.UserID(c)
+
+	var token models.RefreshToken
+	if err := h.tokens.ReadOne(
+		bson.M{"token_hash": hashToken(req.RefreshToken), "user_id": userID},
+		&token,
+	); err != nil {
+		c.JSON(
+			http.StatusUnauthorized,
+			models.NewErrorResponse(errors.ErrInvalidToken),
+		)
+		return
+	}
+
+	if _, err := revokeTokens(h.tokens,
+		bson.M{"family_id": token.FamilyID, "user_id": userID},
+		revokeReasonLogout,
+	); err != nil {
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToRevokeToken),
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse("logged out"))
+}
+
+// 모든 기기에서 로그아웃
+// 사용자의 모든 리프레시 토큰을 폐기
+func (h *UserHandler) LogoutAll(c *gin.Context) {
+	userID := middleware.UserID(c)
+
+	revoked, err := revokeTokens(h.tokens, bson.M{"user_id": userID}, revokeReasonLogoutAll)
+	if err != nil {
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToRevokeToken),
+		)
+		return
+	}
+
+	h.log.Info("User %s logged out from all devices (%d sessions)", userID.Hex(), revoked)
+	c.JSON(http.StatusOK, models.NewSuccessResponse(gin.H{"revoked_sessions": revoked}))
+}
+
+// 갱신에 실패한 리프레시 토큰의 원인을 판별하여 응답
+func (h *UserHandler) rejectRefreshToken(c *gin.Context, hash string) {
+	var token models.RefreshToken
+	if err := h.tokens.ReadOne(bson.M{"token_hash": hash}, &token); err != nil {
+		c.JSON(
+			http.StatusUnauthorized,
+			models.NewErrorResponse(errors.ErrInvalidToken),
+		)
+		return
+	}
+
+	if token.Revoked {
+		if token.RevokedReason == revokeReasonRotated {
+			// 이미 회전된 토큰의 재사용: 토큰 패밀리 전체 폐기
+			h.log.Warn("Refresh token reuse detected for user %s (family %s)", token.UserID.Hex(), token.FamilyID.Hex())
+			if _, err := revokeTokens(h.tokens, bson.M{"family_id": token.FamilyID}, revokeReasonReuse); err != nil {
+				h.log.Error("Failed to revoke token family %s: %v", token.FamilyID.Hex(), err)
+			}
+			c.JSON(
+				http.StatusUnauthorized,
+				models.NewErrorResponse(errors.ErrRefreshTokenReused),
+			)
+			return
+		}
+		c.JSON(
+			http.StatusUnauthorized,
+			models.NewErrorResponse(errors.ErrInvalidToken),
+		)
+		return
+	}
+
+	c.JSON(
+		http.StatusUnauthorized,
+		models.NewErrorResponse(errors.ErrTokenExpired),
+	)
+}
+
+// 액세스 토큰과 리프레시 토큰을 발급하고 리프레시 토큰을 저장
+func (h *UserHandler) issueTokens(c *gin.Context, user models.User, familyID primitive.ObjectID) (*tokenPair, *models.RefreshToken, error) {
+	cfg := config.GetConfig()
+
+	accessToken, err := h.generateJWT(user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	raw, err := generateRefreshToken()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	token := &models.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(raw),
+		UserAgent: c.Request.UserAgent(),
+		CreatedAt: now,
+		ExpiresAt: now.Add(cfg.RefreshTokenTTL),
+	}
+	token.ID, err = h.tokens.Create(token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &tokenPair{
+		AccessToken:      accessToken,
+		ExpiresIn:        int64(cfg.AccessTokenTTL.Seconds()),
+		RefreshToken:     raw,
+		RefreshExpiresAt: token.ExpiresAt,
+	}, token, nil
+}
+
+// filter에 해당하는 유효한 리프레시 토큰을 모두 폐기하고 폐기된 개수 반환
+func revokeTokens(tokens *database.Collection, filter bson.M, reason string) (int64, error) {
+	filter["revoked"] = false
+	return tokens.UpdateMany(
+		filter,
+		bson.M{"$set": bson.M{"revoked": true, "revoked_at": time.Now(), "revoked_reason": reason}},
+	)
+}
+
+// 32바이트 난수 기반 리프레시 토큰 생성
+func generateRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/chrisS41/gobike-server/internal/database"
 	"github.com/chrisS41/gobike-server/internal/errors"
 	"github.com/chrisS41/gobike-server/internal/logger"
+	"github.com/chrisS41/gobike-server/internal/middleware"
 	"github.com/chrisS41/gobike-server/internal/models"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
@@ -18,12 +19,13 @@ import (
 )
 
 type UserHandler struct {
-	users *database.Collection
-	log   *logger.Log
+	users  *database.Collection
+	tokens *database.Collection
+	log    *logger.Log
 }
 
-func NewUserHandler(users *database.Collection, log *logger.Log) *UserHandler {
-	return &UserHandler{users: users, log: log}
+func NewUserHandler(users, tokens *database.Collection, log *logger.Log) *UserHandler {
+	return &UserHandler{users: users, tokens: tokens, log: log}
 }
 
 // 회원가입
@@ -53,6 +55,11 @@ func (h *UserHandler) Register(c *gin.Context) {
 	}
 	user.Password = string(hashedPassword)
 
+	// 역할/상태는 클라이언트 입력을 무시하고 기본값으로 설정
+	user.Role = models.RoleRider
+	user.Status = models.UserStatusActive
+	user.PasswordResetRequired = false
+
 	// 생성 시간 설정
 	user.CreatedAt = time.Now()
 	user.UpdatedAt = time.Now()
@@ -101,6 +108,15 @@ func (h *UserHandler) Login(c *gin.Context) {
 		return
 	}
 
+	// 정지된 계정 확인
+	if user.Status == models.UserStatusSuspended {
+		c.JSON(
+			http.StatusForbidden,
+			models.NewErrorResponse(errors.ErrAccountSuspended),
+		)
+		return
+	}
+
 	// 마지막 로그인 시간 업데이트
 	user.LastLoginAt = time.Now()
 	if err := h.users.Update(
@@ -110,8 +126,8 @@ func (h *UserHandler) Login(c *gin.Context) {
 		log.Printf("Failed to update last login time: %v", err)
 	}
 
-	// JWT 토큰 생성
-	token, err := h.generateJWT(user)
+	// 액세스/리프레시 토큰 발급 (로그인마다 새 토큰 패밀리 생성)
+	tokens, _, err := h.issueTokens(c, user, primitive.NewObjectID())
 	if err != nil {
 		c.JSON(
 			http.StatusInternalServerError,
@@ -123,10 +139,15 @@ func (h *UserHandler) Login(c *gin.Context) {
 	c.JSON(
 		http.StatusOK,
 		models.NewSuccessResponse(gin.H{
-			"token":         token,
-			"name":          user.Name,
-			"role":          user.Role,
-			"last_login_at": user.LastLoginAt,
+			"token":              tokens.AccessToken,
+			"expires_in":         tokens.ExpiresIn,
+			"refresh_token":      tokens.RefreshToken,
+			"refresh_expires_at": tokens.RefreshExpiresAt,
+			"name":               user.Name,
+			"role":               user.Role,
+			"last_login_at":      user.LastLoginAt,
+			// true인 경우 비밀번호 변경 전까지 다른 API 사용 불가
+			"password_reset_required": user.PasswordResetRequired,
 		}),
 	)
 }
@@ -137,12 +158,98 @@ func (h *UserHandler) generateJWT(user models.User) (string, error) {
 		"id":    user.ID,
 		"email": user.Email,
 		"role":  user.Role,
-		"exp":   time.Now().Add(24 * time.Hour).Unix(),
+		// 비밀번호 재설정이 강제된 경우에만 포함
+		"reset_required": user.PasswordResetRequired,
+		"exp":            time.Now().Add(cfg.AccessTokenTTL).Unix(),
 	})
 
 	return token.SignedString([]byte(cfg.JWTSecret))
 }
 
+type changePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+// 비밀번호 변경
+// 변경 후 기존 세션은 모두 폐기되고 새 토큰이 발급됨
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	var req changePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponseWithMessage(errors.ErrMissingParams, err.Error()),
+		)
+		return
+	}
+	if len(req.NewPassword) < 8 {
+		c.JSON(
+			http.StatusBadRequest,
+			models.NewErrorResponse(errors.ErrInvalidPassword),
+		)
+		return
+	}
+
+	var user models.User
+	if err := h.users.ReadOne(bson.M{"_id": middleware.UserID(c)}, &user); err != nil {
+		c.JSON(
+			http.StatusNotFound,
+			models.NewErrorResponse(errors.ErrUserNotFound),
+		)
+		return
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.CurrentPassword)); err != nil {
+		c.JSON(
+			http.StatusUnauthorized,
+			models.NewErrorResponse(errors.ErrUnauthorized),
+		)
+		return
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToHashPassword),
+		)
+		return
+	}
+
+	user.Password = string(hashedPassword)
+	user.PasswordResetRequired = false
+	user.UpdatedAt = time.Now()
+	if err := h.users.Update(
+		bson.M{"_id": user.ID},
+		bson.M{"$set": bson.M{
+			"password":                user.Password,
+			"password_reset_required": false,
+			"updated_at":              user.UpdatedAt,
+		}},
+	); err != nil {
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToUpdateUser),
+		)
+		return
+	}
+
+	if _, err := revokeTokens(h.tokens, bson.M{"user_id": user.ID}, revokeReasonPassword); err != nil {
+		h.log.Error("Failed to revoke tokens after password change for user %s: %v", user.ID.Hex(), err)
+	}
+
+	tokens, _, err := h.issueTokens(c, user, primitive.NewObjectID())
+	if err != nil {
+		c.JSON(
+			http.StatusInternalServerError,
+			models.NewErrorResponse(errors.ErrFailedToGenerateToken),
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewSuccessResponse(tokens))
+}
+
 // 사용자 조회
 func (h *UserHandler) GetUser(c *gin.Context) {
 	// TODO: 사용자 조회 로직 구현
@@ -151,13 +258,21 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 
 // 사용자 업데이트
 func (h *UserHandler) UpdateUser(c *gin.Context) {
+	if _, ok := requireSelf(c, "id"); !ok {
+		return
+	}
+
 	// TODO: 사용자 업데이트 로직 구현
 	c.JSON(http.StatusOK, gin.H{"message": "user updated"})
 }
 
 // 친구 추가
 func (h *UserHandler) AddFriend(c *gin.Context) {
-	userID, _ := primitive.ObjectIDFromHex(c.Param("id"))
+	userID, ok := requireSelf(c, "id")
+	if !ok {
+		return
+	}
+
 	var friendID primitive.ObjectID
 	if err := c.ShouldBindJSON(&friendID); err != nil {
 		c.JSON(
@@ -184,6 +299,10 @@ func (h *UserHandler) AddFriend(c *gin.Context) {
 
 // 친구 조회
 func (h *UserHandler) GetFriends(c *gin.Context) {
+	if _, ok := requireSelf(c, "id"); !ok {
+		return
+	}
+
 	// TODO: 친구 조회 로직 구현
 	c.JSON(
 		http.StatusOK,
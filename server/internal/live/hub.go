@@ -0,0 +1,121 @@
+// live 패키지는 실시간 주행 세션의 이벤트를 구독자(SSE 등)에게 전달하는 메모리 기반 허브를 제공합니다.
+// 허브는 서버 프로세스 안에서만 동작하므로 여러 인스턴스로 운영하는 경우
+// 같은 세션의 업로드와 구독이 같은 인스턴스로 라우팅되어야 합니다.
+package live
+
+import (
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 이벤트 종류
+const (
+	EventPoints  = "points"  // 위치 묶음 추가
+	EventFinish  = "finish"  // 세션 종료 (라이드로 저장됨)
+	EventDiscard = "discard" // 세션 폐기
+)
+
+// 구독자별 대기 이벤트 수
+// 가득 차면 느린 구독자로 보고 구독을 끊음 (클라이언트는 Last-Event-ID로 재연결)
+const subscriberBuffer = 64
+
+// Event 구독자에게 전달되는 이벤트
+type Event struct {
+	ID   int64       // 이벤트 발생 후 세션에 누적된 지점 개수 (SSE 이벤트 ID로 사용)
+	Type string      // EventPoints, EventFinish, EventDiscard
+	Data interface{} // JSON으로 직렬화되는 이벤트 데이터
+}
+
+// Hub 세션별 구독자 관리
+type Hub struct {
+	mu     sync.Mutex
+	topics map[primitive.ObjectID]map[chan Event]struct{}
+	closed bool
+}
+
+func NewHub() *Hub {
+	return &Hub{topics: make(map[primitive.ObjectID]map[chan Event]struct{})}
+}
+
+// Subscribe 세션 이벤트 구독
+// 반환된 채널은 세션이 닫히거나 구독자가 밀리거나 허브가 종료되면 닫힘
+// 구독을 마치면 반드시 cancel을 호출해야 함
+func (h *Hub) Subscribe(sessionID primitive.ObjectID) (events <-chan Event, cancel func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		close(ch)
+		return ch, func() {}
+	}
+
+	subs := h.topics[sessionID]
+	if subs == nil {
+		subs = make(map[chan Event]struct{})
+		h.topics[sessionID] = subs
+	}
+	subs[ch] = struct{}{}
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.remove(sessionID, ch)
+	}
+}
+
+// Publish 세션의 모든 구독자에게 이벤트 전달 (대기하지 않음)
+func (h *Hub) Publish(sessionID primitive.ObjectID, event Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.topics[sessionID] {
+		select {
+		case ch <- event:
+		default:
+			h.remove(sessionID, ch)
+		}
+	}
+}
+
+// CloseSession 마지막 이벤트를 전달하고 세션의 모든 구독을 종료
+func (h *Hub) CloseSession(sessionID primitive.ObjectID, last Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.topics[sessionID] {
+		select {
+		case ch <- last:
+		default:
+		}
+		h.remove(sessionID, ch)
+	}
+}
+
+// Shutdown 모든 구독을 종료하고 이후 구독을 거부 (서버 종료 시 호출)
+func (h *Hub) Shutdown() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for sessionID, subs := range h.topics {
+		for ch := range subs {
+			h.remove(sessionID, ch)
+		}
+	}
+}
+
+// mu를 잡은 상태에서 호출해야 함
+func (h *Hub) remove(sessionID primitive.ObjectID, ch chan Event) {
+	subs := h.topics[sessionID]
+	if _, ok := subs[ch]; !ok {
+		return
+	}
+	delete(subs, ch)
+	close(ch)
+	if len(subs) == 0 {
+		delete(h.topics, sessionID)
+	}
+}
@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	stderrors "errors"
+	"net/http"
+	"strings"
+
+	"github.com/chrisS41/gobike-server/internal/database"
+	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 컨텍스트에 저장되는 인증 정보 키
+const (
+	ContextUserID        = "auth_user_id"
+	ContextUserRole      = "auth_user_role"
+	ContextResetRequired = "auth_reset_required"
+)
+
+// Claims 로그인 시 발급되는 JWT의 클레임
+type Claims struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+	Role  string `json:"role"`
+
+	ResetRequired bool `json:"reset_required,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// 스트리밍 요청에서 토큰을 전달하는 쿼리 파라미터
+const queryTokenParam = "access_token"
+
+// Auth Authorization 헤더의 Bearer 토큰을 검증하고
+// 인증된 사용자 ID와 역할을 컨텍스트에 저장
+// 토큰 발급 후의 계정 정지, 비밀번호 재설정 강제, 역할 변경이 바로 적용되도록
+// 역할과 재설정 여부는 토큰이 아닌 users 컬렉션의 현재 값을 사용
+func Auth(secret string, users *database.Collection) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString := bearerToken(c)
+		if tokenString == "" {
+			abortWithError(c, http.StatusUnauthorized, errors.ErrUnauthorized)
+			return
+		}
+
+		claims := &Claims{}
+		_, err := jwt.ParseWithClaims(
+			tokenString,
+			claims,
+			func(token *jwt.Token) (interface{}, error) {
+				return []byte(secret), nil
+			},
+			jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+			jwt.WithExpirationRequired(),
+		)
+		if err != nil {
+			if stderrors.Is(err, jwt.ErrTokenExpired) {
+				abortWithError(c, http.StatusUnauthorized, errors.ErrTokenExpired)
+				return
+			}
+			abortWithError(c, http.StatusUnauthorized, errors.ErrInvalidToken)
+			return
+		}
+
+		userID, err := primitive.ObjectIDFromHex(claims.ID)
+		if err != nil {
+			abortWithError(c, http.StatusUnauthorized, errors.ErrInvalidToken)
+			return
+		}
+
+		var user models.User
+		err = users.ReadOne(
+			bson.M{"_id": userID},
+			&user,
+			options.FindOne().SetProjection(bson.M{"status": 1, "role": 1, "password_reset_required": 1}),
+		)
+		if err == mongo.ErrNoDocuments {
+			abortWithError(c, http.StatusUnauthorized, errors.ErrInvalidToken)
+			return
+		}
+		if err != nil {
+			abortWithError(c, http.StatusInternalServerError, errors.ErrDatabaseQuery)
+			return
+		}
+		if user.Status == models.UserStatusSuspended {
+			abortWithError(c, http.StatusForbidden, errors.ErrAccountSuspended)
+			return
+		}
+
+		c.Set(ContextUserID, userID)
+		c.Set(ContextUserRole, user.Role)
+		c.Set(ContextResetRequired, user.PasswordResetRequired)
+		c.Next()
+	}
+}
+
+// UserID 인증된 사용자의 ID 반환 (인증되지 않은 경우 NilObjectID)
+func UserID(c *gin.Context) primitive.ObjectID {
+	if v, ok := c.Get(ContextUserID); ok {
+		if id, ok := v.(primitive.ObjectID); ok {
+			return id
+		}
+	}
+	return primitive.NilObjectID
+}
+
+// UserRole 인증된 사용자의 역할 반환
+func UserRole(c *gin.Context) string {
+	return c.GetString(ContextUserRole)
+}
+
+// 요청에서 액세스 토큰 추출
+// 브라우저의 WebSocket/EventSource는 헤더를 지정할 수 없으므로
+// 스트리밍 요청에 한해 access_token 쿼리 파라미터도 허용
+func bearerToken(c *gin.Context) string {
+	if token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); found {
+		return token
+	}
+	if isStreamingRequest(c.Request) {
+		return c.Query(queryTokenParam)
+	}
+	return ""
+}
+
+func isStreamingRequest(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") ||
+		strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
+func abortWithError(c *gin.Context, status int, code int) {
+	c.AbortWithStatusJSON(status, models.NewErrorResponse(code))
+}
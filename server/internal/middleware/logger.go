@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccessLog gin.Logger와 같은 형식의 접근 로그
+// 스트리밍 요청은 쿼리 파라미터로 액세스 토큰을 전달하므로 로그에는 토큰 값을 가려서 남김
+func AccessLog() gin.HandlerFunc {
+	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
+		var statusColor, methodColor, resetColor string
+		if param.IsOutputColor() {
+			statusColor = param.StatusCodeColor()
+			methodColor = param.MethodColor()
+			resetColor = param.ResetColor()
+		}
+		if param.Latency > time.Minute {
+			param.Latency = param.Latency.Truncate(time.Second)
+		}
+		return fmt.Sprintf("[GIN] %v |%s %3d %s| %13v | %15s |%s %-7s %s %#v\n%s",
+			param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+			statusColor, param.StatusCode, resetColor,
+			param.Latency,
+			param.ClientIP,
+			methodColor, param.Method, resetColor,
+			redactPath(param.Path),
+			param.ErrorMessage,
+		)
+	})
+}
+
+// 요청 경로의 쿼리에서 액세스 토큰 값을 가림
+func redactPath(path string) string {
+	p, rawQuery, found := strings.Cut(path, "?")
+	if !found {
+		return path
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		// 해석할 수 없는 쿼리는 토큰이 섞여 있을 수 있으므로 남기지 않음
+		return p
+	}
+	if _, ok := query[queryTokenParam]; !ok {
+		return path
+	}
+	query.Set(queryTokenParam, "REDACTED")
+	return p + "?" + query.Encode()
+}
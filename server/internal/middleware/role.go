@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/chrisS41/gobike-server/internal/errors"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole 인증된 사용자의 역할이 roles 중 하나인지 확인
+// admin 역할은 모든 역할 검사를 통과
+// Auth 미들웨어 이후에 사용해야 함
+func RequireRole(roles ...string) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		allowed[role] = true
+	}
+
+	return func(c *gin.Context) {
+		role := UserRole(c)
+		if role != models.RoleAdmin && !allowed[role] {
+			abortWithError(c, http.StatusForbidden, errors.ErrUnauthorized)
+			return
+		}
+		c.Next()
+	}
+}
+
+// BlockPendingPasswordReset 비밀번호 재설정이 강제된 사용자의 요청 차단
+func BlockPendingPasswordReset() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetBool(ContextResetRequired) {
+			abortWithError(c, http.StatusForbidden, errors.ErrPasswordResetRequired)
+			return
+		}
+		c.Next()
+	}
+}
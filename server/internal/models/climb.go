@@ -0,0 +1,53 @@
+package models
+
+// Climb 트랙에서 찾은 오르막 구간
+type Climb struct {
+	StartIndex    int     `bson:"start_index" json:"start_index"`       // 트랙 지점 인덱스
+	EndIndex      int     `bson:"end_index" json:"end_index"`           // 트랙 지점 인덱스 (포함, 오르막 정상)
+	StartDistance float64 `bson:"start_distance" json:"start_distance"` // 트랙 시작부터 오르막 시작까지 거리 (킬로미터)
+	Distance      float64 `bson:"distance" json:"distance"`             // 킬로미터
+	ElevationGain float64 `bson:"elevation_gain" json:"elevation_gain"` // 시작과 정상의 고도 차 (미터)
+	AvgGrade      float64 `bson:"avg_grade" json:"avg_grade"`           // 평균 경사도 (%)
+	MaxGrade      float64 `bson:"max_grade" json:"max_grade"`           // 100m 이상 구간 기준 최대 경사도 (%)
+	Category      string  `bson:"category" json:"category"`             // ClimbCategory*
+}
+
+// 오르막 등급 (Cat 4가 가장 쉽고 HC가 가장 어려움)
+const (
+	ClimbCategory4  = "4"
+	ClimbCategory3  = "3"
+	ClimbCategory2  = "2"
+	ClimbCategory1  = "1"
+	ClimbCategoryHC = "HC"
+)
+
+// 경로 난이도
+const (
+	DifficultyEasy     = "easy"     // 쉬움
+	DifficultyModerate = "moderate" // 보통
+	DifficultyHard     = "hard"     // 어려움
+	DifficultyExtreme  = "extreme"  // 매우 어려움
+)
+
+// Difficulties 쉬운 순서로 정렬한 난이도 목록
+var Difficulties = []string{DifficultyEasy, DifficultyModerate, DifficultyHard, DifficultyExtreme}
+
+// LegacyDifficulties 난이도가 자유 입력이던 때의 값과 대응하는 정의된 값
+var LegacyDifficulties = map[string]string{
+	"쉬움":     DifficultyEasy,
+	"보통":     DifficultyModerate,
+	"어려움":    DifficultyHard,
+	"매우 어려움": DifficultyExtreme,
+}
+
+// NormalizeDifficulty 난이도 값을 정의된 값으로 변환
+// 이전에 쓰던 한국어 값(쉬움/보통/어려움)도 허용하며, 알 수 없는 값이면 false 반환
+func NormalizeDifficulty(value string) (string, bool) {
+	for _, d := range Difficulties {
+		if value == d {
+			return d, true
+		}
+	}
+	d, ok := LegacyDifficulties[value]
+	return d, ok
+}
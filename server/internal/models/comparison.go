@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// RouteComparison 연결된 경로와 라이드 트랙의 비교 결과
+type RouteComparison struct {
+	RouteDistance    float64           `bson:"route_distance" json:"route_distance"`         // 경로 거리 (킬로미터)
+	Completion       float64           `bson:"completion" json:"completion"`                 // 라이드가 지나간 경로 거리의 비율 (%)
+	OffRouteDistance float64           `bson:"off_route_distance" json:"off_route_distance"` // 경로를 벗어나 달린 거리 (킬로미터)
+	OffRouteTime     time.Duration     `bson:"off_route_time" json:"off_route_time"`         // 경로를 벗어나 있던 시간
+	MaxDeviation     float64           `bson:"max_deviation" json:"max_deviation"`           // 경로에서 가장 멀리 벗어난 거리 (미터)
+	OffRoute         []OffRouteSection `bson:"off_route,omitempty" json:"off_route,omitempty"`
+	Checkpoints      []CheckpointTime  `bson:"checkpoints,omitempty" json:"checkpoints,omitempty"`
+}
+
+// OffRouteSection 경로를 벗어난 구간
+type OffRouteSection struct {
+	StartIndex    int           `bson:"start_index" json:"start_index"`       // 라이드 지점 인덱스
+	EndIndex      int           `bson:"end_index" json:"end_index"`           // 라이드 지점 인덱스 (포함)
+	StartDistance float64       `bson:"start_distance" json:"start_distance"` // 라이드 시작부터 이탈 지점까지 거리 (킬로미터)
+	Distance      float64       `bson:"distance" json:"distance"`             // 킬로미터
+	Duration      time.Duration `bson:"duration" json:"duration"`             // 시각 정보가 없으면 0
+	MaxDeviation  float64       `bson:"max_deviation" json:"max_deviation"`   // 미터
+}
+
+// CheckpointTime 경로의 체크포인트 통과 기록
+type CheckpointTime struct {
+	Name          string        `bson:"name" json:"name"`
+	RouteDistance float64       `bson:"route_distance" json:"route_distance"` // 경로 시작부터 체크포인트까지 거리 (킬로미터)
+	Latitude      float64       `bson:"latitude" json:"latitude"`
+	Longitude     float64       `bson:"longitude" json:"longitude"`
+	Reached       bool          `bson:"reached" json:"reached"`
+	Index         int           `bson:"index,omitempty" json:"index,omitempty"`                 // 통과한 라이드 지점 인덱스
+	Time          *time.Time    `bson:"time,omitempty" json:"time,omitempty"`                   // 통과 시각
+	Elapsed       time.Duration `bson:"elapsed,omitempty" json:"elapsed,omitempty"`             // 라이드 시작부터 통과까지 시간
+	RideDistance  float64       `bson:"ride_distance,omitempty" json:"ride_distance,omitempty"` // 라이드 시작부터 통과까지 거리 (킬로미터)
+}
+
+// RouteDeviation 주행 중 현재 위치의 경로 이탈 여부
+type RouteDeviation struct {
+	OffRoute      bool     `json:"off_route"`
+	Deviation     float64  `json:"deviation"`      // 경로까지 거리 (미터)
+	RouteDistance float64  `json:"route_distance"` // 경로 시작부터 가장 가까운 경로 지점까지 거리 (킬로미터)
+	Remaining     float64  `json:"remaining"`      // 가장 가까운 경로 지점부터 도착까지 거리 (킬로미터)
+	Nearest       GeoPoint `json:"nearest"`        // 가장 가까운 경로 지점
+}
@@ -0,0 +1,49 @@
+package models
+
+// 안내 종류
+const (
+	CueTypeStart    = "start"    // 출발
+	CueTypeTurn     = "turn"     // 회전
+	CueTypeContinue = "continue" // 회전 없이 다른 도로로 이어짐
+	CueTypeClimb    = "climb"    // 오르막 시작
+	CueTypeSummit   = "summit"   // 오르막 정상
+	CueTypePOI      = "poi"      // 경로의 관심 지점 (GPX 웨이포인트)
+	CueTypeFinish   = "finish"   // 도착
+)
+
+// 회전 방향
+const (
+	CueDirectionStraight    = "straight"
+	CueDirectionSlightLeft  = "slight_left"
+	CueDirectionLeft        = "left"
+	CueDirectionSharpLeft   = "sharp_left"
+	CueDirectionSlightRight = "slight_right"
+	CueDirectionRight       = "right"
+	CueDirectionSharpRight  = "sharp_right"
+	CueDirectionUTurn       = "u_turn"
+)
+
+// Cue 큐시트의 안내 하나
+type Cue struct {
+	Type      string   `json:"type"`                // CueType*
+	Direction string   `json:"direction,omitempty"` // CueDirection* (회전, 도로 변경 안내만)
+	Angle     float64  `json:"angle,omitempty"`     // 진행 방향 변화 (도, 오른쪽이 +)
+	Index     int      `json:"index"`               // 트랙 지점 인덱스
+	Distance  float64  `json:"distance"`            // 트랙 시작부터 거리 (킬로미터)
+	Next      float64  `json:"next"`                // 다음 안내까지 거리 (킬로미터)
+	Street    string   `json:"street,omitempty"`    // 안내 이후 달리는 도로 이름
+	Name      string   `json:"name,omitempty"`      // 관심 지점 이름
+	Category  string   `json:"category,omitempty"`  // 오르막 등급 (ClimbCategory*, 오르막/정상 안내만)
+	Note      string   `json:"note"`                // 안내 문구
+	Latitude  float64  `json:"latitude"`
+	Longitude float64  `json:"longitude"`
+	Elevation *float64 `json:"elevation,omitempty"` // 미터
+}
+
+// CueSheet 경로의 큐시트
+type CueSheet struct {
+	RouteID  string  `json:"route_id"`
+	Name     string  `json:"name"`
+	Distance float64 `json:"distance"` // 킬로미터
+	Cues     []Cue   `json:"cues"`
+}
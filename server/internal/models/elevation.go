@@ -0,0 +1,24 @@
+package models
+
+// 고도 출처
+const (
+	ElevationSourceDEM    = "dem"    // 로컬 DEM 타일
+	ElevationSourceDevice = "device" // GPX/기기가 기록한 고도
+)
+
+// ElevationProfile 경로의 거리-고도 그래프
+type ElevationProfile struct {
+	Source        string           `json:"source"`         // ElevationSource*
+	Distance      float64          `json:"distance"`       // 킬로미터
+	ElevationGain float64          `json:"elevation_gain"` // 미터
+	ElevationLoss float64          `json:"elevation_loss"` // 미터
+	MinElevation  float64          `json:"min_elevation"`  // 미터
+	MaxElevation  float64          `json:"max_elevation"`  // 미터
+	Points        []ElevationPoint `json:"points"`
+}
+
+// ElevationPoint 그래프의 한 지점
+type ElevationPoint struct {
+	Distance  float64 `json:"distance"`  // 시작부터 거리 (킬로미터)
+	Elevation float64 `json:"elevation"` // 미터
+}
@@ -0,0 +1,56 @@
+package models
+
+// GeoJSONPoint MongoDB 2dsphere 인덱스용 GeoJSON Point
+// 좌표 순서는 [경도, 위도]
+type GeoJSONPoint struct {
+	Type        string    `bson:"type" json:"type"`
+	Coordinates []float64 `bson:"coordinates" json:"coordinates"`
+}
+
+// GeoJSONLineString MongoDB 2dsphere 인덱스용 GeoJSON LineString
+type GeoJSONLineString struct {
+	Type        string      `bson:"type" json:"type"`
+	Coordinates [][]float64 `bson:"coordinates" json:"coordinates"`
+}
+
+// GeoJSONPolygon MongoDB 공간 조회 조건용 GeoJSON Polygon
+type GeoJSONPolygon struct {
+	Type        string        `bson:"type" json:"type"`
+	Coordinates [][][]float64 `bson:"coordinates" json:"coordinates"`
+}
+
+// NewGeoJSONPoint GeoPoint를 GeoJSON Point로 변환
+func NewGeoJSONPoint(p GeoPoint) GeoJSONPoint {
+	return GeoJSONPoint{Type: "Point", Coordinates: []float64{p.Longitude, p.Latitude}}
+}
+
+// NewGeoJSONLineString GeoPoint 목록을 GeoJSON LineString으로 변환
+// 좌표가 같은 연속 지점은 2dsphere 인덱스가 거부하므로 하나만 남기며,
+// 서로 다른 지점이 2개 미만이면 nil 반환
+func NewGeoJSONLineString(points []GeoPoint) *GeoJSONLineString {
+	coords := make([][]float64, 0, len(points))
+	for _, p := range points {
+		if n := len(coords); n > 0 && coords[n-1][0] == p.Longitude && coords[n-1][1] == p.Latitude {
+			continue
+		}
+		coords = append(coords, []float64{p.Longitude, p.Latitude})
+	}
+	if len(coords) < 2 {
+		return nil
+	}
+	return &GeoJSONLineString{Type: "LineString", Coordinates: coords}
+}
+
+// NewGeoJSONBox 최소/최대 위경도로 사각형 Polygon 생성 (반시계 방향, 닫힌 고리)
+func NewGeoJSONBox(lo, hi GeoPoint) GeoJSONPolygon {
+	return GeoJSONPolygon{
+		Type: "Polygon",
+		Coordinates: [][][]float64{{
+			{lo.Longitude, lo.Latitude},
+			{hi.Longitude, lo.Latitude},
+			{hi.Longitude, hi.Latitude},
+			{lo.Longitude, hi.Latitude},
+			{lo.Longitude, lo.Latitude},
+		}},
+	}
+}
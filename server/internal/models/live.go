@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 실시간 세션 상태
+const (
+	LiveStatusActive    = "active"    // 주행 중 (위치 업로드 가능)
+	LiveStatusFinished  = "finished"  // 종료되어 라이드로 저장됨
+	LiveStatusDiscarded = "discarded" // 저장하지 않고 폐기됨
+)
+
+// 실시간 세션 공개 범위
+const (
+	LiveVisibilityPrivate = "private" // 본인만
+	LiveVisibilityFriends = "friends" // 본인의 친구 목록에 있는 사용자도 구독 가능
+)
+
+// IsValidLiveVisibility 정의된 공개 범위인지 확인
+func IsValidLiveVisibility(visibility string) bool {
+	switch visibility {
+	case LiveVisibilityPrivate, LiveVisibilityFriends:
+		return true
+	}
+	return false
+}
+
+// LiveSession 실시간 주행 세션
+// 클라이언트는 위치를 순번(seq)이 붙은 묶음으로 전송하며, 서버는 순번이 연속된 묶음만 저장
+// 재연결 시 LastSeq 이후의 묶음부터 다시 전송하면 됨
+// 긴 주행도 문서 크기 제한(16MB)에 걸리지 않도록 위치와 센서 측정값은 묶음마다 LiveChunk로 따로 저장하며,
+// Locations/Sensors는 조회할 때 묶음을 모아 채움 (묶음 저장 도입 전 세션은 문서에 들어 있음)
+type LiveSession struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID     primitive.ObjectID `bson:"user_id" json:"user_id"`
+	RouteID    primitive.ObjectID `bson:"route_id,omitempty" json:"route_id"`
+	Status     string             `bson:"status" json:"status"`
+	Visibility string             `bson:"visibility" json:"visibility"`
+	LastSeq    int64              `bson:"last_seq" json:"last_seq"`       // 마지막으로 저장된 묶음 순번 (없으면 0)
+	PointCount int64              `bson:"point_count" json:"point_count"` // 저장된 지점 개수
+	Locations  []GeoPoint         `bson:"locations,omitempty" json:"locations,omitempty"`
+	Sensors    []SensorSample     `bson:"sensors,omitempty" json:"sensors,omitempty"`       // 시간순 센서 측정값 (종료 시 지점 시각에 맞춤)
+	Thresholds *Thresholds        `bson:"thresholds,omitempty" json:"thresholds,omitempty"` // 라이드에 저장할 기준값
+	RideID     primitive.ObjectID `bson:"ride_id,omitempty" json:"ride_id,omitempty"`       // 종료 후 생성된 라이드
+	StartedAt  time.Time          `bson:"started_at" json:"started_at"`
+	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+	EndedAt    *time.Time         `bson:"ended_at,omitempty" json:"ended_at,omitempty"`
+}
+
+// LiveChunk 세션에 저장된 위치 묶음 하나 (live_session_chunks 컬렉션)
+type LiveChunk struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	SessionID primitive.ObjectID `bson:"session_id"`
+	Seq       int64              `bson:"seq"`
+	From      int64              `bson:"from"` // 첫 지점의 세션 내 인덱스
+	Points    []GeoPoint         `bson:"points"`
+	Sensors   []SensorSample     `bson:"sensors,omitempty"`
+}
+
+// LiveBatch 클라이언트가 전송하는 위치 묶음
+// 센서 측정값은 위치와 주기가 다를 수 있으므로 시각을 붙여 따로 보냄
+type LiveBatch struct {
+	Seq     int64          `json:"seq"` // 1부터 시작해 묶음마다 1씩 증가
+	Points  []GeoPoint     `json:"points"`
+	Sensors []SensorSample `json:"sensors,omitempty"`
+}
+
+// LiveAck 위치 묶음 수신 확인
+type LiveAck struct {
+	Seq        int64 `json:"seq"`                 // 확인한 묶음 순번
+	LastSeq    int64 `json:"last_seq"`            // 서버에 저장된 마지막 순번
+	PointCount int64 `json:"point_count"`         // 서버에 저장된 지점 개수
+	Duplicate  bool  `json:"duplicate,omitempty"` // 이미 저장된 묶음을 다시 받은 경우
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Effort 라이드 안에서 측정한 종목별 최고 기록
+// 종목(Key)은 analysis.EffortDefinitions에 정의됨
+type Effort struct {
+	Key         string        `bson:"key" json:"key"`
+	Distance    float64       `bson:"distance" json:"distance"`         // 킬로미터
+	ElapsedTime time.Duration `bson:"elapsed_time" json:"elapsed_time"` // 경과 시간
+	Elevation   float64       `bson:"elevation,omitempty" json:"elevation,omitempty"`
+	StartIndex  int           `bson:"start_index" json:"start_index"` // Ride.Locations 기준 구간 시작 인덱스
+	EndIndex    int           `bson:"end_index" json:"end_index"`     // Ride.Locations 기준 구간 끝 인덱스 (포함)
+}
+
+// PersonalRecord 사용자의 종목별 개인 기록
+// History는 라이드 시작 시각 순으로 기록이 갱신된 이력이며 마지막 항목이 Best와 같음
+type PersonalRecord struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Key       string             `bson:"key" json:"key"`
+	Best      RecordEntry        `bson:"best" json:"best"`
+	History   []RecordEntry      `bson:"history" json:"history,omitempty"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// RecordEntry 개인 기록을 세운 라이드와 기록
+type RecordEntry struct {
+	RideID     primitive.ObjectID `bson:"ride_id" json:"ride_id"`
+	AchievedAt time.Time          `bson:"achieved_at" json:"achieved_at"` // 라이드 시작 시각
+	Effort     `bson:",inline"`
+}
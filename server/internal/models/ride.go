@@ -12,13 +12,48 @@ type Ride struct {
 	RouteID   primitive.ObjectID `bson:"route_id,omitempty" json:"route_id"`
 	StartTime time.Time          `bson:"start_time" json:"start_time"`
 	EndTime   time.Time          `bson:"end_time" json:"end_time"`
-	Distance  float64            `bson:"distance" json:"distance"`
-	Duration  time.Duration      `bson:"duration" json:"duration"`
-	AvgSpeed  float64            `bson:"avg_speed" json:"avg_speed"`
-	MaxSpeed  float64            `bson:"max_speed" json:"max_speed"`
-	Calories  float64            `bson:"calories" json:"calories"`
-	Locations []GeoPoint         `bson:"locations" json:"locations"`
-	Weather   WeatherInfo        `bson:"weather" json:"weather"`
+	// 아래 통계 필드는 Locations로부터 서버에서 계산됨 (analysis.ApplyToRide)
+	Distance      float64       `bson:"distance" json:"distance"`             // 킬로미터
+	Duration      time.Duration `bson:"duration" json:"duration"`             // 전체 경과 시간
+	MovingTime    time.Duration `bson:"moving_time" json:"moving_time"`       // 이동 시간
+	AvgSpeed      float64       `bson:"avg_speed" json:"avg_speed"`           // km/h (이동 시간 기준)
+	MaxSpeed      float64       `bson:"max_speed" json:"max_speed"`           // km/h
+	ElevationGain float64       `bson:"elevation_gain" json:"elevation_gain"` // 미터
+	ElevationLoss float64       `bson:"elevation_loss" json:"elevation_loss"` // 미터
+	Calories      float64       `bson:"calories" json:"calories"`
+	// 트랙과 센서 스트림은 ride_tracks 컬렉션에 묶음으로 저장 (trackstore), 필요할 때만 읽음
+	PointCount   int            `bson:"point_count" json:"point_count"`                   // Locations 지점 수
+	Locations    []GeoPoint     `bson:"-" json:"locations"`                               // 정제한 트랙 (track.Cleaner)
+	RawLocations []GeoPoint     `bson:"-" json:"raw_locations,omitempty"`                 // 정제 전 입력 트랙
+	Simplified   []GeoPoint     `bson:"simplified,omitempty" json:"simplified,omitempty"` // 표시용으로 단순화한 트랙
+	Pauses       []Pause        `bson:"pauses,omitempty" json:"pauses,omitempty"`         // 자동 일시정지 구간
+	Streams      *SensorStreams `bson:"-" json:"streams,omitempty"`                       // Locations와 인덱스가 같은 센서 데이터
+	BestEfforts  []Effort       `bson:"best_efforts,omitempty" json:"best_efforts,omitempty"`
+	Climbs       []Climb        `bson:"climbs,omitempty" json:"climbs,omitempty"` // 등급이 매겨진 오르막 구간
+	// 구간과 강도 계산 기준 (입력값), 센서 요약은 Streams로부터 서버에서 계산됨
+	Thresholds *Thresholds    `bson:"thresholds,omitempty" json:"thresholds,omitempty"`
+	Sensors    *SensorSummary `bson:"sensors,omitempty" json:"sensors,omitempty"`
+	// 연결된 경로와 비교한 결과 (RouteID가 있을 때 서버에서 계산)
+	RouteComparison *RouteComparison `bson:"route_comparison,omitempty" json:"route_comparison,omitempty"`
+	Weather         WeatherInfo      `bson:"weather" json:"weather"`
+	CreatedAt       time.Time        `bson:"created_at" json:"created_at"`
+	UpdatedAt       time.Time        `bson:"updated_at" json:"updated_at"`
+
+	// 이 라이드로 갱신된 개인 기록 종목 (응답 전용, 저장하지 않음)
+	NewRecords []string `bson:"-" json:"new_records,omitempty"`
+	// 이 라이드에서 찾은 구간 기록 (응답 전용, segment_efforts 컬렉션에 저장됨)
+	SegmentEfforts []SegmentEffort `bson:"-" json:"segment_efforts,omitempty"`
+	// geometry=polyline 조회 시 단순화한 트랙의 인코딩 폴리라인 (응답 전용)
+	Polyline string `bson:"-" json:"polyline,omitempty"`
+}
+
+// Pause 한 자리에 멈춰 있던 자동 일시정지 구간 (이동 시간에서 제외)
+type Pause struct {
+	StartIndex int           `bson:"start_index" json:"start_index"` // Locations 인덱스
+	EndIndex   int           `bson:"end_index" json:"end_index"`     // Locations 인덱스 (포함)
+	StartTime  time.Time     `bson:"start_time" json:"start_time"`
+	EndTime    time.Time     `bson:"end_time" json:"end_time"`
+	Duration   time.Duration `bson:"duration" json:"duration"`
 }
 
 type WeatherInfo struct {
@@ -6,13 +6,31 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// 경로 공개 범위
+const (
+	RouteVisibilityPrivate = "private" // 본인만
+	RouteVisibilityFriends = "friends" // 본인의 친구 목록에 있는 사용자까지
+	RouteVisibilityPublic  = "public"  // 모든 사용자
+)
+
+// IsValidRouteVisibility 정의된 공개 범위인지 확인
+func IsValidRouteVisibility(visibility string) bool {
+	switch visibility {
+	case RouteVisibilityPrivate, RouteVisibilityFriends, RouteVisibilityPublic:
+		return true
+	}
+	return false
+}
+
 type Route struct {
 	ID            primitive.ObjectID `bson:"_id,omitempty"`  // MongoDB의 기본 ID 필드
+	UserID        primitive.ObjectID `bson:"user_id"`        // 경로를 만든 사용자
+	Visibility    string             `bson:"visibility"`     // 공개 범위 (RouteVisibility*)
 	Name          string             `bson:"name"`           // 경로의 이름
 	Description   string             `bson:"description"`    // 경로에 대한 설명
 	Distance      float64            `bson:"distance"`       // 경로의 총 거리 (킬로미터)
 	Duration      time.Duration      `bson:"duration"`       // 예상 소요 시간
-	Difficulty    string             `bson:"difficulty"`     // 난이도 (예: 쉬움, 보통, 어려움)
+	Difficulty    string             `bson:"difficulty"`     // 난이도 (Difficulty*), 트랙이 있으면 서버에서 계산
 	GPXData       string             `bson:"gpx_data"`       // GPX 형식의 경로 데이터
 	CreatedAt     time.Time          `bson:"created_at"`     // 경로 생성 일시
 	UpdatedAt     time.Time          `bson:"updated_at"`     // 경로 수정 일시
@@ -20,9 +38,24 @@ type Route struct {
 	EndPoint      GeoPoint           `bson:"end_point"`      // 경로 종료 지점 (위도, 경도)
 	ElevationGain float64            `bson:"elevation_gain"` // 총 상승 고도 (미터)
 	Tags          []string           `bson:"tags"`           // 경로와 관련된 태그
+	RideCount     int64              `bson:"ride_count"`     // 이 경로를 따라 달린 라이드 수 (인기순 정렬용, 서버에서 관리)
+	AvgGrade      float64            `bson:"avg_grade"`      // 평균 경사도 (%)
+	MaxGrade      float64            `bson:"max_grade"`      // 최대 경사도 (%)
+	Climbs        []Climb            `bson:"climbs"`         // 등급이 매겨진 오르막 구간
+
+	// 공간 조회용 GeoJSON (2dsphere 인덱스), StartPoint와 GPX 트랙으로부터 서버에서 계산됨
+	StartLocation *GeoJSONPoint      `bson:"start_location,omitempty" json:"-"` // 시작 지점
+	Geometry      *GeoJSONLineString `bson:"geometry,omitempty" json:"-"`       // 간략화한 경로 선
+
+	// geometry 쿼리로 GPXData 대신 응답하는 경로 선 (응답 전용)
+	Simplified []GeoPoint `bson:"-" json:",omitempty"` // geometry=simplified
+	Polyline   string     `bson:"-" json:",omitempty"` // geometry=polyline
 }
 
 type GeoPoint struct {
-	Latitude  float64 `bson:"latitude"`  // 위도
-	Longitude float64 `bson:"longitude"` // 경도
+	Latitude  float64    `bson:"latitude" json:"latitude"`                       // 위도
+	Longitude float64    `bson:"longitude" json:"longitude"`                     // 경도
+	Elevation *float64   `bson:"elevation,omitempty" json:"elevation,omitempty"` // 고도 (미터, 선택)
+	Timestamp *time.Time `bson:"timestamp,omitempty" json:"timestamp,omitempty"` // 기록 시각 (선택)
+	Accuracy  *float64   `bson:"accuracy,omitempty" json:"accuracy,omitempty"`   // 수평 위치 정확도 (미터, 선택)
 }
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Segment 사용자가 경로나 라이드의 일부를 잘라 정의한 구간
+// 업로드된 라이드가 구간을 통과하면 구간 기록(SegmentEffort)이 생성됨
+type Segment struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID        primitive.ObjectID `bson:"user_id" json:"user_id"` // 구간을 만든 사용자
+	Name          string             `bson:"name" json:"name"`
+	Description   string             `bson:"description" json:"description"`
+	Source        SegmentSource      `bson:"source" json:"source"`
+	Points        []GeoPoint         `bson:"points" json:"points"`
+	Distance      float64            `bson:"distance" json:"distance"`             // 킬로미터
+	ElevationGain float64            `bson:"elevation_gain" json:"elevation_gain"` // 미터
+	AvgGrade      float64            `bson:"avg_grade" json:"avg_grade"`           // 평균 경사도 (%)
+	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time          `bson:"updated_at" json:"updated_at"`
+
+	// 매칭 후보를 찾기 위한 2dsphere 인덱스 필드 (Points의 첫/마지막 지점)
+	StartLocation GeoJSONPoint `bson:"start_location" json:"-"`
+	EndLocation   GeoJSONPoint `bson:"end_location" json:"-"`
+
+	// 구간 기록 수 (응답 전용)
+	EffortCount int64 `bson:"-" json:"effort_count"`
+}
+
+// SegmentSource 구간을 잘라낸 원본 경로 또는 라이드
+type SegmentSource struct {
+	Type       string             `bson:"type" json:"type"` // SegmentSourceRoute, SegmentSourceRide
+	ID         primitive.ObjectID `bson:"id" json:"id"`
+	StartIndex int                `bson:"start_index" json:"start_index"`
+	EndIndex   int                `bson:"end_index" json:"end_index"` // 포함
+}
+
+// 구간 원본 종류
+const (
+	SegmentSourceRoute = "route"
+	SegmentSourceRide  = "ride"
+)
+
+// SegmentEffort 라이드가 구간을 통과한 기록
+type SegmentEffort struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	SegmentID   primitive.ObjectID `bson:"segment_id" json:"segment_id"`
+	UserID      primitive.ObjectID `bson:"user_id" json:"user_id"`
+	RideID      primitive.ObjectID `bson:"ride_id" json:"ride_id"`
+	StartTime   time.Time          `bson:"start_time" json:"start_time"`     // 구간 진입 시각
+	ElapsedTime time.Duration      `bson:"elapsed_time" json:"elapsed_time"` // 구간 통과 시간
+	Distance    float64            `bson:"distance" json:"distance"`         // 실제 주행 거리 (킬로미터)
+	AvgSpeed    float64            `bson:"avg_speed" json:"avg_speed"`       // km/h
+	StartIndex  int                `bson:"start_index" json:"start_index"`   // Ride.Locations 기준
+	EndIndex    int                `bson:"end_index" json:"end_index"`       // Ride.Locations 기준 (포함)
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// LeaderboardEntry 구간 순위표 항목 (사용자별 최고 기록)
+type LeaderboardEntry struct {
+	Rank     int           `json:"rank"`
+	UserID   string        `json:"user_id"`
+	UserName string        `json:"user_name"`
+	Effort   SegmentEffort `json:"effort"`
+	Attempts int           `json:"attempts"` // 해당 기간의 구간 통과 횟수
+}
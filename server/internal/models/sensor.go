@@ -0,0 +1,191 @@
+package models
+
+import "time"
+
+// SensorStreams 센서 측정값 스트림
+// 각 슬라이스는 Ride.Locations와 같은 길이/인덱스로 정렬되며
+// 해당 시점에 측정값이 없으면 null
+type SensorStreams struct {
+	HeartRate   []*int     `bson:"heart_rate,omitempty" json:"heart_rate,omitempty"`   // bpm
+	Cadence     []*int     `bson:"cadence,omitempty" json:"cadence,omitempty"`         // rpm
+	Power       []*int     `bson:"power,omitempty" json:"power,omitempty"`             // 와트
+	Speed       []*float64 `bson:"speed,omitempty" json:"speed,omitempty"`             // km/h (속도 센서)
+	Temperature []*float64 `bson:"temperature,omitempty" json:"temperature,omitempty"` // 섭씨
+	Balance     []*float64 `bson:"balance,omitempty" json:"balance,omitempty"`         // 왼쪽 다리 파워 비율 (%)
+}
+
+// SensorSample 시각이 붙은 센서 측정값 하나 (실시간 업로드용, 트랙 지점과 따로 전송)
+type SensorSample struct {
+	Time        time.Time `bson:"time" json:"time"`
+	HeartRate   *int      `bson:"heart_rate,omitempty" json:"heart_rate,omitempty"`
+	Cadence     *int      `bson:"cadence,omitempty" json:"cadence,omitempty"`
+	Power       *int      `bson:"power,omitempty" json:"power,omitempty"`
+	Speed       *float64  `bson:"speed,omitempty" json:"speed,omitempty"`
+	Temperature *float64  `bson:"temperature,omitempty" json:"temperature,omitempty"`
+	Balance     *float64  `bson:"balance,omitempty" json:"balance,omitempty"`
+}
+
+// NewSensorStreams 지점 개수 n에 맞춘 빈 스트림 생성
+func NewSensorStreams(n int) *SensorStreams {
+	return &SensorStreams{
+		HeartRate:   make([]*int, n),
+		Cadence:     make([]*int, n),
+		Power:       make([]*int, n),
+		Speed:       make([]*float64, n),
+		Temperature: make([]*float64, n),
+		Balance:     make([]*float64, n),
+	}
+}
+
+// Compact 측정값이 하나도 없는 스트림을 제거
+// 모든 스트림이 비어 있으면 nil 반환
+func (s *SensorStreams) Compact() *SensorStreams {
+	if s == nil {
+		return nil
+	}
+	s.HeartRate = compactValues(s.HeartRate)
+	s.Cadence = compactValues(s.Cadence)
+	s.Power = compactValues(s.Power)
+	s.Speed = compactValues(s.Speed)
+	s.Temperature = compactValues(s.Temperature)
+	s.Balance = compactValues(s.Balance)
+	if s.HeartRate == nil && s.Cadence == nil && s.Power == nil &&
+		s.Speed == nil && s.Temperature == nil && s.Balance == nil {
+		return nil
+	}
+	return s
+}
+
+// Select 지정한 인덱스의 측정값만 남긴 스트림 (트랙에서 지점을 걸러낸 뒤 정렬을 맞춤)
+func (s *SensorStreams) Select(idx []int) *SensorStreams {
+	if s == nil {
+		return nil
+	}
+	return &SensorStreams{
+		HeartRate:   selectValues(s.HeartRate, idx),
+		Cadence:     selectValues(s.Cadence, idx),
+		Power:       selectValues(s.Power, idx),
+		Speed:       selectValues(s.Speed, idx),
+		Temperature: selectValues(s.Temperature, idx),
+		Balance:     selectValues(s.Balance, idx),
+	}
+}
+
+// Aligned 모든 스트림의 길이가 n인지 확인 (없는 스트림은 검사하지 않음)
+func (s *SensorStreams) Aligned(n int) bool {
+	if s == nil {
+		return true
+	}
+	return alignedValues(s.HeartRate, n) && alignedValues(s.Cadence, n) && alignedValues(s.Power, n) &&
+		alignedValues(s.Speed, n) && alignedValues(s.Temperature, n) && alignedValues(s.Balance, n)
+}
+
+// Set i번째 지점의 측정값을 sample 값으로 채움 (sample에 없는 값은 그대로 둠)
+func (s *SensorStreams) Set(i int, sample SensorSample) {
+	setValue(s.HeartRate, i, sample.HeartRate)
+	setValue(s.Cadence, i, sample.Cadence)
+	setValue(s.Power, i, sample.Power)
+	setValue(s.Speed, i, sample.Speed)
+	setValue(s.Temperature, i, sample.Temperature)
+	setValue(s.Balance, i, sample.Balance)
+}
+
+// AlignSamples 시각이 붙은 센서 측정값을 트랙 지점 시각에 맞춘 스트림으로 변환
+// 지점마다 시각이 가장 가까운 측정값을 사용하며, maxGap보다 떨어져 있으면 측정값 없음으로 둠
+// samples는 시간순이어야 하며, 측정값이 하나도 없으면 nil 반환
+func AlignSamples(points []GeoPoint, samples []SensorSample, maxGap time.Duration) *SensorStreams {
+	if len(samples) == 0 {
+		return nil
+	}
+	streams := NewSensorStreams(len(points))
+	j := 0
+	for i, p := range points {
+		if p.Timestamp == nil {
+			continue
+		}
+		t := *p.Timestamp
+		for j+1 < len(samples) && absDuration(samples[j+1].Time.Sub(t)) <= absDuration(samples[j].Time.Sub(t)) {
+			j++
+		}
+		if absDuration(samples[j].Time.Sub(t)) <= maxGap {
+			streams.Set(i, samples[j])
+		}
+	}
+	return streams.Compact()
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+func selectValues[T any](values []*T, idx []int) []*T {
+	if values == nil {
+		return nil
+	}
+	selected := make([]*T, len(idx))
+	for k, i := range idx {
+		selected[k] = values[i]
+	}
+	return selected
+}
+
+// 값이 하나도 없으면 nil
+func compactValues[T any](values []*T) []*T {
+	for _, v := range values {
+		if v != nil {
+			return values
+		}
+	}
+	return nil
+}
+
+func alignedValues[T any](values []*T, n int) bool {
+	return values == nil || len(values) == n
+}
+
+func setValue[T any](values []*T, i int, v *T) {
+	if v != nil && i < len(values) {
+		values[i] = v
+	}
+}
+
+// Thresholds 구간(존)과 강도 계산에 쓰는 라이더의 기준값 (라이드 당시 값으로 라이드마다 저장)
+type Thresholds struct {
+	FTP          int `bson:"ftp,omitempty" json:"ftp,omitempty"`                       // 기능적 역치 파워 (와트)
+	MaxHeartRate int `bson:"max_heart_rate,omitempty" json:"max_heart_rate,omitempty"` // 최대 심박수 (bpm)
+}
+
+// SensorSummary 센서 스트림으로부터 서버에서 계산한 요약 (analysis.ApplyToRide)
+// 평균은 일시정지 구간을 제외한 측정 시간 가중 평균
+type SensorSummary struct {
+	HeartRate   *StreamStats `bson:"heart_rate,omitempty" json:"heart_rate,omitempty"`
+	Cadence     *StreamStats `bson:"cadence,omitempty" json:"cadence,omitempty"` // 페달을 돌리지 않은 0은 평균에서 제외
+	Power       *StreamStats `bson:"power,omitempty" json:"power,omitempty"`
+	Speed       *StreamStats `bson:"speed,omitempty" json:"speed,omitempty"`
+	Temperature *StreamStats `bson:"temperature,omitempty" json:"temperature,omitempty"`
+	Balance     *StreamStats `bson:"balance,omitempty" json:"balance,omitempty"` // 왼쪽 다리 비율
+
+	NormalizedPower float64 `bson:"normalized_power,omitempty" json:"normalized_power,omitempty"` // 와트
+	IntensityFactor float64 `bson:"intensity_factor,omitempty" json:"intensity_factor,omitempty"` // NP / FTP
+
+	HeartRateZones []ZoneTime `bson:"heart_rate_zones,omitempty" json:"heart_rate_zones,omitempty"` // 최대 심박수 기준 5구간
+	PowerZones     []ZoneTime `bson:"power_zones,omitempty" json:"power_zones,omitempty"`           // FTP 기준 7구간
+}
+
+// StreamStats 스트림 하나의 평균/최소/최대
+type StreamStats struct {
+	Avg float64 `bson:"avg" json:"avg"`
+	Min float64 `bson:"min" json:"min"`
+	Max float64 `bson:"max" json:"max"`
+}
+
+// ZoneTime 구간 하나에 머문 시간
+type ZoneTime struct {
+	Zone int           `bson:"zone" json:"zone"` // 1부터
+	Min  int           `bson:"min" json:"min"`   // 구간 하한 (bpm 또는 와트, 포함)
+	Max  int           `bson:"max" json:"max"`   // 구간 상한 (미포함, 마지막 구간은 0)
+	Time time.Duration `bson:"time" json:"time"`
+}
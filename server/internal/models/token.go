@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RefreshToken 서버에 저장되는 리프레시 토큰
+// 토큰 원문은 저장하지 않고 SHA-256 해시만 보관
+// 같은 로그인에서 회전(rotate)된 토큰들은 동일한 FamilyID를 가짐
+type RefreshToken struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID        primitive.ObjectID `bson:"user_id" json:"user_id"`
+	FamilyID      primitive.ObjectID `bson:"family_id" json:"family_id"`
+	TokenHash     string             `bson:"token_hash" json:"-"`
+	UserAgent     string             `bson:"user_agent" json:"user_agent"`
+	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
+	ExpiresAt     time.Time          `bson:"expires_at" json:"expires_at"`
+	Revoked       bool               `bson:"revoked" json:"revoked"`
+	RevokedAt     time.Time          `bson:"revoked_at,omitempty" json:"revoked_at"`
+	RevokedReason string             `bson:"revoked_reason,omitempty" json:"revoked_reason"`
+	ReplacedBy    primitive.ObjectID `bson:"replaced_by,omitempty" json:"replaced_by"`
+}
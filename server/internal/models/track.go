@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 라이드 트랙 종류
+const (
+	TrackKindClean = "clean" // 정제한 트랙 (Ride.Locations, Ride.Streams)
+	TrackKindRaw   = "raw"   // 정제 전 입력 트랙 (Ride.RawLocations)
+)
+
+// TrackChunk 라이드 트랙의 연속한 지점 묶음 (ride_tracks 컬렉션)
+// 지점 필드와 센서 스트림을 열 단위 배열로 저장하며, 묶음 안에 값이 하나도 없는 열은 생략
+type TrackChunk struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"-"`
+	RideID    primitive.ObjectID `bson:"ride_id" json:"ride_id"`
+	Kind      string             `bson:"kind" json:"kind"`   // TrackKind*
+	Seq       int                `bson:"seq" json:"seq"`     // 묶음 순서 (0부터)
+	Start     int                `bson:"start" json:"start"` // 첫 지점의 트랙 내 인덱스
+	Count     int                `bson:"count" json:"count"` // 지점 수
+	StartTime *time.Time         `bson:"start_time,omitempty" json:"start_time,omitempty"`
+	EndTime   *time.Time         `bson:"end_time,omitempty" json:"end_time,omitempty"`
+
+	Latitude    []float64    `bson:"lat" json:"lat"`
+	Longitude   []float64    `bson:"lon" json:"lon"`
+	Elevation   []*float64   `bson:"ele,omitempty" json:"ele,omitempty"`
+	Time        []*time.Time `bson:"time,omitempty" json:"time,omitempty"`
+	Accuracy    []*float64   `bson:"acc,omitempty" json:"acc,omitempty"`
+	HeartRate   []*int       `bson:"hr,omitempty" json:"hr,omitempty"`
+	Cadence     []*int       `bson:"cad,omitempty" json:"cad,omitempty"`
+	Power       []*int       `bson:"power,omitempty" json:"power,omitempty"`
+	Speed       []*float64   `bson:"speed,omitempty" json:"speed,omitempty"`
+	Temperature []*float64   `bson:"temp,omitempty" json:"temp,omitempty"`
+	Balance     []*float64   `bson:"bal,omitempty" json:"bal,omitempty"`
+}
@@ -7,7 +7,7 @@ import (
 )
 
 type User struct {
-	ID           primitive.ObjectID `bson:"_id" json:"_id"`
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
 	Email        string             `bson:"email" json:"email"`
 	Password     string             `bson:"password" json:"password"`
 	Name         string             `bson:"name" json:"name"`
@@ -20,6 +20,42 @@ type User struct {
 	LastLoginAt  time.Time          `bson:"last_login_at" json:"last_login_at"`
 	Status       string             `bson:"status" json:"status"`
 	Role         string             `bson:"role" json:"role"`
+
+	// 관리자가 비밀번호 재설정을 강제한 경우 true
+	// 비밀번호를 변경하기 전까지 다른 API 사용이 제한됨
+	PasswordResetRequired bool `bson:"password_reset_required" json:"password_reset_required"`
+}
+
+// 사용자 역할
+const (
+	RoleRider     = "rider"      // 일반 라이더
+	RoleClubAdmin = "club_admin" // 클럽 관리자
+	RoleModerator = "moderator"  // 운영자 (사용자 조회/정지)
+	RoleAdmin     = "admin"      // 전체 관리자
+)
+
+// 사용자 상태
+const (
+	UserStatusActive    = "active"
+	UserStatusSuspended = "suspended"
+)
+
+// IsValidRole 정의된 역할인지 확인
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleRider, RoleClubAdmin, RoleModerator, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+// IsValidUserStatus 정의된 사용자 상태인지 확인
+func IsValidUserStatus(status string) bool {
+	switch status {
+	case UserStatusActive, UserStatusSuspended:
+		return true
+	}
+	return false
 }
 
 type Subscription struct {
@@ -0,0 +1,334 @@
+// osm 패키지는 OpenStreetMap PBF(.osm.pbf) 추출 파일에서 노드와 웨이를 읽습니다.
+// 무압축/zlib 블롭과 DenseNodes를 지원하며 관계(relation)는 읽지 않습니다.
+package osm
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// PBF 블록 크기 상한 (사양 권장값)
+const (
+	maxBlobHeaderSize = 64 << 10
+	maxBlobSize       = 32 << 20
+)
+
+// 읽을 수 있는 필수 기능 (HeaderBlock.required_features)
+var supportedFeatures = map[string]bool{
+	"OsmSchema-V0.6": true,
+	"DenseNodes":     true,
+}
+
+// Node 위치가 있는 노드
+type Node struct {
+	ID        int64
+	Latitude  float64
+	Longitude float64
+}
+
+// Way 노드를 순서대로 이은 웨이
+type Way struct {
+	ID    int64
+	Tags  map[string]string
+	Nodes []int64
+}
+
+// Handler 읽은 요소를 받는 콜백 (nil인 요소는 해석하지 않고 건너뜀)
+type Handler struct {
+	Node func(Node)
+	Way  func(Way)
+}
+
+// Scan PBF 파일을 처음부터 끝까지 읽으며 노드와 웨이를 Handler로 전달
+func Scan(r io.Reader, h Handler) error {
+	headerSeen := false
+	sizeBuf := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(r, sizeBuf); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("read blob header size: %w", err)
+		}
+		size := binary.BigEndian.Uint32(sizeBuf)
+		if size > maxBlobHeaderSize {
+			return fmt.Errorf("blob header too large: %d bytes", size)
+		}
+
+		headerBuf := make([]byte, size)
+		if _, err := io.ReadFull(r, headerBuf); err != nil {
+			return fmt.Errorf("read blob header: %w", err)
+		}
+		blobType, dataSize, err := parseBlobHeader(headerBuf)
+		if err != nil {
+			return err
+		}
+		if dataSize > maxBlobSize {
+			return fmt.Errorf("blob too large: %d bytes", dataSize)
+		}
+
+		blobBuf := make([]byte, dataSize)
+		if _, err := io.ReadFull(r, blobBuf); err != nil {
+			return fmt.Errorf("read blob: %w", err)
+		}
+
+		switch blobType {
+		case "OSMHeader":
+			data, err := readBlob(blobBuf)
+			if err != nil {
+				return err
+			}
+			if err := checkHeader(data); err != nil {
+				return err
+			}
+			headerSeen = true
+		case "OSMData":
+			if !headerSeen {
+				return fmt.Errorf("OSMData block before OSMHeader")
+			}
+			data, err := readBlob(blobBuf)
+			if err != nil {
+				return err
+			}
+			if err := readPrimitiveBlock(data, h); err != nil {
+				return err
+			}
+		}
+		// 알 수 없는 블록은 사양에 따라 건너뜀
+	}
+}
+
+// BlobHeader: 1 type, 2 indexdata, 3 datasize
+func parseBlobHeader(buf []byte) (blobType string, dataSize int, err error) {
+	err = eachField(buf, func(f field) error {
+		switch f.num {
+		case 1:
+			blobType = string(f.data)
+		case 3:
+			dataSize = int(f.value)
+		}
+		return nil
+	})
+	if err == nil && blobType == "" {
+		err = fmt.Errorf("blob header has no type")
+	}
+	return blobType, dataSize, err
+}
+
+// Blob: 1 raw, 2 raw_size, 3 zlib_data (그 밖의 압축 방식은 지원하지 않음)
+func readBlob(buf []byte) ([]byte, error) {
+	var raw, compressed []byte
+	var rawSize int
+	unsupported := 0
+	err := eachField(buf, func(f field) error {
+		switch f.num {
+		case 1:
+			raw = f.data
+		case 2:
+			rawSize = int(f.value)
+		case 3:
+			compressed = f.data
+		case 4, 5, 6, 7:
+			unsupported = f.num
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("parse blob: %w", err)
+	}
+
+	switch {
+	case raw != nil:
+		return raw, nil
+	case compressed != nil:
+		if rawSize > maxBlobSize {
+			return nil, fmt.Errorf("blob too large: %d bytes", rawSize)
+		}
+		zr, err := zlib.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return nil, fmt.Errorf("inflate blob: %w", err)
+		}
+		defer zr.Close()
+		data := make([]byte, 0, rawSize)
+		out := bytes.NewBuffer(data)
+		if _, err := io.Copy(out, io.LimitReader(zr, maxBlobSize+1)); err != nil {
+			return nil, fmt.Errorf("inflate blob: %w", err)
+		}
+		if out.Len() > maxBlobSize {
+			return nil, fmt.Errorf("blob too large")
+		}
+		return out.Bytes(), nil
+	case unsupported != 0:
+		return nil, fmt.Errorf("unsupported blob compression (field %d)", unsupported)
+	}
+	return nil, fmt.Errorf("empty blob")
+}
+
+// HeaderBlock: 4 required_features
+func checkHeader(buf []byte) error {
+	return eachField(buf, func(f field) error {
+		if f.num == 4 && !supportedFeatures[string(f.data)] {
+			return fmt.Errorf("unsupported pbf feature %q", string(f.data))
+		}
+		return nil
+	})
+}
+
+// 좌표 복원에 필요한 PrimitiveBlock 값
+type block struct {
+	strings     [][]byte
+	granularity int64
+	latOffset   int64
+	lonOffset   int64
+}
+
+func (b *block) coord(lat, lon int64) (float64, float64) {
+	return 1e-9 * float64(b.latOffset+b.granularity*lat),
+		1e-9 * float64(b.lonOffset+b.granularity*lon)
+}
+
+func (b *block) str(i uint64) string {
+	if i < uint64(len(b.strings)) {
+		return string(b.strings[i])
+	}
+	return ""
+}
+
+// PrimitiveBlock: 1 stringtable, 2 primitivegroup, 17 granularity, 19 lat_offset, 20 lon_offset
+// 문자열 테이블과 좌표 설정을 먼저 읽은 뒤 그룹을 해석
+func readPrimitiveBlock(buf []byte, h Handler) error {
+	b := &block{granularity: 100}
+	var groups [][]byte
+	err := eachField(buf, func(f field) error {
+		switch f.num {
+		case 1:
+			return eachField(f.data, func(s field) error {
+				if s.num == 1 {
+					b.strings = append(b.strings, s.data)
+				}
+				return nil
+			})
+		case 2:
+			groups = append(groups, f.data)
+		case 17:
+			b.granularity = int64(f.value)
+		case 19:
+			b.latOffset = int64(f.value)
+		case 20:
+			b.lonOffset = int64(f.value)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("parse primitive block: %w", err)
+	}
+
+	for _, g := range groups {
+		// PrimitiveGroup: 1 nodes, 2 dense, 3 ways
+		err := eachField(g, func(f field) error {
+			switch {
+			case f.num == 1 && h.Node != nil:
+				return readNode(b, f.data, h.Node)
+			case f.num == 2 && h.Node != nil:
+				return readDenseNodes(b, f.data, h.Node)
+			case f.num == 3 && h.Way != nil:
+				return readWay(b, f.data, h.Way)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("parse primitive group: %w", err)
+		}
+	}
+	return nil
+}
+
+// Node: 1 id, 8 lat, 9 lon
+func readNode(b *block, buf []byte, fn func(Node)) error {
+	var id, lat, lon int64
+	err := eachField(buf, func(f field) error {
+		switch f.num {
+		case 1:
+			id = zigzag(f.value)
+		case 8:
+			lat = zigzag(f.value)
+		case 9:
+			lon = zigzag(f.value)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	n := Node{ID: id}
+	n.Latitude, n.Longitude = b.coord(lat, lon)
+	fn(n)
+	return nil
+}
+
+// DenseNodes: 1 id, 8 lat, 9 lon (모두 델타 인코딩)
+func readDenseNodes(b *block, buf []byte, fn func(Node)) error {
+	var ids, lats, lons []int64
+	err := eachField(buf, func(f field) error {
+		var err error
+		switch f.num {
+		case 1:
+			ids, err = packedDeltas(f)
+		case 8:
+			lats, err = packedDeltas(f)
+		case 9:
+			lons, err = packedDeltas(f)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if len(lats) != len(ids) || len(lons) != len(ids) {
+		return fmt.Errorf("dense nodes have mismatched lengths")
+	}
+
+	for i, id := range ids {
+		n := Node{ID: id}
+		n.Latitude, n.Longitude = b.coord(lats[i], lons[i])
+		fn(n)
+	}
+	return nil
+}
+
+// Way: 1 id, 2 keys, 3 vals, 8 refs (델타 인코딩)
+func readWay(b *block, buf []byte, fn func(Way)) error {
+	var w Way
+	var keys, vals []uint64
+	err := eachField(buf, func(f field) error {
+		var err error
+		switch f.num {
+		case 1:
+			w.ID = int64(f.value)
+		case 2:
+			keys, err = packedUvarints(f)
+		case 3:
+			vals, err = packedUvarints(f)
+		case 8:
+			w.Nodes, err = packedDeltas(f)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if len(keys) != len(vals) {
+		return fmt.Errorf("way %d has mismatched tags", w.ID)
+	}
+
+	w.Tags = make(map[string]string, len(keys))
+	for i, k := range keys {
+		w.Tags[b.str(k)] = b.str(vals[i])
+	}
+	fn(w)
+	return nil
+}
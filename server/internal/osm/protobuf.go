@@ -0,0 +1,109 @@
+package osm
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// 프로토콜 버퍼 와이어 타입
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// field 메시지의 필드 하나
+// varint/fixed 타입은 value에, 길이 구분 타입은 data에 값이 담김
+type field struct {
+	num   int
+	wire  int
+	value uint64
+	data  []byte
+}
+
+// 프로토콜 버퍼 메시지의 필드를 순서대로 읽음
+func eachField(buf []byte, fn func(f field) error) error {
+	for len(buf) > 0 {
+		key, n := binary.Uvarint(buf)
+		if n <= 0 {
+			return fmt.Errorf("invalid field key")
+		}
+		buf = buf[n:]
+
+		f := field{num: int(key >> 3), wire: int(key & 7)}
+		switch f.wire {
+		case wireVarint:
+			f.value, n = binary.Uvarint(buf)
+			if n <= 0 {
+				return fmt.Errorf("invalid varint in field %d", f.num)
+			}
+			buf = buf[n:]
+		case wireFixed64:
+			if len(buf) < 8 {
+				return fmt.Errorf("truncated field %d", f.num)
+			}
+			f.value = binary.LittleEndian.Uint64(buf)
+			buf = buf[8:]
+		case wireBytes:
+			length, n := binary.Uvarint(buf)
+			if n <= 0 || length > uint64(len(buf)-n) {
+				return fmt.Errorf("invalid length in field %d", f.num)
+			}
+			f.data = buf[n : n+int(length)]
+			buf = buf[n+int(length):]
+		case wireFixed32:
+			if len(buf) < 4 {
+				return fmt.Errorf("truncated field %d", f.num)
+			}
+			f.value = uint64(binary.LittleEndian.Uint32(buf))
+			buf = buf[4:]
+		default:
+			return fmt.Errorf("unsupported wire type %d in field %d", f.wire, f.num)
+		}
+
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 지그재그 인코딩된 sint64
+func zigzag(v uint64) int64 {
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+// packed repeated varint 필드 (값이 하나뿐이면 packed가 아닐 수도 있음)
+func packedUvarints(f field) ([]uint64, error) {
+	if f.wire == wireVarint {
+		return []uint64{f.value}, nil
+	}
+
+	values := make([]uint64, 0, len(f.data))
+	buf := f.data
+	for len(buf) > 0 {
+		v, n := binary.Uvarint(buf)
+		if n <= 0 {
+			return nil, fmt.Errorf("invalid packed varint in field %d", f.num)
+		}
+		values = append(values, v)
+		buf = buf[n:]
+	}
+	return values, nil
+}
+
+// packed repeated sint64 필드를 델타 복원하여 반환 (DenseNodes, Way.refs)
+func packedDeltas(f field) ([]int64, error) {
+	raw, err := packedUvarints(f)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]int64, len(raw))
+	var acc int64
+	for i, v := range raw {
+		acc += zigzag(v)
+		values[i] = acc
+	}
+	return values, nil
+}
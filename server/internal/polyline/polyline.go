@@ -0,0 +1,120 @@
+// polyline 패키지는 Google 인코딩 폴리라인 형식으로 트랙을 변환합니다.
+//
+// 위경도를 10^precision 배한 정수의 차이를 5비트씩 ASCII 문자로 인코딩하며,
+// precision 5(Google 지도)와 6(OSRM, Valhalla 등)을 지원합니다.
+package polyline
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+// 지원하는 정밀도 (소수점 아래 자릿수)
+const (
+	Precision5 = 5
+	Precision6 = 6
+)
+
+// ValidPrecision 지원하는 정밀도인지 확인
+func ValidPrecision(precision int) bool {
+	return precision == Precision5 || precision == Precision6
+}
+
+func factor(precision int) float64 {
+	return math.Pow10(precision)
+}
+
+// Encode 지점 목록을 인코딩 폴리라인으로 변환 (위경도만 사용)
+// 지원하지 않는 정밀도는 Precision5로 인코딩
+func Encode(points []models.GeoPoint, precision int) string {
+	if !ValidPrecision(precision) {
+		precision = Precision5
+	}
+	f := factor(precision)
+
+	var b strings.Builder
+	b.Grow(len(points) * 8)
+	var prevLat, prevLon int64
+	for _, p := range points {
+		lat := int64(math.Round(p.Latitude * f))
+		lon := int64(math.Round(p.Longitude * f))
+		encodeValue(&b, lat-prevLat)
+		encodeValue(&b, lon-prevLon)
+		prevLat, prevLon = lat, lon
+	}
+	return b.String()
+}
+
+func encodeValue(b *strings.Builder, v int64) {
+	u := uint64(v) << 1
+	if v < 0 {
+		u = ^u
+	}
+	for u >= 0x20 {
+		b.WriteByte(byte(0x20|(u&0x1f)) + 63)
+		u >>= 5
+	}
+	b.WriteByte(byte(u) + 63)
+}
+
+// Decode 인코딩 폴리라인을 지점 목록으로 변환
+// 문자열이 중간에 끊겼거나 허용 범위 밖의 문자가 있으면 오류
+func Decode(s string, precision int) ([]models.GeoPoint, error) {
+	if !ValidPrecision(precision) {
+		return nil, fmt.Errorf("unsupported precision %d", precision)
+	}
+	f := factor(precision)
+
+	var points []models.GeoPoint
+	var lat, lon int64
+	for pos := 0; pos < len(s); {
+		dLat, next, err := decodeValue(s, pos)
+		if err != nil {
+			return nil, err
+		}
+		dLon, next, err := decodeValue(s, next)
+		if err != nil {
+			return nil, err
+		}
+		pos = next
+
+		lat += dLat
+		lon += dLon
+		points = append(points, models.GeoPoint{
+			Latitude:  float64(lat) / f,
+			Longitude: float64(lon) / f,
+		})
+	}
+	return points, nil
+}
+
+// pos부터 값 하나를 읽고 다음 위치를 반환
+func decodeValue(s string, pos int) (int64, int, error) {
+	var u uint64
+	for shift := uint(0); ; shift += 5 {
+		if pos >= len(s) {
+			return 0, pos, fmt.Errorf("polyline truncated at %d", pos)
+		}
+		if shift > 60 {
+			return 0, pos, fmt.Errorf("polyline value too long at %d", pos)
+		}
+		c := s[pos]
+		if c < 63 || c > 126 {
+			return 0, pos, fmt.Errorf("invalid polyline character %q at %d", c, pos)
+		}
+		pos++
+		chunk := uint64(c - 63)
+		u |= (chunk & 0x1f) << shift
+		if chunk < 0x20 {
+			break
+		}
+	}
+	v := int64(u >> 1)
+	if u&1 != 0 {
+		v = ^v
+	}
+	return v, pos, nil
+}
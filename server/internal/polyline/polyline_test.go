@@ -0,0 +1,114 @@
+package polyline
+
+import (
+	"math"
+	"testing"
+
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+// Google 인코딩 폴리라인 문서의 예시
+const googleReference = "_p~iF~ps|U_ulLnnqC_mqNvxq`@"
+
+var googlePoints = []models.GeoPoint{
+	{Latitude: 38.5, Longitude: -120.2},
+	{Latitude: 40.7, Longitude: -120.95},
+	{Latitude: 43.252, Longitude: -126.453},
+}
+
+func samePoints(t *testing.T, got, want []models.GeoPoint, precision int) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("points = %d, want %d", len(got), len(want))
+	}
+	tolerance := 0.5/math.Pow10(precision) + 1e-12 // 반올림 오차
+	for i := range want {
+		if math.Abs(got[i].Latitude-want[i].Latitude) > tolerance ||
+			math.Abs(got[i].Longitude-want[i].Longitude) > tolerance {
+			t.Errorf("point %d = (%v, %v), want (%v, %v)", i,
+				got[i].Latitude, got[i].Longitude, want[i].Latitude, want[i].Longitude)
+		}
+	}
+}
+
+func TestGoogleReference(t *testing.T) {
+	if got := Encode(googlePoints, Precision5); got != googleReference {
+		t.Errorf("Encode = %q, want %q", got, googleReference)
+	}
+	points, err := Decode(googleReference, Precision5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	samePoints(t, points, googlePoints, Precision5)
+}
+
+func TestRoundTrip(t *testing.T) {
+	tracks := map[string][]models.GeoPoint{
+		"negative": {
+			{Latitude: -33.8688, Longitude: 151.2093},
+			{Latitude: -34.0001, Longitude: 150.99999},
+			{Latitude: -0.000001, Longitude: -0.000004},
+			{Latitude: 0, Longitude: 0},
+		},
+		"antimeridian": {
+			{Latitude: 65.1234, Longitude: 179.99999},
+			{Latitude: 65.2, Longitude: -179.99999},
+			{Latitude: 65.3, Longitude: 179.5},
+			{Latitude: -89.999999, Longitude: -180},
+			{Latitude: 89.999999, Longitude: 180},
+		},
+		"single": {{Latitude: 37.566535, Longitude: 126.977969}},
+	}
+	for name, points := range tracks {
+		for _, precision := range []int{Precision5, Precision6} {
+			encoded := Encode(points, precision)
+			decoded, err := Decode(encoded, precision)
+			if err != nil {
+				t.Fatalf("%s/%d: %v", name, precision, err)
+			}
+			samePoints(t, decoded, points, precision)
+		}
+	}
+}
+
+func TestPrecision(t *testing.T) {
+	p := []models.GeoPoint{{Latitude: 37.123456, Longitude: 127.654321}}
+
+	// precision 6 문자열을 precision 5로 읽으면 10배가 됨
+	decoded, err := Decode(Encode(p, Precision6), Precision5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(decoded[0].Latitude-371.23456) > 1e-5 {
+		t.Errorf("latitude = %v, want 371.23456", decoded[0].Latitude)
+	}
+
+	if Encode(p, 7) != Encode(p, Precision5) {
+		t.Error("unsupported precision should encode as precision 5")
+	}
+	if _, err := Decode("??", 7); err == nil {
+		t.Error("Decode accepted unsupported precision")
+	}
+	if got := Encode(nil, Precision5); got != "" {
+		t.Errorf("Encode(nil) = %q", got)
+	}
+	if points, err := Decode("", Precision5); err != nil || len(points) != 0 {
+		t.Errorf("Decode(\"\") = %v, %v", points, err)
+	}
+}
+
+func TestDecodeRejectsInvalidInput(t *testing.T) {
+	tests := map[string]string{
+		"truncated value":     googleReference[:len(googleReference)-1],
+		"missing longitude":   "_p~iF",
+		"continuation at end": "_p~iF~ps|U_",
+		"character below 63":  "_p~iF~ps|U ",
+		"character above 126": "_p~iF\x7f",
+		"value too long":      "~~~~~~~~~~~~~~~?",
+	}
+	for name, s := range tests {
+		if _, err := Decode(s, Precision5); err == nil {
+			t.Errorf("%s: Decode(%q) accepted invalid input", name, s)
+		}
+	}
+}
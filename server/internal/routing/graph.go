@@ -0,0 +1,226 @@
+// routing 패키지는 OpenStreetMap 도로망으로 자전거 경로를 탐색합니다.
+// 도로 종류, 자전거 시설, 노면, 경사(DEM)를 반영한 비용으로 A* 탐색을 수행합니다.
+package routing
+
+import (
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/elevation"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/chrisS41/gobike-server/internal/osm"
+)
+
+// 공간 색인 격자 크기 (도)
+const cellSize = 0.01
+
+// Graph 자전거가 다닐 수 있는 도로망
+// 간선은 출발 노드별로 모아 저장 (first[i]부터 first[i+1] 전까지가 노드 i의 간선)
+type Graph struct {
+	lat, lon []float64
+	ele      []float64 // 고도 (미터, DEM이 없으면 NaN)
+	first    []int32
+	edges    []edge
+	cells    map[[2]int32][]int32 // 격자별 노드 (간선이 있는 노드만)
+	names    []string             // 도로 이름 (0번은 이름 없음)
+}
+
+type edge struct {
+	to      int32
+	length  float32 // 미터
+	factor  float32 // 도로 종류 계수 (wayFactor)
+	surface float32 // 노면 계수 (surfaceFactor)
+	grade   float32 // 경사도 (%, 고도를 모르면 0)
+	name    int32   // 도로 이름 (names 인덱스)
+}
+
+// 불러오는 중 모아 두는 웨이 (노드 위치를 읽기 전)
+type wayInfo struct {
+	nodes   []int32
+	factor  float64
+	surface float64
+	dir     direction
+	name    int32
+}
+
+// Load PBF 추출 파일로 도로망 생성
+// 웨이를 먼저 읽어 필요한 노드를 정한 뒤, 파일을 다시 읽어 그 노드의 위치만 저장
+// dem이 있으면 노드 고도를 채워 경사 비용에 반영
+func Load(path string, dem *elevation.Service) (*Graph, error) {
+	index := make(map[int64]int32)
+	names := []string{""}
+	nameIndex := map[string]int32{"": 0}
+	var ways []wayInfo
+	err := scanFile(path, osm.Handler{Way: func(w osm.Way) {
+		factor, dir, ok := wayFactor(w.Tags)
+		if !ok || len(w.Nodes) < 2 {
+			return
+		}
+		info := wayInfo{nodes: make([]int32, len(w.Nodes)), factor: factor, surface: surfaceFactor(w.Tags), dir: dir}
+		name := wayName(w.Tags)
+		if k, ok := nameIndex[name]; ok {
+			info.name = k
+		} else {
+			info.name = int32(len(names))
+			nameIndex[name] = info.name
+			names = append(names, name)
+		}
+		for i, id := range w.Nodes {
+			n, ok := index[id]
+			if !ok {
+				n = int32(len(index))
+				index[id] = n
+			}
+			info.nodes[i] = n
+		}
+		ways = append(ways, info)
+	}})
+	if err != nil {
+		return nil, err
+	}
+	if len(ways) == 0 {
+		return nil, fmt.Errorf("no cycleable ways in %s", path)
+	}
+
+	g := &Graph{
+		lat:   make([]float64, len(index)),
+		lon:   make([]float64, len(index)),
+		ele:   make([]float64, len(index)),
+		cells: make(map[[2]int32][]int32),
+		names: names,
+	}
+	found := make([]bool, len(index))
+	err = scanFile(path, osm.Handler{Node: func(n osm.Node) {
+		if i, ok := index[n.ID]; ok {
+			g.lat[i], g.lon[i] = n.Latitude, n.Longitude
+			found[i] = true
+		}
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range g.ele {
+		g.ele[i] = math.NaN()
+		if v, ok := dem.Lookup(g.lat[i], g.lon[i]); found[i] && ok {
+			g.ele[i] = v
+		}
+	}
+
+	g.build(ways, found)
+	return g, nil
+}
+
+func scanFile(path string, h osm.Handler) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := osm.Scan(f, h); err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	return nil
+}
+
+// 웨이의 연속한 두 노드마다 통행 방향으로 간선을 만들고 노드별로 정렬
+// 추출 범위 밖이라 위치가 없는 노드에 닿는 구간은 제외
+func (g *Graph) build(ways []wayInfo, found []bool) {
+	type pending struct {
+		from int32
+		edge edge
+	}
+	var all []pending
+	degree := make([]int32, len(g.lat)+1)
+	add := func(from, to int32, length float64, w wayInfo) {
+		e := edge{to: to, length: float32(length), factor: float32(w.factor), surface: float32(w.surface), name: w.name}
+		if length > 0 && !math.IsNaN(g.ele[from]) && !math.IsNaN(g.ele[to]) {
+			e.grade = float32(math.Max(-maxGrade, math.Min(maxGrade, (g.ele[to]-g.ele[from])/length*100)))
+		}
+		all = append(all, pending{from, e})
+		degree[from+1]++
+	}
+
+	for _, w := range ways {
+		for k := 1; k < len(w.nodes); k++ {
+			a, b := w.nodes[k-1], w.nodes[k]
+			if !found[a] || !found[b] || a == b {
+				continue
+			}
+			length := analysis.Haversine(g.position(a), g.position(b))
+			if w.dir.forward {
+				add(a, b, length, w)
+			}
+			if w.dir.backward {
+				add(b, a, length, w)
+			}
+		}
+	}
+
+	g.first = make([]int32, len(g.lat)+1)
+	for i := 1; i < len(degree); i++ {
+		g.first[i] = g.first[i-1] + degree[i]
+	}
+	g.edges = make([]edge, len(all))
+	next := make([]int32, len(g.lat))
+	copy(next, g.first)
+	for _, p := range all {
+		g.edges[next[p.from]] = p.edge
+		next[p.from]++
+	}
+
+	for i := range g.lat {
+		if g.first[i+1] > g.first[i] {
+			key := cellKey(g.lat[i], g.lon[i])
+			g.cells[key] = append(g.cells[key], int32(i))
+		}
+	}
+}
+
+// Nodes 도로망의 노드 수
+func (g *Graph) Nodes() int {
+	return len(g.lat)
+}
+
+// Edges 도로망의 간선 수 (방향별)
+func (g *Graph) Edges() int {
+	return len(g.edges)
+}
+
+// 노드 위치 (고도 제외)
+func (g *Graph) position(i int32) models.GeoPoint {
+	return models.GeoPoint{Latitude: g.lat[i], Longitude: g.lon[i]}
+}
+
+// 노드 위치와 고도
+func (g *Graph) point(i int32) models.GeoPoint {
+	p := models.GeoPoint{Latitude: g.lat[i], Longitude: g.lon[i]}
+	if !math.IsNaN(g.ele[i]) {
+		ele := math.Round(g.ele[i]*10) / 10
+		p.Elevation = &ele
+	}
+	return p
+}
+
+func cellKey(lat, lon float64) [2]int32 {
+	return [2]int32{int32(math.Floor(lat / cellSize)), int32(math.Floor(lon / cellSize))}
+}
+
+// 지점에서 maxDistance(미터) 안의 가장 가까운 노드 (간선이 있는 노드만)
+func (g *Graph) nearest(p models.GeoPoint, maxDistance float64) (int32, bool) {
+	center := cellKey(p.Latitude, p.Longitude)
+	best, bestDistance := int32(-1), maxDistance
+	for dLat := int32(-1); dLat <= 1; dLat++ {
+		for dLon := int32(-1); dLon <= 1; dLon++ {
+			for _, i := range g.cells[[2]int32{center[0] + dLat, center[1] + dLon}] {
+				if d := analysis.Haversine(p, g.position(i)); d <= bestDistance {
+					best, bestDistance = i, d
+				}
+			}
+		}
+	}
+	return best, best >= 0
+}
@@ -0,0 +1,307 @@
+package routing
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+// 경사 선호
+const (
+	ElevationAny   = ""      // 기본 비용 (오르막을 약간 피함)
+	ElevationFlat  = "flat"  // 오르막을 강하게 피함
+	ElevationHilly = "hilly" // 오르막을 선호
+)
+
+// 노면 선호
+const (
+	SurfaceAny     = ""        // 기본 비용 (비포장을 약간 피함)
+	SurfacePaved   = "paved"   // 비포장을 강하게 피함
+	SurfaceUnpaved = "unpaved" // 비포장(그래블)을 선호
+)
+
+const (
+	// 순환 경로 목표 거리 범위 (미터)
+	MinLoopDistance = 5000.0
+	MaxLoopDistance = 200000.0
+	// 반환하는 후보 수 기본값과 상한
+	DefaultLoopCount = 3
+	MaxLoopCount     = 5
+
+	// 후보를 만들 방향 수 (시작 지점에서 균등한 방위)
+	loopBearings = 8
+	// 방향마다 목표 거리에 맞추려고 반경을 조정하는 최대 횟수
+	loopAttempts = 3
+	// 실제 거리가 목표와 이 비율 이내이면 조정을 멈춤
+	loopTolerance = 0.1
+	// 도로 거리 / 경유지를 잇는 직선 거리의 초기 추정값
+	loopDetour = 1.3
+	// 이미 지난 도로를 다시 지날 때의 비용 배수
+	reusePenalty = 4.0
+	// 다른 후보와 이 비율 이상 겹치는 후보는 제외
+	maxLoopSimilarity = 0.5
+	// 선호에 따른 계수 (hilly 오르막, unpaved 비포장, paved 비포장)
+	hillyMinFactor   = 0.5
+	unpavedPreferred = 0.6
+	unpavedAvoided   = 2.0
+)
+
+var (
+	ErrInvalidPreference = errors.New("invalid elevation or surface preference")
+	ErrLoopDistance      = errors.New("loop distance out of range")
+	ErrNoLoop            = errors.New("no loop found")
+)
+
+// LoopOptions 순환 경로 조건
+type LoopOptions struct {
+	Start     models.GeoPoint
+	Distance  float64 // 목표 거리 (미터)
+	Elevation string  // Elevation*
+	Surface   string  // Surface*
+	Count     int     // 후보 수 (0이면 DefaultLoopCount)
+}
+
+// Loop 순환 경로 후보
+type Loop struct {
+	Path
+	Overlap float64 // 같은 도로를 다시 지나는 거리의 비율 (0~1)
+	Bearing float64 // 시작 지점에서 본 경로 중심의 방위 (도)
+
+	edges map[[2]int32]bool
+}
+
+// Loops 시작 지점으로 돌아오는 순환 경로 후보
+// 시작 지점을 꼭짓점으로 하는 정삼각형의 나머지 두 꼭짓점을 경유하도록 방위마다 경로를 만들고,
+// 실제 거리가 목표에 가까워지도록 삼각형 크기를 조정
+// 같은 도로를 다시 지나지 않도록 이미 지난 도로에 비용을 더하며, 목표 거리에 가깝고 겹침이 적은 순으로 반환
+func (g *Graph) Loops(opts LoopOptions) ([]Loop, error) {
+	cost, floor, err := loopCost(opts.Elevation, opts.Surface)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Distance < MinLoopDistance || opts.Distance > MaxLoopDistance {
+		return nil, ErrLoopDistance
+	}
+	count := opts.Count
+	if count <= 0 {
+		count = DefaultLoopCount
+	}
+	count = min(count, MaxLoopCount)
+
+	start, ok := g.nearest(opts.Start, MaxSnapDistance)
+	if !ok {
+		return nil, ErrNoRoad
+	}
+
+	var candidates []Loop
+	for b := 0; b < loopBearings; b++ {
+		bearing := float64(b) * 360 / loopBearings
+		// 정삼각형 둘레는 외접원 반지름의 3√3배
+		radius := opts.Distance / (3 * math.Sqrt(3) * loopDetour)
+
+		var best *Loop
+		for attempt := 0; attempt < loopAttempts; attempt++ {
+			loop, ok := g.loop(start, bearing, radius, cost, floor)
+			if !ok {
+				break
+			}
+			if best == nil || math.Abs(loop.Distance-opts.Distance) < math.Abs(best.Distance-opts.Distance) {
+				best = loop
+			}
+			if math.Abs(loop.Distance-opts.Distance) <= opts.Distance*loopTolerance {
+				break
+			}
+			radius *= opts.Distance / loop.Distance
+		}
+		if best != nil {
+			candidates = append(candidates, *best)
+		}
+	}
+
+	score := func(l Loop) float64 {
+		return math.Abs(l.Distance-opts.Distance)/opts.Distance + l.Overlap
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return score(candidates[i]) < score(candidates[j])
+	})
+
+	var loops []Loop
+	for _, c := range candidates {
+		if len(loops) == count {
+			break
+		}
+		distinct := true
+		for _, l := range loops {
+			if similarity(c, l) >= maxLoopSimilarity {
+				distinct = false
+				break
+			}
+		}
+		if distinct {
+			loops = append(loops, c)
+		}
+	}
+	if len(loops) == 0 {
+		return nil, ErrNoLoop
+	}
+	return loops, nil
+}
+
+// 경사/노면 선호에 따른 비용 함수와 계수의 최솟값 (A* 추정치용)
+func loopCost(elevationPref, surfacePref string) (costFunc, float64, error) {
+	floor := minWayFactor
+
+	var grade func(g float64) float64
+	switch elevationPref {
+	case ElevationAny:
+		grade = gradeFactor
+	case ElevationFlat:
+		grade = func(g float64) float64 {
+			f := gradeFactor(g)
+			return f * f
+		}
+	case ElevationHilly:
+		// 오르막일수록 비용을 줄임 (가파른 내리막 계수는 유지)
+		grade = func(g float64) float64 {
+			if g > 0 {
+				return math.Max(hillyMinFactor, 1/gradeFactor(g))
+			}
+			return gradeFactor(g)
+		}
+		floor *= hillyMinFactor
+	default:
+		return nil, 0, ErrInvalidPreference
+	}
+
+	var surface func(s float64) float64
+	switch surfacePref {
+	case SurfaceAny:
+		surface = func(s float64) float64 { return s }
+	case SurfacePaved:
+		surface = func(s float64) float64 {
+			if s > 1 {
+				return s * unpavedAvoided
+			}
+			return 1
+		}
+	case SurfaceUnpaved:
+		surface = func(s float64) float64 {
+			if s > 1 {
+				return unpavedPreferred
+			}
+			return 1
+		}
+		floor *= unpavedPreferred
+	default:
+		return nil, 0, ErrInvalidPreference
+	}
+
+	return func(_ int32, e *edge) float64 {
+		return float64(e.length) * float64(e.factor) * surface(float64(e.surface)) * grade(float64(e.grade))
+	}, floor, nil
+}
+
+// start를 꼭짓점으로 하고 외접원 중심이 bearing 방향 radius(미터)에 있는 삼각형을 따라 도는 경로
+func (g *Graph) loop(start int32, bearing, radius float64, cost costFunc, floor float64) (*Loop, bool) {
+	origin := g.position(start)
+	center := destination(origin, bearing, radius)
+
+	// 중심에서 본 시작 지점의 방위에서 ±120도
+	back := math.Mod(bearing+180, 360)
+	nodes := []int32{start}
+	for _, offset := range []float64{120, 240} {
+		n, ok := g.nearest(destination(center, back+offset, radius), MaxSnapDistance)
+		if !ok {
+			return nil, false
+		}
+		nodes = append(nodes, n)
+	}
+	nodes = append(nodes, start)
+
+	// 이미 지난 도로(방향 무관)는 비용을 높여 다른 길로 돌아오도록 함
+	used := map[[2]int32]bool{}
+	penalized := func(from int32, e *edge) float64 {
+		c := cost(from, e)
+		if used[edgeKey(from, e.to)] {
+			c *= reusePenalty
+		}
+		return c
+	}
+
+	route := []int32{start}
+	for i := 1; i < len(nodes); i++ {
+		leg, ok := g.astar(nodes[i-1], nodes[i], penalized, floor)
+		if !ok {
+			return nil, false
+		}
+		for k := 1; k < len(leg); k++ {
+			used[edgeKey(leg[k-1], leg[k])] = true
+		}
+		route = append(route, leg[1:]...)
+	}
+
+	loop := &Loop{Bearing: bearing, edges: map[[2]int32]bool{}}
+	var repeated float64
+	loop.Points = make([]models.GeoPoint, len(route))
+	for i, n := range route {
+		loop.Points[i] = g.point(n)
+		if i == 0 {
+			continue
+		}
+		d := g.distance(route[i-1], n)
+		key := edgeKey(route[i-1], n)
+		if loop.edges[key] {
+			repeated += d
+		}
+		loop.edges[key] = true
+		loop.Distance += d
+	}
+	if loop.Distance == 0 {
+		return nil, false
+	}
+	loop.Overlap = repeated / loop.Distance
+	return loop, true
+}
+
+// 두 노드 사이 거리 (미터)
+func (g *Graph) distance(a, b int32) float64 {
+	return analysis.Haversine(g.position(a), g.position(b))
+}
+
+// 방향과 무관한 간선 키
+func edgeKey(a, b int32) [2]int32 {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]int32{a, b}
+}
+
+// 두 후보가 공유하는 도로의 비율 (작은 쪽 기준)
+func similarity(a, b Loop) float64 {
+	shared := 0
+	for key := range a.edges {
+		if b.edges[key] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(min(len(a.edges), len(b.edges)))
+}
+
+// p에서 bearing(도) 방향으로 distance(미터) 떨어진 지점
+func destination(p models.GeoPoint, bearing, distance float64) models.GeoPoint {
+	const earthRadius = 6371000.0
+	lat1 := p.Latitude * math.Pi / 180
+	lon1 := p.Longitude * math.Pi / 180
+	theta := bearing * math.Pi / 180
+	delta := distance / earthRadius
+
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(delta) + math.Cos(lat1)*math.Sin(delta)*math.Cos(theta))
+	lon2 := lon1 + math.Atan2(math.Sin(theta)*math.Sin(delta)*math.Cos(lat1), math.Cos(delta)-math.Sin(lat1)*math.Sin(lat2))
+	return models.GeoPoint{
+		Latitude:  lat2 * 180 / math.Pi,
+		Longitude: math.Mod(lon2*180/math.Pi+540, 360) - 180,
+	}
+}
@@ -0,0 +1,177 @@
+package routing
+
+import (
+	"math"
+	"testing"
+)
+
+func residential(int) map[string]string {
+	return highway("residential")
+}
+
+func TestLoopsDistance(t *testing.T) {
+	// 200m 간격 31×31 격자 (6km×6km), 중앙에서 출발
+	g := gridGraph(t, 31, 200, residential, nil)
+	start := xy(3000, 3000)
+
+	for _, distance := range []float64{6000, 10000} {
+		loops, err := g.Loops(LoopOptions{Start: start, Distance: distance, Count: MaxLoopCount})
+		if err != nil {
+			t.Fatalf("Loops(%v) error = %v", distance, err)
+		}
+		best := loops[0]
+		if diff := math.Abs(best.Distance-distance) / distance; diff > loopTolerance {
+			t.Errorf("Loops(%v) 첫 후보 거리 = %.0f, 목표와 %.0f%% 차이 (허용 %.0f%%)", distance, best.Distance, diff*100, loopTolerance*100)
+		}
+		for i, l := range loops {
+			first, last := l.Points[0], l.Points[len(l.Points)-1]
+			if first != last {
+				t.Errorf("loops[%d] 시작 %v와 끝 %v가 다름", i, first, last)
+			}
+		}
+	}
+}
+
+func TestLoopsDistinct(t *testing.T) {
+	g := gridGraph(t, 31, 200, residential, nil)
+
+	loops, err := g.Loops(LoopOptions{Start: xy(3000, 3000), Distance: 8000, Count: MaxLoopCount})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loops) < 2 {
+		t.Fatalf("후보 = %d, want 2개 이상", len(loops))
+	}
+	for i := range loops {
+		for j := i + 1; j < len(loops); j++ {
+			if s := similarity(loops[i], loops[j]); s >= maxLoopSimilarity {
+				t.Errorf("loops[%d], loops[%d] 유사도 = %.2f, want < %v", i, j, s, maxLoopSimilarity)
+			}
+		}
+	}
+}
+
+func TestLoopsRejectsInvalid(t *testing.T) {
+	g := gridGraph(t, 3, 200, residential, nil)
+
+	tests := []struct {
+		name string
+		opts LoopOptions
+		want error
+	}{
+		{"경사 선호", LoopOptions{Start: xy(0, 0), Distance: 10000, Elevation: "steep"}, ErrInvalidPreference},
+		{"노면 선호", LoopOptions{Start: xy(0, 0), Distance: 10000, Surface: "gravel"}, ErrInvalidPreference},
+		{"짧은 거리", LoopOptions{Start: xy(0, 0), Distance: 1000}, ErrLoopDistance},
+		{"긴 거리", LoopOptions{Start: xy(0, 0), Distance: 500000}, ErrLoopDistance},
+		{"도로에서 먼 시작", LoopOptions{Start: xy(5000, 5000), Distance: 10000}, ErrNoRoad},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := g.Loops(tt.opts); err != tt.want {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoopCostPreferences(t *testing.T) {
+	// 0→1로 가는 두 갈래 (북쪽 2, 남쪽 3을 거침, 길이 같음)
+	nodes := [][2]float64{{0, 0}, {2000, 0}, {1000, 300}, {1000, -300}}
+
+	tests := []struct {
+		name      string
+		ele       []float64
+		north     map[string]string
+		elevation string
+		surface   string
+		want      int32 // 거쳐 가는 노드
+	}{
+		{"기본은 포장 도로", nil, map[string]string{"highway": "residential", "surface": "gravel"}, ElevationAny, SurfaceAny, 3},
+		{"paved는 포장 도로", nil, map[string]string{"highway": "residential", "surface": "gravel"}, ElevationAny, SurfacePaved, 3},
+		{"unpaved는 비포장 도로", nil, map[string]string{"highway": "residential", "surface": "gravel"}, ElevationAny, SurfaceUnpaved, 2},
+		{"flat은 평지", []float64{0, 0, 60, 0}, highway("residential"), ElevationFlat, SurfaceAny, 3},
+		{"hilly는 언덕", []float64{0, 0, 60, 0}, highway("residential"), ElevationHilly, SurfaceAny, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := testGraph(t, nodes, tt.ele, []testWay{
+				{tt.north, []int32{0, 2, 1}},
+				{highway("residential"), []int32{0, 3, 1}},
+			})
+			cost, floor, err := loopCost(tt.elevation, tt.surface)
+			if err != nil {
+				t.Fatal(err)
+			}
+			path, ok := g.astar(0, 1, cost, floor)
+			if !ok {
+				t.Fatal("경로 없음")
+			}
+			if want := []int32{0, tt.want, 1}; !equalNodes(path, want) {
+				t.Errorf("path = %v, want %v", path, want)
+			}
+		})
+	}
+}
+
+func TestLoopCostHeuristicAdmissible(t *testing.T) {
+	// 선호에 따라 계수가 minWayFactor보다 작아져도 floor 이하여야 함
+	kinds := []map[string]string{
+		highway("cycleway"),
+		{"highway": "cycleway", "surface": "gravel"},
+		{"highway": "track", "surface": "dirt"},
+		highway("residential"),
+	}
+	tags := func(k int) map[string]string { return kinds[k%len(kinds)] }
+	height := func(x, y float64) float64 { return 0.15*x - 0.1*y }
+	g := gridGraph(t, 5, 150, tags, height)
+
+	for _, elevation := range []string{ElevationAny, ElevationFlat, ElevationHilly} {
+		for _, surface := range []string{SurfaceAny, SurfacePaved, SurfaceUnpaved} {
+			cost, floor, err := loopCost(elevation, surface)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for to := int32(0); int(to) < g.Nodes(); to++ {
+				costs := costsTo(g, to, cost)
+				for from := int32(0); int(from) < g.Nodes(); from++ {
+					estimate := g.distance(from, to) * floor
+					if estimate > costs[from]*(1+1e-6) { // 간선 길이는 float32
+						t.Fatalf("%q/%q 추정치(%d→%d) = %.3f, 실제 최소 비용 %.3f보다 큼", elevation, surface, from, to, estimate, costs[from])
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	loop := func(keys ...[2]int32) Loop {
+		l := Loop{edges: map[[2]int32]bool{}}
+		for _, k := range keys {
+			l.edges[k] = true
+		}
+		return l
+	}
+	a := loop([2]int32{0, 1}, [2]int32{1, 2}, [2]int32{2, 3}, [2]int32{0, 3})
+
+	tests := []struct {
+		name string
+		b    Loop
+		want float64
+	}{
+		{"같은 경로", a, 1},
+		{"겹치지 않음", loop([2]int32{4, 5}, [2]int32{5, 6}), 0},
+		{"작은 쪽이 모두 겹침", loop([2]int32{0, 1}, [2]int32{1, 2}), 1},
+		{"절반 겹침", loop([2]int32{0, 1}, [2]int32{2, 3}, [2]int32{4, 5}, [2]int32{5, 6}), 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := similarity(a, tt.b); got != tt.want {
+				t.Errorf("similarity = %v, want %v", got, tt.want)
+			}
+			if got := similarity(tt.b, a); got != tt.want {
+				t.Errorf("similarity (순서 바꿈) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package routing
+
+import "math"
+
+// 자전거 도로 가중치
+// 비용은 거리(미터)에 도로 종류와 경사에 따른 계수를 곱한 값이며 계수가 작을수록 선호
+const (
+	// 가장 선호하는 도로의 계수 (A* 추정치는 직선 거리에 이 값을 곱하므로 모든 계수가 이 이상이어야 함)
+	minWayFactor = 0.7
+	// 자전거를 끌고 가야 하는 도로 (보행자 도로, 계단 등)
+	walkFactor = 3.0
+	// 경사 1%당 오르막 비용 증가율과, 가파른 오르막(steepGrade 초과)에 더하는 증가율
+	climbPenalty      = 0.08
+	steepGrade        = 8.0
+	steepClimbPenalty = 0.15
+	// 이보다 가파른 내리막은 위험하므로 1%당 비용을 올림
+	steepDescentGrade   = 10.0
+	steepDescentPenalty = 0.03
+	// DEM 오차로 짧은 구간의 경사가 과장되지 않도록 제한 (%)
+	maxGrade = 25.0
+)
+
+// 도로 종류(highway 태그)별 기본 계수
+var highwayFactors = map[string]float64{
+	"cycleway":       0.7,
+	"path":           1.2,
+	"living_street":  1.0,
+	"residential":    1.0,
+	"unclassified":   1.0,
+	"service":        1.1,
+	"road":           1.2,
+	"track":          1.3,
+	"tertiary":       1.1,
+	"tertiary_link":  1.1,
+	"secondary":      1.4,
+	"secondary_link": 1.4,
+	"primary":        1.8,
+	"primary_link":   1.8,
+	"trunk":          3.0,
+	"trunk_link":     3.0,
+	"bridleway":      2.0,
+	"footway":        walkFactor,
+	"pedestrian":     walkFactor,
+	"steps":          5.0,
+}
+
+// 비포장 노면 계수
+var surfaceFactors = map[string]float64{
+	"compacted":   1.1,
+	"fine_gravel": 1.1,
+	"gravel":      1.3,
+	"unpaved":     1.3,
+	"dirt":        1.4,
+	"ground":      1.4,
+	"grass":       1.6,
+	"sand":        1.8,
+	"mud":         1.8,
+}
+
+// 자전거 통행 방향
+type direction struct {
+	forward  bool
+	backward bool
+}
+
+// wayFactor 웨이 태그로부터 자전거 통행 계수(노면 제외)와 방향 계산
+// 자전거가 다닐 수 없는 웨이(고속도로, 자전거 금지, 통행 금지 등)는 false
+func wayFactor(tags map[string]string) (float64, direction, bool) {
+	highway := tags["highway"]
+	bicycle := tags["bicycle"]
+	allowed := bicycle == "yes" || bicycle == "designated" || bicycle == "permissive"
+
+	factor, ok := highwayFactors[highway]
+	switch {
+	case bicycle == "no" || bicycle == "use_sidepath":
+		return 0, direction{}, false
+	case (highway == "motorway" || highway == "motorway_link") && allowed:
+		factor, ok = 3.0, true
+	case !ok:
+		return 0, direction{}, false
+	}
+	if access := tags["access"]; (access == "no" || access == "private") && !allowed {
+		return 0, direction{}, false
+	}
+
+	switch {
+	case bicycle == "dismount":
+		factor = walkFactor
+	case (highway == "footway" || highway == "pedestrian") && allowed:
+		// 자전거 통행이 허용된 보행자 도로 (같은 계수인 trunk 등은 제외)
+		factor = 1.0
+	case highway == "path" && bicycle == "designated":
+		factor = 0.75
+	case highway == "path" && allowed:
+		factor = 0.9
+	case bicycle == "designated" || tags["bicycle_road"] == "yes":
+		factor *= 0.8
+	}
+
+	// 차도 옆 자전거 차로
+	if hasCycleLane(tags) {
+		factor *= 0.8
+	}
+
+	return math.Max(factor, minWayFactor), wayDirection(tags), true
+}
+
+// 노면 계수 (포장 도로나 노면 태그가 없으면 1)
+func surfaceFactor(tags map[string]string) float64 {
+	if s, ok := surfaceFactors[tags["surface"]]; ok {
+		return s
+	}
+	return 1
+}
+
+// 안내에 쓰는 도로 이름 (name이 없으면 도로 번호)
+func wayName(tags map[string]string) string {
+	if name := tags["name"]; name != "" {
+		return name
+	}
+	return tags["ref"]
+}
+
+func hasCycleLane(tags map[string]string) bool {
+	for _, key := range []string{"cycleway", "cycleway:both", "cycleway:left", "cycleway:right"} {
+		switch tags[key] {
+		case "lane", "track", "shared_lane", "opposite_lane", "opposite_track":
+			return true
+		}
+	}
+	return false
+}
+
+// 일방통행 태그로 자전거 통행 방향 결정 (자전거 예외 태그 우선)
+func wayDirection(tags map[string]string) direction {
+	both := direction{forward: true, backward: true}
+	if tags["oneway:bicycle"] == "no" {
+		return both
+	}
+	switch tags["cycleway"] {
+	case "opposite", "opposite_lane", "opposite_track":
+		return both
+	}
+
+	oneway := tags["oneway:bicycle"]
+	if oneway == "" {
+		oneway = tags["oneway"]
+	}
+	switch oneway {
+	case "yes", "true", "1":
+		return direction{forward: true}
+	case "-1", "reverse":
+		return direction{backward: true}
+	case "no", "false", "0":
+		return both
+	}
+	if tags["junction"] == "roundabout" {
+		return direction{forward: true}
+	}
+	return both
+}
+
+// gradeFactor 경사도(%)에 따른 비용 계수 (오르막과 가파른 내리막에서 1보다 큼)
+func gradeFactor(grade float64) float64 {
+	grade = math.Max(-maxGrade, math.Min(maxGrade, grade))
+	switch {
+	case grade > steepGrade:
+		return 1 + grade*climbPenalty + (grade-steepGrade)*steepClimbPenalty
+	case grade > 0:
+		return 1 + grade*climbPenalty
+	case grade < -steepDescentGrade:
+		return 1 + (-grade-steepDescentGrade)*steepDescentPenalty
+	}
+	return 1
+}
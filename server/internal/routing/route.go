@@ -0,0 +1,165 @@
+package routing
+
+import (
+	"container/heap"
+	"errors"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+const (
+	// 경유지를 도로망에 붙일 때 허용하는 최대 거리 (미터)
+	MaxSnapDistance = 500.0
+	// 경유지 사이 직선 거리 합의 상한 (미터), 탐색 범위를 제한
+	MaxPlanDistance = 300000.0
+	// 경유지 수 상한 (출발/도착 포함)
+	MaxWaypoints = 25
+)
+
+var (
+	ErrTooFewWaypoints  = errors.New("at least 2 waypoints are required")
+	ErrTooManyWaypoints = errors.New("too many waypoints")
+	ErrTooFar           = errors.New("waypoints are too far apart")
+	ErrNoRoad           = errors.New("no cycleable road near waypoint")
+	ErrNoPath           = errors.New("no path between waypoints")
+)
+
+// Path 탐색한 경로
+type Path struct {
+	Points   []models.GeoPoint // 도로망 노드 (DEM이 있으면 고도 포함)
+	Distance float64           // 미터
+}
+
+// Route 경유지를 순서대로 지나는 자전거 경로 탐색
+// 경유지는 가장 가까운 도로망 노드에 붙이며, 구간마다 A*로 탐색한 경로를 이어 붙임
+// 실패 원인은 Err* 오류 (ErrNoRoad, ErrNoPath는 몇 번째 경유지인지 함께 반환)
+func (g *Graph) Route(waypoints []models.GeoPoint) (*Path, int, error) {
+	switch {
+	case len(waypoints) < 2:
+		return nil, 0, ErrTooFewWaypoints
+	case len(waypoints) > MaxWaypoints:
+		return nil, 0, ErrTooManyWaypoints
+	}
+
+	var straight float64
+	for i := 1; i < len(waypoints); i++ {
+		straight += analysis.Haversine(waypoints[i-1], waypoints[i])
+	}
+	if straight > MaxPlanDistance {
+		return nil, 0, ErrTooFar
+	}
+
+	nodes := make([]int32, len(waypoints))
+	for i, wp := range waypoints {
+		n, ok := g.nearest(wp, MaxSnapDistance)
+		if !ok {
+			return nil, i, ErrNoRoad
+		}
+		nodes[i] = n
+	}
+
+	path := &Path{}
+	route := []int32{nodes[0]}
+	for i := 1; i < len(nodes); i++ {
+		leg, ok := g.astar(nodes[i-1], nodes[i], defaultCost, minWayFactor)
+		if !ok {
+			return nil, i, ErrNoPath
+		}
+		route = append(route, leg[1:]...)
+	}
+
+	path.Points = make([]models.GeoPoint, len(route))
+	for i, n := range route {
+		path.Points[i] = g.point(n)
+		if i > 0 {
+			path.Distance += g.distance(route[i-1], n)
+		}
+	}
+	return path, 0, nil
+}
+
+// 간선 비용 (거리에 계수를 곱한 값)
+type costFunc func(from int32, e *edge) float64
+
+// 기본 비용: 거리 x 도로 종류 x 노면 x 경사 계수
+func defaultCost(_ int32, e *edge) float64 {
+	return float64(e.length) * float64(e.factor) * float64(e.surface) * gradeFactor(float64(e.grade))
+}
+
+// from에서 to까지 cost가 가장 작은 노드 순서
+// 추정치는 직선 거리 x floor이므로 floor는 cost가 거리에 곱하는 계수의 최솟값 이하여야 함
+// (기본 비용은 경사/노면 계수가 1 이상이므로 minWayFactor)
+func (g *Graph) astar(from, to int32, cost costFunc, floor float64) ([]int32, bool) {
+	if from == to {
+		return []int32{from}, true
+	}
+
+	target := g.position(to)
+	estimate := func(n int32) float64 {
+		return analysis.Haversine(g.position(n), target) * floor
+	}
+
+	best := map[int32]float64{from: 0}
+	prev := map[int32]int32{}
+	done := map[int32]bool{}
+	open := &queue{{node: from, priority: estimate(from)}}
+
+	for open.Len() > 0 {
+		cur := heap.Pop(open).(item)
+		if done[cur.node] {
+			continue
+		}
+		if cur.node == to {
+			return g.trace(prev, from, to), true
+		}
+		done[cur.node] = true
+
+		for k := g.first[cur.node]; k < g.first[cur.node+1]; k++ {
+			e := &g.edges[k]
+			if done[e.to] {
+				continue
+			}
+			c := best[cur.node] + cost(cur.node, e)
+			if old, ok := best[e.to]; ok && old <= c {
+				continue
+			}
+			best[e.to] = c
+			prev[e.to] = cur.node
+			heap.Push(open, item{node: e.to, priority: c + estimate(e.to)})
+		}
+	}
+	return nil, false
+}
+
+// prev를 거슬러 올라가 from→to 노드 순서 복원
+func (g *Graph) trace(prev map[int32]int32, from, to int32) []int32 {
+	var nodes []int32
+	for n := to; n != from; n = prev[n] {
+		nodes = append(nodes, n)
+	}
+	nodes = append(nodes, from)
+	for i, j := 0, len(nodes)-1; i < j; i, j = i+1, j-1 {
+		nodes[i], nodes[j] = nodes[j], nodes[i]
+	}
+	return nodes
+}
+
+// A* 우선순위 큐 (priority가 작은 순)
+type item struct {
+	node     int32
+	priority float64
+}
+
+type queue []item
+
+func (q queue) Len() int            { return len(q) }
+func (q queue) Less(i, j int) bool  { return q[i].priority < q[j].priority }
+func (q queue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *queue) Push(x interface{}) { *q = append(*q, x.(item)) }
+func (q *queue) Pop() interface{} {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return it
+}
@@ -0,0 +1,378 @@
+package routing
+
+import (
+	"math"
+	"testing"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+// 기준 위치에서 동쪽 x, 북쪽 y 미터 떨어진 지점
+func xy(x, y float64) models.GeoPoint {
+	const lat, lon = 37.5, 127.0
+	scale := analysis.EarthRadius * math.Pi / 180
+	return models.GeoPoint{
+		Latitude:  lat + y/scale,
+		Longitude: lon + x/(scale*math.Cos(lat*math.Pi/180)),
+	}
+}
+
+// 테스트 도로망의 웨이 (nodes는 testGraph의 노드 번호)
+type testWay struct {
+	tags  map[string]string
+	nodes []int32
+}
+
+// 미터 좌표의 노드와 웨이로 만든 도로망 (Load와 같은 계수/방향 계산)
+// ele가 nil이면 고도 없음
+func testGraph(t *testing.T, nodes [][2]float64, ele []float64, ways []testWay) *Graph {
+	t.Helper()
+	g := &Graph{
+		lat:   make([]float64, len(nodes)),
+		lon:   make([]float64, len(nodes)),
+		ele:   make([]float64, len(nodes)),
+		cells: make(map[[2]int32][]int32),
+		names: []string{""},
+	}
+	found := make([]bool, len(nodes))
+	for i, n := range nodes {
+		p := xy(n[0], n[1])
+		g.lat[i], g.lon[i] = p.Latitude, p.Longitude
+		g.ele[i] = math.NaN()
+		if ele != nil {
+			g.ele[i] = ele[i]
+		}
+		found[i] = true
+	}
+
+	var infos []wayInfo
+	for _, w := range ways {
+		factor, dir, ok := wayFactor(w.tags)
+		if !ok {
+			t.Fatalf("wayFactor(%v) = false", w.tags)
+		}
+		infos = append(infos, wayInfo{nodes: w.nodes, factor: factor, surface: surfaceFactor(w.tags), dir: dir})
+	}
+	g.build(infos, found)
+	return g
+}
+
+// n×n 격자 도로망 (간격 spacing 미터, 0번 노드가 남서쪽 모서리)
+// tags는 k번째 도로 구간의 태그, height가 nil이 아니면 노드 고도
+func gridGraph(t *testing.T, n int, spacing float64, tags func(k int) map[string]string, height func(x, y float64) float64) *Graph {
+	t.Helper()
+	var nodes [][2]float64
+	var ele []float64
+	for row := 0; row < n; row++ {
+		for col := 0; col < n; col++ {
+			x, y := float64(col)*spacing, float64(row)*spacing
+			nodes = append(nodes, [2]float64{x, y})
+			if height != nil {
+				ele = append(ele, height(x, y))
+			}
+		}
+	}
+	var ways []testWay
+	add := func(a, b int) {
+		ways = append(ways, testWay{tags: tags(len(ways)), nodes: []int32{int32(a), int32(b)}})
+	}
+	for row := 0; row < n; row++ {
+		for col := 0; col < n; col++ {
+			i := row*n + col
+			if col+1 < n {
+				add(i, i+1)
+			}
+			if row+1 < n {
+				add(i, i+n)
+			}
+		}
+	}
+	return testGraph(t, nodes, ele, ways)
+}
+
+func highway(kind string) map[string]string {
+	return map[string]string{"highway": kind}
+}
+
+// 노드 순서를 따라가는 비용 (연속한 두 노드 사이 간선이 없으면 +Inf)
+func pathCost(g *Graph, nodes []int32, cost costFunc) float64 {
+	var total float64
+	for i := 1; i < len(nodes); i++ {
+		best := math.Inf(1)
+		for k := g.first[nodes[i-1]]; k < g.first[nodes[i-1]+1]; k++ {
+			if e := &g.edges[k]; e.to == nodes[i] {
+				best = math.Min(best, cost(nodes[i-1], e))
+			}
+		}
+		total += best
+	}
+	return total
+}
+
+// 모든 노드에서 to까지의 최소 비용 (Bellman–Ford, 도달할 수 없으면 +Inf)
+func costsTo(g *Graph, to int32, cost costFunc) []float64 {
+	dist := make([]float64, g.Nodes())
+	for i := range dist {
+		dist[i] = math.Inf(1)
+	}
+	dist[to] = 0
+	for changed := true; changed; {
+		changed = false
+		for from := int32(0); int(from) < g.Nodes(); from++ {
+			for k := g.first[from]; k < g.first[from+1]; k++ {
+				e := &g.edges[k]
+				if c := dist[e.to] + cost(from, e); c < dist[from]-1e-9 {
+					dist[from] = c
+					changed = true
+				}
+			}
+		}
+	}
+	return dist
+}
+
+func equalNodes(a, b []int32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAstarPrefersCycleway(t *testing.T) {
+	// 0→1 직선 1km 간선도로와 2를 거쳐 돌아가는 자전거 도로 (약 1.08km)
+	g := testGraph(t, [][2]float64{{0, 0}, {1000, 0}, {500, 200}}, nil, []testWay{
+		{highway("primary"), []int32{0, 1}},
+		{highway("cycleway"), []int32{0, 2, 1}},
+	})
+
+	path, ok := g.astar(0, 1, defaultCost, minWayFactor)
+	if !ok {
+		t.Fatal("경로 없음")
+	}
+	if want := []int32{0, 2, 1}; !equalNodes(path, want) {
+		t.Errorf("path = %v, want %v (자전거 도로)", path, want)
+	}
+}
+
+func TestAstarCheapestPath(t *testing.T) {
+	kinds := []string{"residential", "primary", "cycleway", "secondary", "track", "tertiary", "footway", "service"}
+	tags := func(k int) map[string]string {
+		tags := highway(kinds[k*7%len(kinds)])
+		if k%5 == 0 {
+			tags["surface"] = "gravel"
+		}
+		return tags
+	}
+	height := func(x, y float64) float64 {
+		return 40 * math.Sin(x/300) * math.Cos(y/400)
+	}
+	g := gridGraph(t, 6, 200, tags, height)
+
+	for _, to := range []int32{35, 17, 5} {
+		costs := costsTo(g, to, defaultCost)
+		for from := int32(0); int(from) < g.Nodes(); from++ {
+			path, ok := g.astar(from, to, defaultCost, minWayFactor)
+			if !ok {
+				t.Fatalf("astar(%d, %d) 경로 없음", from, to)
+			}
+			if path[0] != from || path[len(path)-1] != to {
+				t.Fatalf("astar(%d, %d) = %v, 양 끝이 다름", from, to, path)
+			}
+			if got := pathCost(g, path, defaultCost); math.Abs(got-costs[from]) > 1e-6 {
+				t.Errorf("astar(%d, %d) 비용 = %.3f, want %.3f (최소 비용)", from, to, got, costs[from])
+			}
+		}
+	}
+}
+
+func TestAstarHeuristicAdmissible(t *testing.T) {
+	// 가장 선호하는 도로와 내리막이 섞인 도로망에서도 추정치가 실제 최소 비용을 넘지 않아야 함
+	kinds := []map[string]string{
+		highway("cycleway"),
+		{"highway": "path", "bicycle": "designated"},
+		{"highway": "tertiary", "cycleway": "lane", "bicycle": "designated"},
+		highway("residential"),
+	}
+	tags := func(k int) map[string]string { return kinds[k%len(kinds)] }
+	height := func(x, y float64) float64 { return -0.2 * (x + y) }
+	g := gridGraph(t, 5, 150, tags, height)
+
+	for to := int32(0); int(to) < g.Nodes(); to++ {
+		costs := costsTo(g, to, defaultCost)
+		for from := int32(0); int(from) < g.Nodes(); from++ {
+			estimate := analysis.Haversine(g.position(from), g.position(to)) * minWayFactor
+			if estimate > costs[from]*(1+1e-6) { // 간선 길이는 float32
+				t.Fatalf("추정치(%d→%d) = %.3f, 실제 최소 비용 %.3f보다 큼", from, to, estimate, costs[from])
+			}
+		}
+	}
+}
+
+func TestAstarOneway(t *testing.T) {
+	// 0→1 일방통행 300m와 2를 거쳐 돌아가는 양방향 도로
+	g := testGraph(t, [][2]float64{{0, 0}, {300, 0}, {150, 400}}, nil, []testWay{
+		{map[string]string{"highway": "residential", "oneway": "yes"}, []int32{0, 1}},
+		{highway("residential"), []int32{1, 2, 0}},
+	})
+
+	if path, ok := g.astar(0, 1, defaultCost, minWayFactor); !ok || !equalNodes(path, []int32{0, 1}) {
+		t.Errorf("정방향 path = %v, want [0 1]", path)
+	}
+	if path, ok := g.astar(1, 0, defaultCost, minWayFactor); !ok || !equalNodes(path, []int32{1, 2, 0}) {
+		t.Errorf("역방향 path = %v, want [1 2 0] (일방통행 역주행 금지)", path)
+	}
+
+	// 돌아갈 길이 없으면 경로 없음
+	g = testGraph(t, [][2]float64{{0, 0}, {300, 0}}, nil, []testWay{
+		{map[string]string{"highway": "residential", "oneway": "-1"}, []int32{0, 1}},
+	})
+	if path, ok := g.astar(0, 1, defaultCost, minWayFactor); ok {
+		t.Errorf("oneway=-1 정방향 path = %v, want 없음", path)
+	}
+	if _, ok := g.astar(1, 0, defaultCost, minWayFactor); !ok {
+		t.Error("oneway=-1 역방향 경로 없음")
+	}
+}
+
+func TestWayDirection(t *testing.T) {
+	tests := []struct {
+		name string
+		tags map[string]string
+		want direction
+	}{
+		{"양방향", map[string]string{}, direction{true, true}},
+		{"일방통행", map[string]string{"oneway": "yes"}, direction{true, false}},
+		{"역방향 일방통행", map[string]string{"oneway": "-1"}, direction{false, true}},
+		{"자전거 예외", map[string]string{"oneway": "yes", "oneway:bicycle": "no"}, direction{true, true}},
+		{"역방향 자전거 차로", map[string]string{"oneway": "yes", "cycleway": "opposite_lane"}, direction{true, true}},
+		{"회전 교차로", map[string]string{"junction": "roundabout"}, direction{true, false}},
+		{"자전거만 일방통행", map[string]string{"oneway:bicycle": "yes"}, direction{true, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wayDirection(tt.tags); got != tt.want {
+				t.Errorf("wayDirection = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWayFactor(t *testing.T) {
+	tests := []struct {
+		name string
+		tags map[string]string
+		want float64
+		ok   bool
+	}{
+		{"자전거 도로", highway("cycleway"), 0.7, true},
+		{"주거 도로", highway("residential"), 1.0, true},
+		{"간선도로", highway("primary"), 1.8, true},
+		{"자전거 차로가 있는 간선도로", map[string]string{"highway": "primary", "cycleway:right": "lane"}, 1.8 * 0.8, true},
+		{"자전거 지정 도로", map[string]string{"highway": "path", "bicycle": "designated"}, 0.75, true},
+		{"자전거 허용 보도", map[string]string{"highway": "footway", "bicycle": "yes"}, 1.0, true},
+		{"보도", highway("footway"), walkFactor, true},
+		{"내려서 끌고 가기", map[string]string{"highway": "residential", "bicycle": "dismount"}, walkFactor, true},
+		{"하한", map[string]string{"highway": "cycleway", "bicycle": "designated", "cycleway": "track"}, minWayFactor, true},
+		{"자전거 허용 고속도로", map[string]string{"highway": "motorway", "bicycle": "yes"}, 3.0, true},
+		{"자전거 허용 간선 국도", map[string]string{"highway": "trunk", "bicycle": "yes"}, 3.0, true},
+		{"고속도로", highway("motorway"), 0, false},
+		{"자전거 금지", map[string]string{"highway": "residential", "bicycle": "no"}, 0, false},
+		{"통행 금지", map[string]string{"highway": "service", "access": "private"}, 0, false},
+		{"자전거만 허용", map[string]string{"highway": "service", "access": "no", "bicycle": "yes"}, 1.1, true},
+		{"도로 아님", map[string]string{"building": "yes"}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, ok := wayFactor(tt.tags)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if ok && math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("factor = %v, want %v", got, tt.want)
+			}
+			if ok && got < minWayFactor {
+				t.Errorf("factor = %v, minWayFactor보다 작음", got)
+			}
+		})
+	}
+}
+
+func TestGradeFactor(t *testing.T) {
+	tests := []struct {
+		grade float64
+		want  float64
+	}{
+		{0, 1},
+		{5, 1.4},
+		{10, 1 + 10*climbPenalty + 2*steepClimbPenalty},
+		{40, 1 + maxGrade*climbPenalty + (maxGrade-steepGrade)*steepClimbPenalty}, // maxGrade로 제한
+		{-5, 1},
+		{-10, 1},
+		{-15, 1 + 5*steepDescentPenalty},
+	}
+	for _, tt := range tests {
+		if got := gradeFactor(tt.grade); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("gradeFactor(%v) = %v, want %v", tt.grade, got, tt.want)
+		}
+	}
+	for grade := -30.0; grade <= 30; grade += 0.5 {
+		if f := gradeFactor(grade); f < 1 {
+			t.Fatalf("gradeFactor(%v) = %v, 1보다 작음 (A* 추정치가 과대평가됨)", grade, f)
+		}
+	}
+}
+
+func TestNearest(t *testing.T) {
+	// 2번 노드는 간선이 없으므로 후보가 아님
+	g := testGraph(t, [][2]float64{{0, 0}, {400, 0}, {600, 0}}, nil, []testWay{
+		{highway("residential"), []int32{0, 1}},
+	})
+
+	tests := []struct {
+		name string
+		p    models.GeoPoint
+		want int32
+		ok   bool
+	}{
+		{"가까운 노드", xy(80, 30), 0, true},
+		{"다른 쪽 끝", xy(330, -20), 1, true},
+		{"간선 없는 노드 제외", xy(600, 0), 1, true},
+		{"최대 거리 밖", xy(0, 700), 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := g.nearest(tt.p, MaxSnapDistance)
+			if ok != tt.ok || (ok && got != tt.want) {
+				t.Errorf("nearest = %d, %v, want %d, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRoute(t *testing.T) {
+	g := gridGraph(t, 4, 200, func(int) map[string]string { return highway("residential") }, nil)
+
+	path, _, err := g.Route([]models.GeoPoint{xy(5, 5), xy(600, 0), xy(600, 600)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(path.Distance-1200) > 1 {
+		t.Errorf("distance = %.1f, want 1200", path.Distance)
+	}
+	if d := analysis.Haversine(path.Points[len(path.Points)-1], xy(600, 600)); d > 1 {
+		t.Errorf("마지막 지점이 도착 경유지에서 %.1fm 떨어짐", d)
+	}
+
+	if _, _, err := g.Route([]models.GeoPoint{xy(0, 0)}); err != ErrTooFewWaypoints {
+		t.Errorf("경유지 1개 error = %v, want %v", err, ErrTooFewWaypoints)
+	}
+	if _, i, err := g.Route([]models.GeoPoint{xy(0, 0), xy(3000, 0)}); err != ErrNoRoad || i != 1 {
+		t.Errorf("도로에서 먼 경유지 = %d, %v, want 1, %v", i, err, ErrNoRoad)
+	}
+}
@@ -0,0 +1,77 @@
+package routing
+
+import (
+	"math"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+const (
+	// 트랙 구간을 도로에 맞출 때 허용하는 최대 거리 (미터)
+	streetMatchDistance = 20.0
+	// 트랙 구간과 도로의 방향 차이 상한 (도), 교차로에서 가로지르는 도로를 고르지 않도록 함
+	streetMatchAngle = 45.0
+	// 이보다 짧은 구간은 방향 비교를 생략 (GPS 오차로 방향이 불안정)
+	streetMatchMinLength = 5.0
+)
+
+// StreetNames 트랙 구간(지점 i에서 i+1)마다 가장 가까운 도로의 이름
+// 결과의 길이는 len(points)-1이며, 근처에 방향이 맞는 도로가 없거나 이름이 없으면 ""
+// g가 nil이면 nil (도로망을 불러오지 않은 경우)
+func (g *Graph) StreetNames(points []models.GeoPoint) []string {
+	if g == nil || len(points) < 2 {
+		return nil
+	}
+
+	names := make([]string, len(points)-1)
+	for i := range names {
+		a, b := points[i], points[i+1]
+		mid := models.GeoPoint{
+			Latitude:  (a.Latitude + b.Latitude) / 2,
+			Longitude: (a.Longitude + b.Longitude) / 2,
+		}
+		bearing := math.NaN()
+		if analysis.Haversine(a, b) >= streetMatchMinLength {
+			bearing = analysis.Bearing(a, b)
+		}
+		if e, ok := g.nearestEdge(mid, bearing); ok {
+			names[i] = g.names[e.name]
+		}
+	}
+	return names
+}
+
+// 지점에서 streetMatchDistance 안의 가장 가까운 간선
+// bearing이 NaN이 아니면 방향(진행 방향 무관)이 streetMatchAngle 이내인 간선만
+func (g *Graph) nearestEdge(p models.GeoPoint, bearing float64) (*edge, bool) {
+	center := cellKey(p.Latitude, p.Longitude)
+	var best *edge
+	bestDistance := streetMatchDistance
+	for dLat := int32(-1); dLat <= 1; dLat++ {
+		for dLon := int32(-1); dLon <= 1; dLon++ {
+			for _, n := range g.cells[[2]int32{center[0] + dLat, center[1] + dLon}] {
+				from := g.position(n)
+				for k := g.first[n]; k < g.first[n+1]; k++ {
+					e := &g.edges[k]
+					to := g.position(e.to)
+					d := analysis.DistanceToSegment(p, from, to)
+					if d > bestDistance {
+						continue
+					}
+					if !math.IsNaN(bearing) && axisDifference(bearing, analysis.Bearing(from, to)) > streetMatchAngle {
+						continue
+					}
+					best, bestDistance = e, d
+				}
+			}
+		}
+	}
+	return best, best != nil
+}
+
+// 진행 방향과 무관한 두 방위의 차이 (0~90도)
+func axisDifference(a, b float64) float64 {
+	d := math.Mod(math.Abs(a-b), 180)
+	return math.Min(d, 180-d)
+}
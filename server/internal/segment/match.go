@@ -0,0 +1,160 @@
+// Package segment 라이드 트랙에서 구간 통과 기록을 찾음
+//
+// 라이드가 구간 시작 반경(게이트)에 들어온 뒤 종료 게이트에 도달하고,
+// 그 사이 주행 경로가 구간 경로를 따라갔을 때(경로 유사도) 하나의 기록으로 인정
+package segment
+
+import (
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+const (
+	// GateRadius 시작/종료 지점으로 인정하는 반경 (미터)
+	GateRadius = 30.0
+	// CorridorWidth 구간 경로에서 주행 경로까지 허용하는 최대 거리 (미터)
+	CorridorWidth = 50.0
+	// MinCoverage 주행 경로가 CorridorWidth 안에 들어와야 하는 구간 경로 표본의 비율
+	MinCoverage = 0.9
+
+	// 구간 거리 대비 실제 주행 거리의 허용 범위 (게이트 반경은 별도로 더함)
+	minLengthRatio = 0.9
+	maxLengthRatio = 1.25
+	// 경로 유사도를 확인할 구간 경로 표본 간격 (미터)
+	sampleSpacing = 20.0
+)
+
+// Match 라이드에서 찾은 구간 통과 기록
+type Match struct {
+	StartIndex  int           // 라이드 지점 인덱스
+	EndIndex    int           // 라이드 지점 인덱스 (포함)
+	ElapsedTime time.Duration // 구간 통과 시간
+	Distance    float64       // 실제 주행 거리 (미터)
+}
+
+// Find 라이드 트랙 ride에서 구간 path를 통과한 기록을 모두 찾음 (같은 구간을 여러 번 돈 경우 포함)
+// 시작/종료 지점에 시각 정보가 없는 통과는 시간을 잴 수 없으므로 제외
+func Find(path, ride []models.GeoPoint) []Match {
+	if len(path) < 2 || len(ride) < 2 {
+		return nil
+	}
+
+	start, end := path[0], path[len(path)-1]
+	length := analysis.TrackDistance(path)
+	minLength := length*minLengthRatio - GateRadius
+	maxLength := length*maxLengthRatio + 2*GateRadius
+	samples := samplePath(path, sampleSpacing)
+	cum := analysis.CumulativeDistances(ride)
+
+	var matches []Match
+	for i := 0; i < len(ride); {
+		// 시작 게이트 진입: 반경 안에 연속으로 들어온 지점 중 시작점에 가장 가까운 지점
+		if analysis.Haversine(ride[i], start) > GateRadius {
+			i++
+			continue
+		}
+		s, runEnd := closestInGate(ride, i, start)
+
+		m, ok := findEnd(path, ride, cum, samples, s, runEnd, end, minLength, maxLength)
+		if !ok {
+			i = runEnd + 1
+			continue
+		}
+		matches = append(matches, m)
+		i = m.EndIndex + 1
+	}
+	return matches
+}
+
+// 시작 인덱스 s 이후에서 종료 게이트를 찾아 경로 유사도를 확인
+func findEnd(path, ride []models.GeoPoint, cum []float64, samples []models.GeoPoint, s, from int, end models.GeoPoint, minLength, maxLength float64) (Match, bool) {
+	for j := from + 1; j < len(ride); j++ {
+		traveled := cum[j] - cum[s]
+		if traveled > maxLength {
+			return Match{}, false
+		}
+		// 시작과 종료 게이트가 겹치는 순환 구간은 충분히 달린 뒤에만 종료로 인정
+		if traveled < minLength || analysis.Haversine(ride[j], end) > GateRadius {
+			continue
+		}
+
+		e, _ := closestInGate(ride, j, end)
+		a, b := ride[s].Timestamp, ride[e].Timestamp
+		if a == nil || b == nil || !b.After(*a) {
+			return Match{}, false
+		}
+		if coverage(samples, ride[s:e+1]) < MinCoverage {
+			return Match{}, false
+		}
+		return Match{
+			StartIndex:  s,
+			EndIndex:    e,
+			ElapsedTime: b.Sub(*a),
+			Distance:    cum[e] - cum[s],
+		}, true
+	}
+	return Match{}, false
+}
+
+// from부터 gate 반경 안에 연속으로 있는 지점 중 gate에 가장 가까운 지점과 연속 구간의 마지막 인덱스
+func closestInGate(ride []models.GeoPoint, from int, gate models.GeoPoint) (closest, runEnd int) {
+	closest, runEnd = from, from
+	best := analysis.Haversine(ride[from], gate)
+	for k := from + 1; k < len(ride); k++ {
+		d := analysis.Haversine(ride[k], gate)
+		if d > GateRadius {
+			break
+		}
+		runEnd = k
+		if d < best {
+			best, closest = d, k
+		}
+	}
+	return closest, runEnd
+}
+
+// 구간 경로 표본 중 주행 경로로부터 CorridorWidth 안에 있는 비율
+func coverage(samples, track []models.GeoPoint) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	covered := 0
+	for _, p := range samples {
+		if len(track) == 1 {
+			if analysis.Haversine(p, track[0]) <= CorridorWidth {
+				covered++
+			}
+			continue
+		}
+		for k := 1; k < len(track); k++ {
+			if analysis.DistanceToSegment(p, track[k-1], track[k]) <= CorridorWidth {
+				covered++
+				break
+			}
+		}
+	}
+	return float64(covered) / float64(len(samples))
+}
+
+// 경로를 spacing 미터 간격으로 보간한 표본 (처음과 마지막 지점 포함)
+// 지점 간격이 넓은 경로에서도 유사도 검사가 성기지 않도록 함
+func samplePath(path []models.GeoPoint, spacing float64) []models.GeoPoint {
+	samples := []models.GeoPoint{path[0]}
+	since := 0.0 // 마지막 표본 이후 이동 거리
+	for i := 1; i < len(path); i++ {
+		a, b := path[i-1], path[i]
+		d := analysis.Haversine(a, b)
+		pos := spacing - since
+		for ; pos < d; pos += spacing {
+			t := pos / d
+			samples = append(samples, models.GeoPoint{
+				Latitude:  a.Latitude + t*(b.Latitude-a.Latitude),
+				Longitude: a.Longitude + t*(b.Longitude-a.Longitude),
+			})
+		}
+		since = d - (pos - spacing)
+	}
+	return append(samples, path[len(path)-1])
+}
@@ -0,0 +1,218 @@
+package segment
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+var startTime = time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC)
+
+// 기준 위치에서 동쪽 x, 북쪽 y 미터 떨어진 지점
+func xy(x, y float64) models.GeoPoint {
+	const lat, lon = 37.5, 127.0
+	scale := analysis.EarthRadius * math.Pi / 180
+	return models.GeoPoint{
+		Latitude:  lat + y/scale,
+		Longitude: lon + x/(scale*math.Cos(lat*math.Pi/180)),
+	}
+}
+
+// 꼭짓점을 차례로 잇는 경로를 step 미터 간격으로 나눈 지점 (꼭짓점 포함)
+func polyline(step float64, corners ...[2]float64) []models.GeoPoint {
+	points := []models.GeoPoint{xy(corners[0][0], corners[0][1])}
+	for i := 1; i < len(corners); i++ {
+		a, b := corners[i-1], corners[i]
+		d := math.Hypot(b[0]-a[0], b[1]-a[1])
+		n := int(math.Round(d / step))
+		for k := 1; k <= n; k++ {
+			t := float64(k) / float64(n)
+			points = append(points, xy(a[0]+t*(b[0]-a[0]), a[1]+t*(b[1]-a[1])))
+		}
+	}
+	return points
+}
+
+// 10m 간격 지점을 1초마다 지나는 라이드 (10m/s)
+func ride(corners ...[2]float64) []models.GeoPoint {
+	points := polyline(10, corners...)
+	for i := range points {
+		ts := startTime.Add(time.Duration(i) * time.Second)
+		points[i].Timestamp = &ts
+	}
+	return points
+}
+
+// 동쪽으로 1km 곧은 구간
+var straight = polyline(100, [2]float64{0, 0}, [2]float64{1000, 0})
+
+func TestFind(t *testing.T) {
+	// 한 변 250m 정사각형을 도는 순환 구간 (시작 = 종료)
+	loop := polyline(50, [2]float64{0, 0}, [2]float64{250, 0}, [2]float64{250, 250}, [2]float64{0, 250}, [2]float64{0, 0})
+
+	noTime := ride([2]float64{-300, 0}, [2]float64{1300, 0})
+	for i := range noTime {
+		noTime[i].Timestamp = nil
+	}
+	startNoTime := ride([2]float64{-300, 0}, [2]float64{1300, 0})
+	for i := 27; i <= 33; i++ {
+		startNoTime[i].Timestamp = nil
+	}
+
+	type want struct {
+		start, end int
+		elapsed    time.Duration
+	}
+	tests := []struct {
+		name string
+		path []models.GeoPoint
+		ride []models.GeoPoint
+		want []want
+	}{
+		{
+			name: "정상 통과",
+			path: straight,
+			ride: ride([2]float64{-300, 0}, [2]float64{1300, 0}),
+			want: []want{{30, 130, 100 * time.Second}},
+		},
+		{
+			name: "게이트 안에서 가장 가까운 지점 선택",
+			path: straight,
+			ride: ride([2]float64{-300, 20}, [2]float64{1300, 20}),
+			want: []want{{30, 130, 100 * time.Second}},
+		},
+		{
+			name: "반대 방향",
+			path: straight,
+			ride: ride([2]float64{1300, 0}, [2]float64{-300, 0}),
+			want: nil,
+		},
+		{
+			name: "순환 구간 (시작과 종료 게이트가 겹침)",
+			path: loop,
+			ride: ride([2]float64{-100, 0}, [2]float64{250, 0}, [2]float64{250, 250}, [2]float64{0, 250}, [2]float64{0, -100}),
+			want: []want{{10, 110, 100 * time.Second}},
+		},
+		{
+			name: "구간 일부를 벗어난 우회 (경로 유사도 미달)",
+			path: straight,
+			ride: ride([2]float64{-300, 0}, [2]float64{300, 0}, [2]float64{300, 80}, [2]float64{700, 80}, [2]float64{700, 0}, [2]float64{1300, 0}),
+			want: nil,
+		},
+		{
+			name: "너무 긴 우회 (거리 초과)",
+			path: straight,
+			ride: ride([2]float64{-300, 0}, [2]float64{500, 0}, [2]float64{500, 300}, [2]float64{600, 300}, [2]float64{600, 0}, [2]float64{1300, 0}),
+			want: nil,
+		},
+		{
+			name: "한 라이드에서 두 번 통과",
+			path: straight,
+			// 동쪽으로 통과, 서쪽으로 돌아와 시작 게이트를 지나 300m 더 간 뒤 다시 동쪽으로 통과
+			ride: ride([2]float64{-300, 0}, [2]float64{1300, 0}, [2]float64{-300, 0}, [2]float64{1300, 0}),
+			want: []want{{30, 130, 100 * time.Second}, {350, 450, 100 * time.Second}},
+		},
+		{
+			name: "시각 없는 트랙",
+			path: straight,
+			ride: noTime,
+			want: nil,
+		},
+		{
+			name: "시작 게이트 지점에 시각 없음",
+			path: straight,
+			ride: startNoTime,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Find(tt.path, tt.ride)
+			if len(got) != len(tt.want) {
+				t.Fatalf("matches = %+v, want %d", got, len(tt.want))
+			}
+			for i, w := range tt.want {
+				m := got[i]
+				if m.StartIndex != w.start || m.EndIndex != w.end || m.ElapsedTime != w.elapsed {
+					t.Errorf("match %d = %d~%d (%v), want %d~%d (%v)", i, m.StartIndex, m.EndIndex, m.ElapsedTime, w.start, w.end, w.elapsed)
+				}
+				want := analysis.TrackDistance(tt.ride[m.StartIndex : m.EndIndex+1])
+				if math.Abs(m.Distance-want) > 1e-6 {
+					t.Errorf("match %d distance = %.1f, want %.1f", i, m.Distance, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFindShortInput(t *testing.T) {
+	r := ride([2]float64{-300, 0}, [2]float64{1300, 0})
+	if got := Find(straight[:1], r); got != nil {
+		t.Errorf("한 지점 구간: %+v", got)
+	}
+	if got := Find(straight, r[:1]); got != nil {
+		t.Errorf("한 지점 라이드: %+v", got)
+	}
+}
+
+func TestClosestInGate(t *testing.T) {
+	r := ride([2]float64{-100, 5}, [2]float64{100, 5})
+	// 게이트 반경 30m: x = -29 ~ 29 (5m 북쪽) 지점이 반경 안
+	closest, runEnd := closestInGate(r, 8, xy(0, 0))
+	if closest != 10 || runEnd != 12 {
+		t.Errorf("closestInGate = %d, %d, want 10, 12", closest, runEnd)
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	samples := samplePath(straight, sampleSpacing)
+	tests := []struct {
+		name string
+		ride []models.GeoPoint
+		want float64
+	}{
+		{"같은 경로", polyline(10, [2]float64{0, 0}, [2]float64{1000, 0}), 1},
+		{"폭 안에서 나란히", polyline(10, [2]float64{0, 40}, [2]float64{1000, 40}), 1},
+		{"폭 밖에서 나란히", polyline(10, [2]float64{0, 60}, [2]float64{1000, 60}), 0},
+		{"앞 절반만", polyline(10, [2]float64{0, 0}, [2]float64{500, 0}), 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 절반 경계의 표본 수 차이만큼 허용
+			if got := coverage(samples, tt.ride); math.Abs(got-tt.want) > 0.05 {
+				t.Errorf("coverage = %.3f, want %.3f", got, tt.want)
+			}
+		})
+	}
+	if got := coverage(nil, straight); got != 0 {
+		t.Errorf("표본 없음: coverage = %v, want 0", got)
+	}
+}
+
+func TestSamplePath(t *testing.T) {
+	// 간격이 고르지 않은 경로도 spacing 간격으로 나눔
+	path := []models.GeoPoint{xy(0, 0), xy(15, 0), xy(15, 0), xy(300, 0), xy(300, 45)}
+	samples := samplePath(path, 20)
+	if samples[0] != path[0] || samples[len(samples)-1] != path[len(path)-1] {
+		t.Fatal("처음과 마지막 지점을 포함해야 함")
+	}
+	// 345m / 20m = 17.25 → 내부 표본 17개와 양 끝
+	if len(samples) != 19 {
+		t.Errorf("samples = %d, want 19", len(samples))
+	}
+	for i := 1; i < len(samples)-1; i++ {
+		along := float64(i) * 20
+		var want models.GeoPoint
+		if along <= 300 {
+			want = xy(along, 0)
+		} else {
+			want = xy(300, along-300)
+		}
+		if d := analysis.Haversine(samples[i], want); d > 0.01 {
+			t.Errorf("sample %d is %.3fm from %.0fm along the path", i, d, along)
+		}
+	}
+}
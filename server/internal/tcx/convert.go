@@ -0,0 +1,55 @@
+package tcx
+
+import (
+	"fmt"
+
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+// ToRide TCX 활동(Activity)으로부터 라이드 생성
+// 여러 활동/랩은 하나의 트랙으로 이어붙이며, 위치 정보가 없는 트랙포인트는 제외
+// 심박/케이던스/파워/속도는 같은 인덱스의 센서 스트림으로 저장
+// 통계 필드는 analysis.ApplyToRide로 별도 계산해야 함
+func ToRide(db *Database) (*models.Ride, error) {
+	var points []Trackpoint
+	var calories int
+	for _, a := range db.Activities {
+		for _, lap := range a.Laps {
+			calories += lap.Calories
+			for _, p := range lap.Points {
+				if p.Latitude != nil && p.Longitude != nil {
+					points = append(points, p)
+				}
+			}
+		}
+	}
+	if len(points) < 2 {
+		return nil, fmt.Errorf("tcx contains %d positioned trackpoints, at least 2 required", len(points))
+	}
+
+	ride := &models.Ride{
+		StartTime: points[0].Time,
+		EndTime:   points[len(points)-1].Time,
+		Calories:  float64(calories),
+		Locations: make([]models.GeoPoint, len(points)),
+	}
+	streams := models.NewSensorStreams(len(points))
+	for i, p := range points {
+		ts := p.Time
+		ride.Locations[i] = models.GeoPoint{
+			Latitude:  *p.Latitude,
+			Longitude: *p.Longitude,
+			Elevation: p.Altitude,
+			Timestamp: &ts,
+		}
+		streams.HeartRate[i] = p.HeartRate
+		streams.Cadence[i] = p.Cadence
+		streams.Power[i] = p.Power
+		if p.Speed != nil {
+			kmh := *p.Speed * 3.6
+			streams.Speed[i] = &kmh
+		}
+	}
+	ride.Streams = streams.Compact()
+	return ride, nil
+}
@@ -0,0 +1,239 @@
+package tcx
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// XML 매핑용 구조체 (입력)
+// 네임스페이스를 지정하지 않아 접두사와 무관하게 로컬 이름으로 매칭됨
+type inDatabase struct {
+	XMLName    xml.Name     `xml:"TrainingCenterDatabase"`
+	Activities []inActivity `xml:"Activities>Activity"`
+	Courses    []inCourse   `xml:"Courses>Course"`
+}
+
+type inActivity struct {
+	Sport string  `xml:"Sport,attr"`
+	ID    string  `xml:"Id"`
+	Laps  []inLap `xml:"Lap"`
+	Notes string  `xml:"Notes"`
+}
+
+type inLap struct {
+	StartTime        string       `xml:"StartTime,attr"`
+	TotalTimeSeconds string       `xml:"TotalTimeSeconds"`
+	DistanceMeters   string       `xml:"DistanceMeters"`
+	MaximumSpeed     string       `xml:"MaximumSpeed"`
+	Calories         string       `xml:"Calories"`
+	AverageHeartRate string       `xml:"AverageHeartRateBpm>Value"`
+	MaximumHeartRate string       `xml:"MaximumHeartRateBpm>Value"`
+	Cadence          string       `xml:"Cadence"`
+	Tracks           []inTrackSeq `xml:"Track"`
+}
+
+type inCourse struct {
+	Name         string          `xml:"Name"`
+	Notes        string          `xml:"Notes"`
+	Laps         []inLap         `xml:"Lap"`
+	Tracks       []inTrackSeq    `xml:"Track"`
+	CoursePoints []inCoursePoint `xml:"CoursePoint"`
+}
+
+type inTrackSeq struct {
+	Points []inTrackpoint `xml:"Trackpoint"`
+}
+
+type inTrackpoint struct {
+	Time           string     `xml:"Time"`
+	Latitude       string     `xml:"Position>LatitudeDegrees"`
+	Longitude      string     `xml:"Position>LongitudeDegrees"`
+	AltitudeMeters string     `xml:"AltitudeMeters"`
+	DistanceMeters string     `xml:"DistanceMeters"`
+	HeartRate      string     `xml:"HeartRateBpm>Value"`
+	Cadence        string     `xml:"Cadence"`
+	Extensions     *inAnyNode `xml:"Extensions"`
+}
+
+type inCoursePoint struct {
+	Name           string `xml:"Name"`
+	Time           string `xml:"Time"`
+	Latitude       string `xml:"Position>LatitudeDegrees"`
+	Longitude      string `xml:"Position>LongitudeDegrees"`
+	AltitudeMeters string `xml:"AltitudeMeters"`
+	PointType      string `xml:"PointType"`
+	Notes          string `xml:"Notes"`
+}
+
+type inAnyNode struct {
+	XMLName xml.Name
+	Content string      `xml:",chardata"`
+	Nodes   []inAnyNode `xml:",any"`
+}
+
+// Parse TCX 문서 해석
+func Parse(r io.Reader) (*Database, error) {
+	var doc inDatabase
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid tcx: %w", err)
+	}
+
+	db := &Database{}
+	for _, a := range doc.Activities {
+		activity := Activity{Sport: a.Sport, Notes: strings.TrimSpace(a.Notes)}
+		if t, err := parseTime(a.ID); err == nil {
+			activity.ID = t
+		}
+		for _, l := range a.Laps {
+			lap, err := convertLap(l)
+			if err != nil {
+				return nil, err
+			}
+			activity.Laps = append(activity.Laps, lap)
+		}
+		db.Activities = append(db.Activities, activity)
+	}
+
+	for _, c := range doc.Courses {
+		course := Course{Name: strings.TrimSpace(c.Name), Notes: strings.TrimSpace(c.Notes)}
+		for _, l := range c.Laps {
+			course.Distance += parseFloatOr(l.DistanceMeters, 0)
+			course.TotalTime += time.Duration(parseFloatOr(l.TotalTimeSeconds, 0) * float64(time.Second))
+		}
+		for _, trk := range c.Tracks {
+			points, err := convertTrackpoints(trk.Points)
+			if err != nil {
+				return nil, err
+			}
+			course.Points = append(course.Points, points...)
+		}
+		for _, cp := range c.CoursePoints {
+			point := CoursePoint{
+				Name:      strings.TrimSpace(cp.Name),
+				Latitude:  parseFloatOr(cp.Latitude, 0),
+				Longitude: parseFloatOr(cp.Longitude, 0),
+				Altitude:  parseFloat(cp.AltitudeMeters),
+				PointType: strings.TrimSpace(cp.PointType),
+				Notes:     strings.TrimSpace(cp.Notes),
+			}
+			if t, err := parseTime(cp.Time); err == nil {
+				point.Time = t
+			}
+			course.CoursePoints = append(course.CoursePoints, point)
+		}
+		db.Courses = append(db.Courses, course)
+	}
+	return db, nil
+}
+
+func convertLap(l inLap) (Lap, error) {
+	lap := Lap{
+		TotalTime:        time.Duration(parseFloatOr(l.TotalTimeSeconds, 0) * float64(time.Second)),
+		Distance:         parseFloatOr(l.DistanceMeters, 0),
+		MaximumSpeed:     parseFloat(l.MaximumSpeed),
+		Calories:         int(parseFloatOr(l.Calories, 0)),
+		AverageHeartRate: parseInt(l.AverageHeartRate),
+		MaximumHeartRate: parseInt(l.MaximumHeartRate),
+		Cadence:          parseInt(l.Cadence),
+	}
+	if t, err := parseTime(l.StartTime); err == nil {
+		lap.StartTime = t
+	}
+	for _, trk := range l.Tracks {
+		points, err := convertTrackpoints(trk.Points)
+		if err != nil {
+			return Lap{}, err
+		}
+		lap.Points = append(lap.Points, points...)
+	}
+	return lap, nil
+}
+
+func convertTrackpoints(src []inTrackpoint) ([]Trackpoint, error) {
+	points := make([]Trackpoint, 0, len(src))
+	for _, tp := range src {
+		t, err := parseTime(tp.Time)
+		if err != nil {
+			return nil, err
+		}
+		p := Trackpoint{
+			Time:      t,
+			Latitude:  parseFloat(tp.Latitude),
+			Longitude: parseFloat(tp.Longitude),
+			Altitude:  parseFloat(tp.AltitudeMeters),
+			Distance:  parseFloat(tp.DistanceMeters),
+			HeartRate: parseInt(tp.HeartRate),
+			Cadence:   parseInt(tp.Cadence),
+		}
+		if p.Latitude != nil && (*p.Latitude < -90 || *p.Latitude > 90) {
+			return nil, fmt.Errorf("invalid latitude: %v", *p.Latitude)
+		}
+		if p.Longitude != nil && (*p.Longitude < -180 || *p.Longitude > 180) {
+			return nil, fmt.Errorf("invalid longitude: %v", *p.Longitude)
+		}
+		if tp.Extensions != nil {
+			readExtensions(&p, *tp.Extensions)
+		}
+		points = append(points, p)
+	}
+	return points, nil
+}
+
+// ActivityExtension(TPX)의 Speed, Watts 읽기
+func readExtensions(p *Trackpoint, node inAnyNode) {
+	for _, child := range node.Nodes {
+		value := strings.TrimSpace(child.Content)
+		switch child.XMLName.Local {
+		case "Speed":
+			p.Speed = parseFloat(value)
+		case "Watts":
+			p.Power = parseInt(value)
+		case "RunCadence":
+			if p.Cadence == nil {
+				p.Cadence = parseInt(value)
+			}
+		}
+		readExtensions(p, child)
+	}
+}
+
+func parseTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time: %q", value)
+	}
+	return t.UTC(), nil
+}
+
+func parseFloat(value string) *float64 {
+	f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return nil
+	}
+	return &f
+}
+
+func parseFloatOr(value string, fallback float64) float64 {
+	if f := parseFloat(value); f != nil {
+		return *f
+	}
+	return fallback
+}
+
+func parseInt(value string) *int {
+	f := parseFloat(value)
+	if f == nil {
+		return nil
+	}
+	i := int(*f + 0.5)
+	return &i
+}
@@ -0,0 +1,115 @@
+package tcx
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func parseFixture(t *testing.T) *Database {
+	t.Helper()
+	f, err := os.Open("testdata/activity.tcx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	db, err := Parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestParseRejectsInvalidDocument(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+	}{
+		{"not xml", "not a tcx file"},
+		{"bad time", `<TrainingCenterDatabase><Activities><Activity><Lap><Track>
+			<Trackpoint><Time>yesterday</Time></Trackpoint>
+			</Track></Lap></Activity></Activities></TrainingCenterDatabase>`},
+		{"bad latitude", `<TrainingCenterDatabase><Activities><Activity><Lap><Track>
+			<Trackpoint><Time>2024-05-01T06:00:00Z</Time>
+			<Position><LatitudeDegrees>91</LatitudeDegrees><LongitudeDegrees>0</LongitudeDegrees></Position>
+			</Trackpoint></Track></Lap></Activity></Activities></TrainingCenterDatabase>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(tt.doc)); err == nil {
+				t.Fatal("Parse accepted an invalid document")
+			}
+		})
+	}
+}
+
+func TestParseActivity(t *testing.T) {
+	db := parseFixture(t)
+	if len(db.Activities) != 1 || len(db.Activities[0].Laps) != 1 {
+		t.Fatalf("activities/laps = %+v", db.Activities)
+	}
+	lap := db.Activities[0].Laps[0]
+	if lap.Calories != 15 || lap.TotalTime != 2*time.Second {
+		t.Errorf("lap = %+v", lap)
+	}
+	if len(lap.Points) != 4 {
+		t.Fatalf("points = %d, want 4", len(lap.Points))
+	}
+	p := lap.Points[0]
+	if p.Speed == nil || *p.Speed != 10 || p.Power == nil || *p.Power != 200 {
+		t.Errorf("extensions = speed %v, power %v", p.Speed, p.Power)
+	}
+}
+
+func TestToRideSensorStreams(t *testing.T) {
+	ride, err := ToRide(parseFixture(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 위치가 없는 두 번째 트랙포인트는 제외
+	if len(ride.Locations) != 3 {
+		t.Fatalf("locations = %d, want 3", len(ride.Locations))
+	}
+	if ride.Calories != 15 {
+		t.Errorf("calories = %v, want 15", ride.Calories)
+	}
+	start := time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC)
+	if !ride.StartTime.Equal(start) || !ride.EndTime.Equal(start.Add(2*time.Second)) {
+		t.Errorf("time = %v ~ %v", ride.StartTime, ride.EndTime)
+	}
+	if e := ride.Locations[1].Elevation; e == nil || *e != 51 {
+		t.Errorf("elevation = %v, want 51", e)
+	}
+
+	s := ride.Streams
+	if s == nil {
+		t.Fatal("streams = nil")
+	}
+	checkInts := func(name string, got []*int, want []int) {
+		t.Helper()
+		for i, w := range want {
+			if w < 0 {
+				if got[i] != nil {
+					t.Errorf("%s %d = %d, want nil", name, i, *got[i])
+				}
+				continue
+			}
+			if got[i] == nil || *got[i] != w {
+				t.Errorf("%s %d = %v, want %d", name, i, got[i], w)
+			}
+		}
+	}
+	checkInts("heart rate", s.HeartRate, []int{120, 122, -1})
+	checkInts("cadence", s.Cadence, []int{80, 82, 84})
+	checkInts("power", s.Power, []int{200, 220, -1})
+
+	// 속도는 m/s → km/h
+	if got := s.Speed[1]; got == nil || *got < 39.599 || *got > 39.601 {
+		t.Errorf("speed 1 = %v, want 39.6", got)
+	}
+	if s.Temperature != nil {
+		t.Errorf("temperature stream = %v, want nil", s.Temperature)
+	}
+}
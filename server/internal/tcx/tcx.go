@@ -0,0 +1,332 @@
+// tcx 패키지는 Garmin Training Center XML(TCX v2) 파일을 해석하고 생성합니다.
+package tcx
+
+import (
+	"encoding/xml"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	namespaceTCX = "http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2"
+	namespaceAX  = "http://www.garmin.com/xmlschemas/ActivityExtension/v2"
+
+	// TCX 스키마의 Course/CoursePoint Name 최대 길이
+	maxCourseNameLength      = 15
+	maxCoursePointNameLength = 10
+)
+
+// Database <TrainingCenterDatabase> 요소
+type Database struct {
+	Activities []Activity
+	Courses    []Course
+}
+
+// Activity 실제 운동 기록
+type Activity struct {
+	Sport string // Biking, Running, Other
+	ID    time.Time
+	Laps  []Lap
+	Notes string
+}
+
+// Lap 랩 단위 요약과 트랙
+type Lap struct {
+	StartTime        time.Time
+	TotalTime        time.Duration
+	Distance         float64  // 미터
+	MaximumSpeed     *float64 // m/s
+	Calories         int
+	AverageHeartRate *int
+	MaximumHeartRate *int
+	Cadence          *int
+	Points           []Trackpoint
+}
+
+// Course 계획된 코스
+type Course struct {
+	Name         string
+	Notes        string
+	Distance     float64 // 미터
+	TotalTime    time.Duration
+	Points       []Trackpoint
+	CoursePoints []CoursePoint
+}
+
+// Trackpoint <Trackpoint> 요소
+type Trackpoint struct {
+	Time      time.Time
+	Latitude  *float64
+	Longitude *float64
+	Altitude  *float64 // 미터
+	Distance  *float64 // 시작점부터의 누적 거리 (미터)
+	HeartRate *int
+	Cadence   *int
+	Speed     *float64 // m/s (ActivityExtension)
+	Power     *int     // 와트 (ActivityExtension)
+}
+
+// CoursePoint 코스 위의 안내 지점 (회전, 오르막 등)
+type CoursePoint struct {
+	Name      string
+	Time      time.Time
+	Latitude  float64
+	Longitude float64
+	Altitude  *float64
+	PointType string // Generic, Left, Right, Straight, Summit, Valley, Water, Food, Danger, First Aid, ...
+	Notes     string
+}
+
+// 출력용 XML 구조체
+type outDatabase struct {
+	XMLName    xml.Name       `xml:"TrainingCenterDatabase"`
+	Xmlns      string         `xml:"xmlns,attr"`
+	XmlnsAX    string         `xml:"xmlns:ns3,attr"`
+	Activities *outActivities `xml:"Activities,omitempty"`
+	Courses    *outCourses    `xml:"Courses,omitempty"`
+}
+
+type outActivities struct {
+	Activities []outActivity `xml:"Activity"`
+}
+
+type outActivity struct {
+	Sport string   `xml:"Sport,attr"`
+	ID    string   `xml:"Id"`
+	Laps  []outLap `xml:"Lap"`
+	Notes string   `xml:"Notes,omitempty"`
+}
+
+type outLap struct {
+	StartTime        string       `xml:"StartTime,attr"`
+	TotalTimeSeconds string       `xml:"TotalTimeSeconds"`
+	DistanceMeters   string       `xml:"DistanceMeters"`
+	MaximumSpeed     string       `xml:"MaximumSpeed,omitempty"`
+	Calories         int          `xml:"Calories"`
+	AverageHeartRate *outHRValue  `xml:"AverageHeartRateBpm,omitempty"`
+	MaximumHeartRate *outHRValue  `xml:"MaximumHeartRateBpm,omitempty"`
+	Intensity        string       `xml:"Intensity"`
+	Cadence          *int         `xml:"Cadence,omitempty"`
+	TriggerMethod    string       `xml:"TriggerMethod"`
+	Track            *outTrackSeq `xml:"Track,omitempty"`
+}
+
+type outCourses struct {
+	Courses []outCourse `xml:"Course"`
+}
+
+type outCourse struct {
+	Name         string           `xml:"Name"`
+	Lap          outCourseLap     `xml:"Lap"`
+	Track        *outTrackSeq     `xml:"Track,omitempty"`
+	Notes        string           `xml:"Notes,omitempty"`
+	CoursePoints []outCoursePoint `xml:"CoursePoint"`
+}
+
+type outCourseLap struct {
+	TotalTimeSeconds string       `xml:"TotalTimeSeconds"`
+	DistanceMeters   string       `xml:"DistanceMeters"`
+	BeginPosition    *outPosition `xml:"BeginPosition,omitempty"`
+	EndPosition      *outPosition `xml:"EndPosition,omitempty"`
+	Intensity        string       `xml:"Intensity"`
+}
+
+type outTrackSeq struct {
+	Points []outTrackpoint `xml:"Trackpoint"`
+}
+
+type outTrackpoint struct {
+	Time           string           `xml:"Time"`
+	Position       *outPosition     `xml:"Position,omitempty"`
+	AltitudeMeters string           `xml:"AltitudeMeters,omitempty"`
+	DistanceMeters string           `xml:"DistanceMeters,omitempty"`
+	HeartRate      *outHRValue      `xml:"HeartRateBpm,omitempty"`
+	Cadence        *int             `xml:"Cadence,omitempty"`
+	Extensions     *outTPExtensions `xml:"Extensions,omitempty"`
+}
+
+type outPosition struct {
+	Latitude  string `xml:"LatitudeDegrees"`
+	Longitude string `xml:"LongitudeDegrees"`
+}
+
+type outHRValue struct {
+	Value int `xml:"Value"`
+}
+
+type outTPExtensions struct {
+	TPX outTPX `xml:"ns3:TPX"`
+}
+
+type outTPX struct {
+	Speed string `xml:"ns3:Speed,omitempty"`
+	Watts *int   `xml:"ns3:Watts,omitempty"`
+}
+
+type outCoursePoint struct {
+	Name           string      `xml:"Name"`
+	Time           string      `xml:"Time"`
+	Position       outPosition `xml:"Position"`
+	AltitudeMeters string      `xml:"AltitudeMeters,omitempty"`
+	PointType      string      `xml:"PointType"`
+	Notes          string      `xml:"Notes,omitempty"`
+}
+
+// Write TCX v2 문서로 출력
+func Write(w io.Writer, db *Database) error {
+	doc := outDatabase{Xmlns: namespaceTCX, XmlnsAX: namespaceAX}
+
+	if len(db.Activities) > 0 {
+		doc.Activities = &outActivities{}
+		for _, a := range db.Activities {
+			out := outActivity{Sport: a.Sport, ID: formatTime(a.ID), Notes: a.Notes}
+			if out.Sport == "" {
+				out.Sport = "Biking"
+			}
+			for _, lap := range a.Laps {
+				out.Laps = append(out.Laps, toOutLap(lap))
+			}
+			doc.Activities.Activities = append(doc.Activities.Activities, out)
+		}
+	}
+
+	if len(db.Courses) > 0 {
+		doc.Courses = &outCourses{}
+		for _, course := range db.Courses {
+			doc.Courses.Courses = append(doc.Courses.Courses, toOutCourse(course))
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func toOutLap(lap Lap) outLap {
+	out := outLap{
+		StartTime:        formatTime(lap.StartTime),
+		TotalTimeSeconds: formatFloat(lap.TotalTime.Seconds()),
+		DistanceMeters:   formatFloat(lap.Distance),
+		Calories:         lap.Calories,
+		Intensity:        "Active",
+		Cadence:          lap.Cadence,
+		TriggerMethod:    "Manual",
+	}
+	if lap.MaximumSpeed != nil {
+		out.MaximumSpeed = formatFloat(*lap.MaximumSpeed)
+	}
+	if lap.AverageHeartRate != nil {
+		out.AverageHeartRate = &outHRValue{Value: *lap.AverageHeartRate}
+	}
+	if lap.MaximumHeartRate != nil {
+		out.MaximumHeartRate = &outHRValue{Value: *lap.MaximumHeartRate}
+	}
+	if len(lap.Points) > 0 {
+		out.Track = toOutTrack(lap.Points)
+	}
+	return out
+}
+
+func toOutCourse(course Course) outCourse {
+	out := outCourse{
+		Name:  truncateRunes(course.Name, maxCourseNameLength),
+		Notes: course.Notes,
+		Lap: outCourseLap{
+			TotalTimeSeconds: formatFloat(course.TotalTime.Seconds()),
+			DistanceMeters:   formatFloat(course.Distance),
+			Intensity:        "Active",
+		},
+	}
+	if len(course.Points) > 0 {
+		out.Lap.BeginPosition = toOutPosition(course.Points[0])
+		out.Lap.EndPosition = toOutPosition(course.Points[len(course.Points)-1])
+		out.Track = toOutTrack(course.Points)
+	}
+
+	for _, cp := range course.CoursePoints {
+		ocp := outCoursePoint{
+			Name: truncateRunes(cp.Name, maxCoursePointNameLength),
+			Time: formatTime(cp.Time),
+			Position: outPosition{
+				Latitude:  formatFloat(cp.Latitude),
+				Longitude: formatFloat(cp.Longitude),
+			},
+			PointType: cp.PointType,
+			Notes:     cp.Notes,
+		}
+		if ocp.PointType == "" {
+			ocp.PointType = "Generic"
+		}
+		if cp.Altitude != nil {
+			ocp.AltitudeMeters = formatFloat(*cp.Altitude)
+		}
+		out.CoursePoints = append(out.CoursePoints, ocp)
+	}
+	return out
+}
+
+func toOutTrack(points []Trackpoint) *outTrackSeq {
+	track := &outTrackSeq{Points: make([]outTrackpoint, 0, len(points))}
+	for _, p := range points {
+		out := outTrackpoint{
+			Time:     formatTime(p.Time),
+			Position: toOutPosition(p),
+			Cadence:  p.Cadence,
+		}
+		if p.Altitude != nil {
+			out.AltitudeMeters = formatFloat(*p.Altitude)
+		}
+		if p.Distance != nil {
+			out.DistanceMeters = formatFloat(*p.Distance)
+		}
+		if p.HeartRate != nil {
+			out.HeartRate = &outHRValue{Value: *p.HeartRate}
+		}
+		if p.Speed != nil || p.Power != nil {
+			out.Extensions = &outTPExtensions{TPX: outTPX{Watts: p.Power}}
+			if p.Speed != nil {
+				out.Extensions.TPX.Speed = formatFloat(*p.Speed)
+			}
+		}
+		track.Points = append(track.Points, out)
+	}
+	return track
+}
+
+func toOutPosition(p Trackpoint) *outPosition {
+	if p.Latitude == nil || p.Longitude == nil {
+		return nil
+	}
+	return &outPosition{
+		Latitude:  formatFloat(*p.Latitude),
+		Longitude: formatFloat(*p.Longitude),
+	}
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func formatFloat(f float64) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		f = 0
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) > n {
+		r = r[:n]
+	}
+	return string(r)
+}
@@ -0,0 +1,136 @@
+package track
+
+import (
+	"math"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+const (
+	// 첫 지점의 속도 불확실성 (m/s)
+	initialSpeedError = 10.0
+	// 정확도가 이보다 작게 기록돼도 이 값으로 봄 (미터, 과신 방지)
+	minAccuracy = 1.0
+)
+
+// 2x2 행렬
+type mat2 [2][2]float64
+
+func (a mat2) mul(b mat2) mat2 {
+	return mat2{
+		{a[0][0]*b[0][0] + a[0][1]*b[1][0], a[0][0]*b[0][1] + a[0][1]*b[1][1]},
+		{a[1][0]*b[0][0] + a[1][1]*b[1][0], a[1][0]*b[0][1] + a[1][1]*b[1][1]},
+	}
+}
+
+func (a mat2) add(b mat2) mat2 {
+	return mat2{
+		{a[0][0] + b[0][0], a[0][1] + b[0][1]},
+		{a[1][0] + b[1][0], a[1][1] + b[1][1]},
+	}
+}
+
+func (a mat2) transpose() mat2 {
+	return mat2{{a[0][0], a[1][0]}, {a[0][1], a[1][1]}}
+}
+
+func (a mat2) inverse() mat2 {
+	det := a[0][0]*a[1][1] - a[0][1]*a[1][0]
+	if det == 0 {
+		return mat2{}
+	}
+	return mat2{{a[1][1] / det, -a[0][1] / det}, {-a[1][0] / det, a[0][0] / det}}
+}
+
+func (a mat2) apply(v [2]float64) [2]float64 {
+	return [2]float64{a[0][0]*v[0] + a[0][1]*v[1], a[1][0]*v[0] + a[1][1]*v[1]}
+}
+
+// Smooth 등속 운동 모델의 칼만 필터와 RTS 평활기로 위경도를 평활화한 복사본
+// processNoise는 가속도 잡음(m/s²), 측정 오차는 지점의 정확도(없으면 defaultAccuracy) 사용
+// 시각이 없는 트랙은 지점 간격을 1초로 보며, 고도와 시각 등 나머지 필드는 그대로 둠
+func Smooth(points []models.GeoPoint, processNoise, defaultAccuracy float64) []models.GeoPoint {
+	smoothed := make([]models.GeoPoint, len(points))
+	copy(smoothed, points)
+	n := len(points)
+	if n < 3 {
+		return smoothed
+	}
+
+	// 첫 지점을 원점으로 하는 평면 좌표 (미터)
+	origin := points[0]
+	scale := analysis.EarthRadius * math.Pi / 180
+	cosLat := math.Cos(origin.Latitude * math.Pi / 180)
+	zx := make([]float64, n)
+	zy := make([]float64, n)
+	for i, p := range points {
+		zx[i] = (p.Longitude - origin.Longitude) * cosLat * scale
+		zy[i] = (p.Latitude - origin.Latitude) * scale
+	}
+
+	variance := func(p models.GeoPoint) float64 {
+		acc := defaultAccuracy
+		if p.Accuracy != nil {
+			acc = *p.Accuracy
+		}
+		acc = math.Max(acc, minAccuracy)
+		return acc * acc
+	}
+
+	// 두 축은 같은 모델과 측정 오차를 쓰므로 공분산과 이득을 함께 계산
+	var (
+		fx, fy      = make([][2]float64, n), make([][2]float64, n) // 보정한 상태 (위치, 속도)
+		px, py      = make([][2]float64, n), make([][2]float64, n) // 예측한 상태
+		filtered    = make([]mat2, n)
+		predicted   = make([]mat2, n)
+		transitions = make([]mat2, n)
+		q           = processNoise * processNoise
+	)
+	fx[0] = [2]float64{zx[0], 0}
+	fy[0] = [2]float64{zy[0], 0}
+	filtered[0] = mat2{{variance(points[0]), 0}, {0, initialSpeedError * initialSpeedError}}
+
+	for k := 1; k < n; k++ {
+		dt := 1.0
+		if a, b := points[k-1].Timestamp, points[k].Timestamp; a != nil && b != nil {
+			dt = math.Max(b.Sub(*a).Seconds(), 0)
+		}
+		f := mat2{{1, dt}, {0, 1}}
+		noise := mat2{
+			{q * dt * dt * dt / 3, q * dt * dt / 2},
+			{q * dt * dt / 2, q * dt},
+		}
+		transitions[k] = f
+		predicted[k] = f.mul(filtered[k-1]).mul(f.transpose()).add(noise)
+		px[k] = f.apply(fx[k-1])
+		py[k] = f.apply(fy[k-1])
+
+		p := predicted[k]
+		s := p[0][0] + variance(points[k])
+		gain := [2]float64{p[0][0] / s, p[1][0] / s}
+		fx[k] = [2]float64{px[k][0] + gain[0]*(zx[k]-px[k][0]), px[k][1] + gain[1]*(zx[k]-px[k][0])}
+		fy[k] = [2]float64{py[k][0] + gain[0]*(zy[k]-py[k][0]), py[k][1] + gain[1]*(zy[k]-py[k][0])}
+		filtered[k] = mat2{
+			{(1 - gain[0]) * p[0][0], (1 - gain[0]) * p[0][1]},
+			{p[1][0] - gain[1]*p[0][0], p[1][1] - gain[1]*p[0][1]},
+		}
+	}
+
+	// RTS 평활: 뒤 지점의 평활 상태로 앞 지점을 거꾸로 보정
+	sx, sy := fx[n-1], fy[n-1]
+	setPoint := func(i int, x, y float64) {
+		smoothed[i].Latitude = origin.Latitude + y/scale
+		smoothed[i].Longitude = origin.Longitude + x/(cosLat*scale)
+	}
+	setPoint(n-1, sx[0], sy[0])
+	for k := n - 2; k >= 0; k-- {
+		c := filtered[k].mul(transitions[k+1].transpose()).mul(predicted[k+1].inverse())
+		dx := c.apply([2]float64{sx[0] - px[k+1][0], sx[1] - px[k+1][1]})
+		dy := c.apply([2]float64{sy[0] - py[k+1][0], sy[1] - py[k+1][1]})
+		sx = [2]float64{fx[k][0] + dx[0], fx[k][1] + dx[1]}
+		sy = [2]float64{fy[k][0] + dy[0], fy[k][1] + dy[1]}
+		setPoint(k, sx[0], sy[0])
+	}
+	return smoothed
+}
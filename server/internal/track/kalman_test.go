@@ -0,0 +1,67 @@
+package track
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+func TestSmoothReducesNoise(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	truth := straightTrack(120, 8)
+	noisy := make([]models.GeoPoint, len(truth))
+	for i := range truth {
+		noisy[i] = pointAt(float64(i)*8+rng.NormFloat64()*5, rng.NormFloat64()*5, i)
+		elevation := float64(i)
+		noisy[i].Elevation = &elevation
+	}
+	input := append([]models.GeoPoint(nil), noisy...)
+
+	smoothed := Smooth(noisy, 1.5, 8)
+	if !reflect.DeepEqual(noisy, input) {
+		t.Fatal("입력이 바뀜")
+	}
+	if len(smoothed) != len(noisy) {
+		t.Fatalf("points = %d, want %d", len(smoothed), len(noisy))
+	}
+
+	rms := func(points []models.GeoPoint) float64 {
+		var sum float64
+		for i, p := range points {
+			d := analysis.Haversine(p, truth[i])
+			sum += d * d
+		}
+		return math.Sqrt(sum / float64(len(points)))
+	}
+	if before, after := rms(noisy), rms(smoothed); after >= before*0.7 {
+		t.Errorf("RMS error %.2fm -> %.2fm, want at least 30%% lower", before, after)
+	}
+
+	for i := range smoothed {
+		if smoothed[i].Timestamp != noisy[i].Timestamp || smoothed[i].Elevation != noisy[i].Elevation {
+			t.Fatalf("point %d: 위경도 외 필드가 바뀜", i)
+		}
+	}
+}
+
+func TestSmoothKeepsCleanLine(t *testing.T) {
+	// 초기 속도를 0으로 두므로 처음 몇 지점은 조금 당겨짐
+	points := straightTrack(60, 8)
+	smoothed := Smooth(points, 1.5, 8)
+	for i := range points {
+		if d := analysis.Haversine(points[i], smoothed[i]); d > 1 {
+			t.Fatalf("point %d moved %.2fm on a clean constant-speed track", i, d)
+		}
+	}
+}
+
+func TestSmoothShortTrack(t *testing.T) {
+	points := straightTrack(2, 8)
+	if got := Smooth(points, 1.5, 8); !reflect.DeepEqual(got, points) {
+		t.Errorf("3개 미만은 그대로 둬야 함: %v", got)
+	}
+}
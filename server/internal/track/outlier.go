@@ -0,0 +1,60 @@
+package track
+
+import (
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+// 연속으로 이만큼 속도 검사에 걸리면 앞서 남긴 지점이 잘못된 것으로 보고 다시 기준을 잡음
+const maxRejectRun = 5
+
+// Reject 남길 지점의 인덱스 (순서 유지)
+// maxAccuracy(미터)보다 정확도가 나쁜 지점과, 직전에 남긴 지점에서 maxSpeed(m/s)를 넘는 속도로
+// 튀어나간 지점을 제거함. 속도 검사는 두 지점 모두 시각이 있을 때만 하며, 0인 기준은 검사하지 않음
+func Reject(points []models.GeoPoint, maxSpeed, maxAccuracy float64) []int {
+	candidates := make([]int, 0, len(points))
+	for i, p := range points {
+		if maxAccuracy > 0 && p.Accuracy != nil && *p.Accuracy > maxAccuracy {
+			continue
+		}
+		candidates = append(candidates, i)
+	}
+	if maxSpeed <= 0 {
+		return candidates
+	}
+
+	kept := make([]int, 0, len(candidates))
+	run := 0
+	for k, i := range candidates {
+		p := points[i]
+		if len(kept) == 0 {
+			// 첫 지점은 앞 지점이 없으므로, 다음 지점과만 어긋나고 그 다음 두 지점은 서로 맞으면 튄 것으로 봄
+			if k+2 < len(candidates) {
+				next, after := points[candidates[k+1]], points[candidates[k+2]]
+				if tooFast(p, next, maxSpeed) && !tooFast(next, after, maxSpeed) {
+					continue
+				}
+			}
+			kept = append(kept, i)
+			continue
+		}
+
+		if tooFast(points[kept[len(kept)-1]], p, maxSpeed) && run+1 < maxRejectRun {
+			run++
+			continue
+		}
+		run = 0
+		kept = append(kept, i)
+	}
+	return kept
+}
+
+// a에서 b까지 이동 속도가 maxSpeed를 넘는지 (시각을 알 수 없으면 false)
+// 시각이 같거나 거꾸로인 지점은 1초 간격으로 봄
+func tooFast(a, b models.GeoPoint, maxSpeed float64) bool {
+	if a.Timestamp == nil || b.Timestamp == nil {
+		return false
+	}
+	dt := max(b.Timestamp.Sub(*a.Timestamp).Seconds(), 1)
+	return analysis.Haversine(a, b)/dt > maxSpeed
+}
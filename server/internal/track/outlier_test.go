@@ -0,0 +1,127 @@
+package track
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+func without(n int, removed ...int) []int {
+	skip := make(map[int]bool)
+	for _, i := range removed {
+		skip[i] = true
+	}
+	var idx []int
+	for i := 0; i < n; i++ {
+		if !skip[i] {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+func TestReject(t *testing.T) {
+	const maxSpeed = 100 / 3.6
+	acc := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name        string
+		points      func() []models.GeoPoint
+		maxSpeed    float64
+		maxAccuracy float64
+		want        []int
+	}{
+		{
+			name:     "정상 트랙",
+			points:   func() []models.GeoPoint { return straightTrack(20, 8) },
+			maxSpeed: maxSpeed,
+			want:     without(20),
+		},
+		{
+			name: "200km/h로 튄 지점",
+			points: func() []models.GeoPoint {
+				p := straightTrack(20, 8)
+				p[10] = pointAt(80, 60, 10) // 직전 지점에서 약 60m/s
+				return p
+			},
+			maxSpeed: maxSpeed,
+			want:     without(20, 10),
+		},
+		{
+			name: "연속으로 튄 지점",
+			points: func() []models.GeoPoint {
+				p := straightTrack(20, 8)
+				p[10] = pointAt(80, 100, 10)
+				p[11] = pointAt(88, 100, 11)
+				return p
+			},
+			maxSpeed: maxSpeed,
+			want:     without(20, 10, 11),
+		},
+		{
+			name: "첫 지점이 튐",
+			points: func() []models.GeoPoint {
+				p := straightTrack(20, 8)
+				p[0] = pointAt(-500, 0, 0)
+				return p
+			},
+			maxSpeed: maxSpeed,
+			want:     without(20, 0),
+		},
+		{
+			name: "위치가 계속 옮겨지면 기준을 다시 잡음",
+			points: func() []models.GeoPoint {
+				p := straightTrack(20, 8)
+				for i := 10; i < 20; i++ {
+					p[i] = pointAt(float64(i)*8, 1000, i)
+				}
+				return p
+			},
+			maxSpeed: maxSpeed,
+			want:     without(20, 10, 11, 12, 13),
+		},
+		{
+			name: "정확도가 나쁜 지점",
+			points: func() []models.GeoPoint {
+				p := straightTrack(10, 8)
+				p[3].Accuracy = acc(80)
+				p[4].Accuracy = acc(20)
+				return p
+			},
+			maxSpeed:    maxSpeed,
+			maxAccuracy: 50,
+			want:        without(10, 3),
+		},
+		{
+			name: "시각이 없으면 속도 검사 안 함",
+			points: func() []models.GeoPoint {
+				p := make([]models.GeoPoint, 10)
+				for i := range p {
+					p[i] = pointAt(float64(i)*8, 0, -1)
+				}
+				p[5] = pointAt(40, 5000, -1)
+				return p
+			},
+			maxSpeed: maxSpeed,
+			want:     without(10),
+		},
+		{
+			name: "속도 기준 0",
+			points: func() []models.GeoPoint {
+				p := straightTrack(10, 8)
+				p[5] = pointAt(40, 5000, 5)
+				return p
+			},
+			want: without(10),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Reject(tt.points(), tt.maxSpeed, tt.maxAccuracy)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Reject = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
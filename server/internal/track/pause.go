@@ -0,0 +1,44 @@
+package track
+
+import (
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+// DetectPauses radius(미터) 안에 duration 이상 머문 구간
+// 구간 첫 지점을 기준으로 반경을 벗어나지 않은 지점까지를 한 구간으로 보며,
+// 시각이 없는 지점이 있거나 기준이 0이면 nil
+func DetectPauses(points []models.GeoPoint, radius float64, duration time.Duration) []models.Pause {
+	if radius <= 0 || duration <= 0 {
+		return nil
+	}
+	for _, p := range points {
+		if p.Timestamp == nil {
+			return nil
+		}
+	}
+
+	var pauses []models.Pause
+	for i := 0; i < len(points); {
+		j := i
+		for j+1 < len(points) && analysis.Haversine(points[i], points[j+1]) <= radius {
+			j++
+		}
+		start, end := *points[i].Timestamp, *points[j].Timestamp
+		if end.Sub(start) < duration {
+			i++
+			continue
+		}
+		pauses = append(pauses, models.Pause{
+			StartIndex: i,
+			EndIndex:   j,
+			StartTime:  start,
+			EndTime:    end,
+			Duration:   end.Sub(start),
+		})
+		i = j + 1
+	}
+	return pauses
+}
@@ -0,0 +1,72 @@
+package track
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+// 10초 주행, stop초 정지(반경 3m 안에서 흔들림), 10초 주행
+func stopTrack(stop int) []models.GeoPoint {
+	var points []models.GeoPoint
+	sec := 0
+	for ; sec < 10; sec++ {
+		points = append(points, pointAt(float64(sec)*8, 0, sec))
+	}
+	for i := 0; i < stop; i++ {
+		angle := float64(i)
+		points = append(points, pointAt(80+3*math.Sin(angle), 3*math.Cos(angle), sec))
+		sec++
+	}
+	for i := 1; i <= 10; i++ {
+		points = append(points, pointAt(83+float64(i)*8, 0, sec))
+		sec++
+	}
+	return points
+}
+
+func TestDetectPauses(t *testing.T) {
+	points := stopTrack(30)
+	pauses := DetectPauses(points, 15, 20*time.Second)
+	if len(pauses) != 1 {
+		t.Fatalf("pauses = %d, want 1", len(pauses))
+	}
+	p := pauses[0]
+	// 정지 직전 지점(9)부터 반경 15m를 벗어나기 전까지
+	if p.StartIndex > 10 || p.EndIndex < 39 || p.EndIndex > 41 {
+		t.Errorf("pause = %d~%d, want about 9~40", p.StartIndex, p.EndIndex)
+	}
+	if p.Duration < 29*time.Second || p.Duration != p.EndTime.Sub(p.StartTime) {
+		t.Errorf("duration = %v (%v ~ %v), want at least 29s", p.Duration, p.StartTime, p.EndTime)
+	}
+	if !p.StartTime.Equal(*points[p.StartIndex].Timestamp) {
+		t.Errorf("start time = %v, want time of point %d", p.StartTime, p.StartIndex)
+	}
+}
+
+func TestDetectPausesSkips(t *testing.T) {
+	noTime := stopTrack(30)
+	noTime[5].Timestamp = nil
+
+	tests := []struct {
+		name     string
+		points   []models.GeoPoint
+		radius   float64
+		duration time.Duration
+	}{
+		{"짧은 정지", stopTrack(10), 15, 20 * time.Second},
+		{"계속 이동", straightTrack(60, 8), 15, 20 * time.Second},
+		{"시각 없는 지점", noTime, 15, 20 * time.Second},
+		{"반경 0", stopTrack(30), 0, 20 * time.Second},
+		{"시간 0", stopTrack(30), 15, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if pauses := DetectPauses(tt.points, tt.radius, tt.duration); len(pauses) != 0 {
+				t.Errorf("pauses = %+v, want none", pauses)
+			}
+		})
+	}
+}
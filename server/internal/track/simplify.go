@@ -0,0 +1,176 @@
+package track
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+// 첫 지점을 원점으로 하는 평면 좌표 (미터)
+// 트랙 범위 안에서는 등장방형 투영의 오차가 단순화 허용 오차보다 충분히 작음
+func planar(points []models.GeoPoint) [][2]float64 {
+	xy := make([][2]float64, len(points))
+	if len(points) == 0 {
+		return xy
+	}
+	scale := analysis.EarthRadius * math.Pi / 180
+	cosLat := math.Cos(points[0].Latitude * math.Pi / 180)
+	for i, p := range points {
+		xy[i] = [2]float64{
+			(p.Longitude - points[0].Longitude) * cosLat * scale,
+			(p.Latitude - points[0].Latitude) * scale,
+		}
+	}
+	return xy
+}
+
+// DouglasPeucker 남길 지점의 인덱스 (Douglas–Peucker)
+// 처음과 마지막 지점을 잇는 선에서 tolerance(미터)보다 먼 지점이 없을 때까지 가장 먼 지점으로 나눔
+func DouglasPeucker(points []models.GeoPoint, tolerance float64) []int {
+	n := len(points)
+	if n <= 2 {
+		return allIndices(n)
+	}
+
+	xy := planar(points)
+	keep := make([]bool, n)
+	keep[0], keep[n-1] = true, true
+	stack := [][2]int{{0, n - 1}}
+	for len(stack) > 0 {
+		span := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		first, last := span[0], span[1]
+
+		farthest, best := -1, tolerance
+		for i := first + 1; i < last; i++ {
+			if d := segmentDistance(xy[i], xy[first], xy[last]); d > best {
+				farthest, best = i, d
+			}
+		}
+		if farthest < 0 {
+			continue
+		}
+		keep[farthest] = true
+		stack = append(stack, [2]int{first, farthest}, [2]int{farthest, last})
+	}
+	return keptIndices(keep)
+}
+
+// Visvalingam 남길 지점의 인덱스 (Visvalingam–Whyatt)
+// 이웃 두 지점과 이루는 삼각형 넓이가 가장 작은 지점부터 제거하며,
+// 넓이가 tolerance²(제곱미터)보다 작은 지점이 없으면 멈춤
+// 제거로 이웃의 넓이가 작아져도 앞서 제거한 넓이 이상으로 보아 순서를 유지함
+func Visvalingam(points []models.GeoPoint, tolerance float64) []int {
+	n := len(points)
+	if n <= 2 {
+		return allIndices(n)
+	}
+
+	xy := planar(points)
+	prev := make([]int, n)
+	next := make([]int, n)
+	for i := range points {
+		prev[i], next[i] = i-1, i+1
+	}
+	area := func(i int) float64 {
+		a, b, c := xy[prev[i]], xy[i], xy[next[i]]
+		return math.Abs((b[0]-a[0])*(c[1]-a[1])-(c[0]-a[0])*(b[1]-a[1])) / 2
+	}
+
+	h := &areaHeap{index: make([]int, n)}
+	for i := 1; i < n-1; i++ {
+		heap.Push(h, &vertex{point: i, area: area(i)})
+	}
+
+	keep := make([]bool, n)
+	for i := range keep {
+		keep[i] = true
+	}
+	threshold := tolerance * tolerance
+	var removed float64
+	for h.Len() > 0 {
+		v := heap.Pop(h).(*vertex)
+		if v.area >= threshold {
+			break
+		}
+		removed = math.Max(removed, v.area)
+		keep[v.point] = false
+		p, nx := prev[v.point], next[v.point]
+		next[p], prev[nx] = nx, p
+		for _, i := range []int{p, nx} {
+			if i == 0 || i == n-1 {
+				continue
+			}
+			h.update(i, math.Max(area(i), removed))
+		}
+	}
+	return keptIndices(keep)
+}
+
+// 평면 좌표에서 p부터 선분 a-b까지 거리
+func segmentDistance(p, a, b [2]float64) float64 {
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	t := 0.0
+	if l := dx*dx + dy*dy; l > 0 {
+		t = math.Max(0, math.Min(1, ((p[0]-a[0])*dx+(p[1]-a[1])*dy)/l))
+	}
+	return math.Hypot(p[0]-a[0]-t*dx, p[1]-a[1]-t*dy)
+}
+
+func allIndices(n int) []int {
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	return idx
+}
+
+func keptIndices(keep []bool) []int {
+	var idx []int
+	for i, k := range keep {
+		if k {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// Visvalingam 넓이 순 최소 힙
+type vertex struct {
+	point int
+	area  float64
+}
+
+type areaHeap struct {
+	items []*vertex
+	index []int // 지점별 힙 안 위치 (items에 없으면 의미 없음)
+}
+
+func (h *areaHeap) Len() int           { return len(h.items) }
+func (h *areaHeap) Less(i, j int) bool { return h.items[i].area < h.items[j].area }
+
+func (h *areaHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.index[h.items[i].point], h.index[h.items[j].point] = i, j
+}
+
+func (h *areaHeap) Push(x any) {
+	v := x.(*vertex)
+	h.index[v.point] = len(h.items)
+	h.items = append(h.items, v)
+}
+
+func (h *areaHeap) Pop() any {
+	v := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return v
+}
+
+// 힙에 남아 있는 지점의 넓이를 바꿈
+func (h *areaHeap) update(point int, area float64) {
+	pos := h.index[point]
+	h.items[pos].area = area
+	heap.Fix(h, pos)
+}
@@ -0,0 +1,110 @@
+package track
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+// 2km 동안 진폭 40m로 굽이치는 트랙 (5m 간격)
+func windingTrack() []models.GeoPoint {
+	var points []models.GeoPoint
+	for x := 0.0; x <= 2000; x += 5 {
+		points = append(points, pointAt(40*math.Sin(x/150), x, -1))
+	}
+	return points
+}
+
+// 원본 각 지점에서 단순화한 선까지 가장 먼 거리 (미터)
+func maxDeviation(points []models.GeoPoint, idx []int) float64 {
+	xy := planar(points)
+	var worst float64
+	for k := 1; k < len(idx); k++ {
+		a, b := xy[idx[k-1]], xy[idx[k]]
+		for i := idx[k-1] + 1; i < idx[k]; i++ {
+			worst = math.Max(worst, segmentDistance(xy[i], a, b))
+		}
+	}
+	return worst
+}
+
+func TestSimplify(t *testing.T) {
+	methods := []struct {
+		name     string
+		simplify func([]models.GeoPoint, float64) []int
+	}{
+		{"douglas-peucker", DouglasPeucker},
+		{"visvalingam", Visvalingam},
+	}
+	tracks := []struct {
+		name    string
+		points  []models.GeoPoint
+		maxKept int
+	}{
+		{"직선", straightTrack(100, 8), 2},
+		{"굽은 길", windingTrack(), 120},
+	}
+
+	for _, m := range methods {
+		for _, tr := range tracks {
+			for _, tolerance := range []float64{2, 5, 10} {
+				t.Run(fmt.Sprintf("%s/%s/%.0fm", m.name, tr.name, tolerance), func(t *testing.T) {
+					idx := m.simplify(tr.points, tolerance)
+					if len(idx) < 2 || idx[0] != 0 || idx[len(idx)-1] != len(tr.points)-1 {
+						t.Fatalf("처음과 마지막 지점을 남겨야 함: %v", idx)
+					}
+					for k := 1; k < len(idx); k++ {
+						if idx[k] <= idx[k-1] {
+							t.Fatalf("인덱스가 오름차순이 아님: %v", idx)
+						}
+					}
+					if len(idx) > tr.maxKept {
+						t.Errorf("kept = %d, want at most %d", len(idx), tr.maxKept)
+					}
+					if d := maxDeviation(tr.points, idx); d > tolerance {
+						t.Errorf("tolerance %.0fm: max deviation = %.2fm", tolerance, d)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestSimplifyShortTrack(t *testing.T) {
+	for n := 0; n <= 2; n++ {
+		points := straightTrack(n, 8)
+		if got := DouglasPeucker(points, 5); len(got) != n {
+			t.Errorf("DouglasPeucker(%d points) = %v", n, got)
+		}
+		if got := Visvalingam(points, 5); len(got) != n {
+			t.Errorf("Visvalingam(%d points) = %v", n, got)
+		}
+	}
+}
+
+func TestCleanerSimplify(t *testing.T) {
+	points := windingTrack()
+	for _, method := range []string{SimplifyDouglasPeucker, SimplifyVisvalingam} {
+		c, err := New(Options{Simplify: method, SimplifyTolerance: 5})
+		if err != nil {
+			t.Fatal(err)
+		}
+		simplified := c.Simplify(points)
+		if len(simplified) < 2 || len(simplified) >= len(points) {
+			t.Errorf("%s: %d -> %d points", method, len(points), len(simplified))
+		}
+		if simplified[0] != points[0] || simplified[len(simplified)-1] != points[len(points)-1] {
+			t.Errorf("%s: 처음과 마지막 지점이 바뀜", method)
+		}
+	}
+
+	c, err := New(Options{Simplify: SimplifyNone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Simplify(points) != nil {
+		t.Error("none 방식은 nil이어야 함")
+	}
+}
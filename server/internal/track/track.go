@@ -0,0 +1,169 @@
+// track 패키지는 기기가 기록한 GPS 트랙을 정제합니다.
+//
+// 정제는 이상 지점 제거(속도, 위치 정확도), 칼만 평활화, 자동 일시정지 검출 순서로 진행하며
+// 저장과 표시용 단순화(Douglas–Peucker, Visvalingam–Whyatt)를 따로 제공합니다.
+package track
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+// 단순화 방식
+const (
+	SimplifyNone           = "none"
+	SimplifyDouglasPeucker = "douglas-peucker"
+	SimplifyVisvalingam    = "visvalingam"
+)
+
+// Options 정제 단계별 설정 (0이나 false인 단계는 건너뜀)
+type Options struct {
+	MaxSpeed    float64 // 직전 지점에서 이 속도(m/s)를 넘어 이동한 지점은 제거
+	MaxAccuracy float64 // 위치 정확도가 이 값(미터)보다 나쁜 지점은 제거
+
+	Smoothing       bool    // 칼만 평활화 여부
+	ProcessNoise    float64 // 가속도 잡음 (m/s²), 클수록 측정값을 더 따라감
+	DefaultAccuracy float64 // 정확도가 없는 지점의 측정 오차 (미터)
+
+	PauseRadius   float64       // 이 반경(미터) 안에 머문 시간이
+	PauseDuration time.Duration // 이 시간 이상이면 자동 일시정지
+
+	Simplify          string  // 단순화 방식 (Simplify*)
+	SimplifyTolerance float64 // 단순화 허용 오차 (미터)
+}
+
+// DefaultOptions 자전거 주행 기준 기본 설정
+func DefaultOptions() Options {
+	return Options{
+		MaxSpeed:          100 / 3.6,
+		MaxAccuracy:       50,
+		Smoothing:         true,
+		ProcessNoise:      1.5,
+		DefaultAccuracy:   8,
+		PauseRadius:       15,
+		PauseDuration:     20 * time.Second,
+		Simplify:          SimplifyDouglasPeucker,
+		SimplifyTolerance: 5,
+	}
+}
+
+// Cleaner 설정에 따라 트랙을 정제
+// nil Cleaner는 트랙을 그대로 둠 (정제 비활성화)
+type Cleaner struct {
+	opts Options
+}
+
+// New 설정을 검증해 Cleaner 생성
+func New(opts Options) (*Cleaner, error) {
+	switch opts.Simplify {
+	case "":
+		opts.Simplify = SimplifyNone
+	case SimplifyNone, SimplifyDouglasPeucker, SimplifyVisvalingam:
+	default:
+		return nil, fmt.Errorf("unknown simplify method %q", opts.Simplify)
+	}
+	if opts.MaxSpeed < 0 || opts.MaxAccuracy < 0 || opts.ProcessNoise < 0 ||
+		opts.DefaultAccuracy < 0 || opts.PauseRadius < 0 || opts.PauseDuration < 0 || opts.SimplifyTolerance < 0 {
+		return nil, fmt.Errorf("track options must not be negative")
+	}
+	if opts.Smoothing && (opts.ProcessNoise == 0 || opts.DefaultAccuracy == 0) {
+		return nil, fmt.Errorf("smoothing requires positive process noise and default accuracy")
+	}
+	return &Cleaner{opts: opts}, nil
+}
+
+// Options 현재 설정
+func (c *Cleaner) Options() Options {
+	if c == nil {
+		return Options{}
+	}
+	return c.opts
+}
+
+// Result 정제 결과
+type Result struct {
+	Points   []models.GeoPoint
+	Kept     []int // Points 각 지점의 원본 인덱스
+	Rejected int   // 제거한 이상 지점 수
+	Pauses   []models.Pause
+}
+
+// Clean 이상 지점 제거, 평활화, 자동 일시정지 검출을 차례로 적용
+// 입력은 변경하지 않으며, 남은 지점이 2개 미만이 되면 이상 지점을 제거하지 않음
+func (c *Cleaner) Clean(points []models.GeoPoint) Result {
+	kept := make([]int, len(points))
+	for i := range kept {
+		kept[i] = i
+	}
+	if c == nil {
+		return Result{Points: points, Kept: kept}
+	}
+
+	if filtered := Reject(points, c.opts.MaxSpeed, c.opts.MaxAccuracy); len(filtered) >= 2 {
+		kept = filtered
+	}
+	cleaned := make([]models.GeoPoint, len(kept))
+	for k, i := range kept {
+		cleaned[k] = points[i]
+	}
+	if c.opts.Smoothing {
+		cleaned = Smooth(cleaned, c.opts.ProcessNoise, c.opts.DefaultAccuracy)
+	}
+
+	return Result{
+		Points:   cleaned,
+		Kept:     kept,
+		Rejected: len(points) - len(kept),
+		Pauses:   DetectPauses(cleaned, c.opts.PauseRadius, c.opts.PauseDuration),
+	}
+}
+
+// ApplyToRide 라이드 트랙을 정제해 Locations를 바꾸고 입력 트랙은 RawLocations에 보관
+// 센서 스트림은 남은 지점에 맞춰 정렬하며, nil Cleaner는 정제 관련 필드만 비움
+func (c *Cleaner) ApplyToRide(ride *models.Ride) Result {
+	ride.RawLocations = nil
+	ride.Pauses = nil
+	if c == nil || len(ride.Locations) == 0 {
+		return c.Clean(ride.Locations)
+	}
+
+	result := c.Clean(ride.Locations)
+	ride.RawLocations = ride.Locations
+	ride.Locations = result.Points
+	if result.Rejected > 0 {
+		ride.Streams = ride.Streams.Select(result.Kept).Compact()
+	}
+	ride.Pauses = result.Pauses
+	return result
+}
+
+// Simplify 설정한 방식으로 단순화한 트랙 (nil Cleaner이거나 방식이 none이면 nil)
+func (c *Cleaner) Simplify(points []models.GeoPoint) []models.GeoPoint {
+	if c == nil {
+		return nil
+	}
+	var idx []int
+	switch c.opts.Simplify {
+	case SimplifyDouglasPeucker:
+		idx = DouglasPeucker(points, c.opts.SimplifyTolerance)
+	case SimplifyVisvalingam:
+		idx = Visvalingam(points, c.opts.SimplifyTolerance)
+	default:
+		return nil
+	}
+	return Pick(points, idx)
+}
+
+// Pick 인덱스의 지점만 고른 복사본
+func Pick(points []models.GeoPoint, idx []int) []models.GeoPoint {
+	if idx == nil {
+		return nil
+	}
+	picked := make([]models.GeoPoint, len(idx))
+	for k, i := range idx {
+		picked[k] = points[i]
+	}
+	return picked
+}
@@ -0,0 +1,114 @@
+package track
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/models"
+)
+
+// 테스트 트랙 기준 위치와 시각
+const (
+	originLat = 37.5
+	originLon = 127.0
+)
+
+var startTime = time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC)
+
+// 기준 위치에서 북쪽/동쪽으로 떨어진 지점 (미터), sec초에 기록
+// sec가 음수이면 시각 없음
+func pointAt(north, east float64, sec int) models.GeoPoint {
+	scale := analysis.EarthRadius * math.Pi / 180
+	p := models.GeoPoint{
+		Latitude:  originLat + north/scale,
+		Longitude: originLon + east/(scale*math.Cos(originLat*math.Pi/180)),
+	}
+	if sec >= 0 {
+		ts := startTime.Add(time.Duration(sec) * time.Second)
+		p.Timestamp = &ts
+	}
+	return p
+}
+
+// 북쪽으로 speed(m/s)로 1초마다 기록한 n개 지점
+func straightTrack(n int, speed float64) []models.GeoPoint {
+	points := make([]models.GeoPoint, n)
+	for i := range points {
+		points[i] = pointAt(float64(i)*speed, 0, i)
+	}
+	return points
+}
+
+func TestNewRejectsInvalidOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*Options)
+		want   string
+	}{
+		{"simplify", func(o *Options) { o.Simplify = "bezier" }, "unknown simplify method"},
+		{"negative speed", func(o *Options) { o.MaxSpeed = -1 }, "must not be negative"},
+		{"negative pause", func(o *Options) { o.PauseDuration = -time.Second }, "must not be negative"},
+		{"smoothing noise", func(o *Options) { o.ProcessNoise = 0 }, "smoothing requires"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			tt.mutate(&opts)
+			_, err := New(opts)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("New error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	c, err := New(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Options().Simplify != SimplifyNone {
+		t.Errorf("simplify = %q, want %q", c.Options().Simplify, SimplifyNone)
+	}
+}
+
+func TestCleanNilCleaner(t *testing.T) {
+	points := straightTrack(5, 8)
+	var c *Cleaner
+	result := c.Clean(points)
+	if !reflect.DeepEqual(result.Points, points) || !reflect.DeepEqual(result.Kept, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("nil Cleaner는 트랙을 그대로 둬야 함: kept %v", result.Kept)
+	}
+	if c.Simplify(points) != nil {
+		t.Error("nil Cleaner의 Simplify는 nil이어야 함")
+	}
+}
+
+func TestApplyToRide(t *testing.T) {
+	c, err := New(DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	points := straightTrack(30, 8)
+	points[10] = pointAt(80, 60, 10) // 200km/h 이상으로 튄 지점
+	hr := make([]*int, len(points))
+	for i := range hr {
+		v := 100 + i
+		hr[i] = &v
+	}
+	ride := &models.Ride{Locations: points, Streams: &models.SensorStreams{HeartRate: hr}}
+
+	result := c.ApplyToRide(ride)
+	if result.Rejected != 1 {
+		t.Fatalf("rejected = %d, want 1", result.Rejected)
+	}
+	if len(ride.RawLocations) != 30 || len(ride.Locations) != 29 {
+		t.Fatalf("raw/clean = %d/%d, want 30/29", len(ride.RawLocations), len(ride.Locations))
+	}
+	if len(ride.Streams.HeartRate) != 29 || *ride.Streams.HeartRate[10] != 111 {
+		t.Errorf("센서 스트림이 남은 지점에 맞춰지지 않음: %d개, [10]=%d", len(ride.Streams.HeartRate), *ride.Streams.HeartRate[10])
+	}
+}
@@ -0,0 +1,119 @@
+package trackstore
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chrisS41/gobike-server/internal/analysis"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"github.com/chrisS41/gobike-server/internal/track"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 트랙이 아직 라이드 문서에 있는 라이드 (트랙 묶음 저장 도입 전 형식)
+var legacyFilter = bson.M{"locations": bson.M{"$exists": true}}
+
+// 라이드 문서에 들어 있던 트랙 필드 (옮긴 뒤 제거)
+var legacyFields = bson.M{"locations": "", "raw_locations": "", "streams": ""}
+
+// 트랙이 아직 라이드 문서에 있으면 rideID 라이드와 일치하는 조건
+func legacyRide(rideID primitive.ObjectID) bson.M {
+	filter := bson.M{"_id": rideID}
+	for k, v := range legacyFilter {
+		filter[k] = v
+	}
+	return filter
+}
+
+// 라이드 문서에 들어 있던 트랙 필드
+type legacyTrack struct {
+	ID           primitive.ObjectID    `bson:"_id"`
+	Locations    []models.GeoPoint     `bson:"locations"`
+	RawLocations []models.GeoPoint     `bson:"raw_locations"`
+	Streams      *models.SensorStreams `bson:"streams"`
+	Simplified   []models.GeoPoint     `bson:"simplified"`
+	Pauses       []models.Pause        `bson:"pauses"`
+	Thresholds   *models.Thresholds    `bson:"thresholds"`
+	Sensors      *models.SensorSummary `bson:"sensors"`
+}
+
+// Migrate 트랙이 라이드 문서에 들어 있는 라이드를 모두 트랙 묶음으로 옮김
+// 라이드마다 묶음을 저장한 뒤 문서에서 트랙 필드를 제거하므로 중간에 멈춰도 다시 실행하면 이어서 진행됨
+// 끝나기 전에 읽는 라이드는 읽을 때 옮기며, 옮긴 라이드 수를 반환
+// 옮기지 못한 라이드가 있어도 나머지는 계속 옮기고 실패한 라이드의 오류를 모아 반환 (다음 실행 때 다시 시도)
+func (s *Store) Migrate() (int, error) {
+	var ids []primitive.ObjectID
+	err := s.rides.ReadEach(legacyFilter, func(doc bson.Raw) error {
+		var row struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := bson.Unmarshal(doc, &row); err != nil {
+			return err
+		}
+		ids = append(ids, row.ID)
+		return nil
+	}, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return 0, fmt.Errorf("find rides to migrate: %w", err)
+	}
+
+	var errs []error
+	for _, id := range ids {
+		if err := s.migrate(id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return len(ids) - len(errs), fmt.Errorf("migrate %d of %d rides failed: %w", len(errs), len(ids), errors.Join(errs...))
+	}
+	s.migrated.Store(true)
+	return len(ids), nil
+}
+
+// 라이드 문서에 트랙이 남아 있으면 묶음으로 옮기고 문서에서 제거
+// 단순화한 트랙과 센서 요약이 없으면 함께 계산해 문서에 남김
+// 같은 라이드의 Save와 순서대로 처리하고 묶음은 덮어쓰므로 여러 번 동시에 불려도 안전함
+func (s *Store) migrate(rideID primitive.ObjectID) error {
+	mu := s.lock(rideID)
+	mu.Lock()
+	defer mu.Unlock()
+
+	var legacy legacyTrack
+	err := s.rides.ReadOne(legacyRide(rideID), &legacy, options.FindOne().SetProjection(bson.M{
+		"locations": 1, "raw_locations": 1, "streams": 1, "simplified": 1, "pauses": 1, "thresholds": 1, "sensors": 1,
+	}))
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("load legacy track of ride %s: %w", rideID.Hex(), err)
+	}
+
+	ride := &models.Ride{
+		ID:           legacy.ID,
+		Locations:    legacy.Locations,
+		RawLocations: legacy.RawLocations,
+		Streams:      legacy.Streams,
+	}
+	if err := s.save(ride); err != nil {
+		return err
+	}
+
+	set := bson.M{"point_count": len(legacy.Locations)}
+	if len(legacy.Simplified) == 0 && len(legacy.Locations) > 0 {
+		opts := track.DefaultOptions()
+		set["simplified"] = track.Pick(legacy.Locations, track.DouglasPeucker(legacy.Locations, opts.SimplifyTolerance))
+	}
+	if legacy.Sensors == nil {
+		if sensors := analysis.SensorSummary(legacy.Locations, legacy.Streams, legacy.Pauses, legacy.Thresholds); sensors != nil {
+			set["sensors"] = sensors
+		}
+	}
+	if err := s.rides.Update(legacyRide(rideID), bson.M{"$set": set, "$unset": legacyFields}); err != nil {
+		return fmt.Errorf("remove legacy track of ride %s: %w", rideID.Hex(), err)
+	}
+	return nil
+}
@@ -0,0 +1,260 @@
+// trackstore 패키지는 라이드 트랙을 라이드 문서 밖의 ride_tracks 컬렉션에 묶음 단위로 저장합니다.
+//
+// 라이드 문서에는 요약과 단순화한 트랙만 남기고, 전체 해상도의 지점과 센서 스트림은
+// ChunkSize개씩 열 단위 배열(models.TrackChunk)로 나누어 저장하므로 긴 라이드도
+// MongoDB 문서 크기 제한(16MB)에 걸리지 않고, 목록 조회 시 트랙을 읽지 않습니다.
+package trackstore
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/chrisS41/gobike-server/internal/database"
+	"github.com/chrisS41/gobike-server/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ChunkSize 묶음 하나의 지점 수 (1Hz 기록 약 1시간, 묶음 하나가 약 0.5MB)
+const ChunkSize = 3600
+
+// 라이드별 저장/옮기기를 순서대로 처리하기 위한 잠금 수
+const lockStripes = 64
+
+// Store 라이드 트랙 저장소
+type Store struct {
+	chunks *database.Collection
+	rides  *database.Collection // 트랙이 아직 라이드 문서에 있으면 옮기기 위해 사용
+
+	migrated atomic.Bool // Migrate가 끝나 라이드 문서에 트랙이 남아 있지 않음
+	locks    [lockStripes]sync.Mutex
+}
+
+func New(chunks, rides *database.Collection) *Store {
+	return &Store{chunks: chunks, rides: rides}
+}
+
+// 라이드 ID에 해당하는 잠금 (ObjectID 끝 바이트는 증가 카운터라 고르게 나뉨)
+func (s *Store) lock(rideID primitive.ObjectID) *sync.Mutex {
+	return &s.locks[int(rideID[len(rideID)-1])%lockStripes]
+}
+
+// Save 라이드의 정제한 트랙(센서 스트림 포함)과 입력 트랙을 저장 (기존 트랙은 교체)
+// 묶음을 (ride_id, kind, seq) 단위로 덮어쓴 뒤 남는 뒤쪽 묶음만 지우므로 저장 도중 실패해도
+// 기존 트랙이 사라지지 않으며, 라이드 문서에 남은 이전 형식 트랙은 제거해 나중에 옮기면서
+// 새 트랙을 덮어쓰지 않도록 함
+func (s *Store) Save(ride *models.Ride) error {
+	mu := s.lock(ride.ID)
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := s.save(ride); err != nil {
+		return err
+	}
+	if err := s.rides.Update(legacyRide(ride.ID), bson.M{"$unset": legacyFields}); err != nil {
+		return fmt.Errorf("remove legacy track of ride %s: %w", ride.ID.Hex(), err)
+	}
+	return nil
+}
+
+// 트랙 묶음 저장 (잠금은 호출하는 쪽에서 잡음)
+func (s *Store) save(ride *models.Ride) error {
+	tracks := []struct {
+		kind string
+		docs []interface{}
+	}{
+		{models.TrackKindClean, toChunks(ride.ID, models.TrackKindClean, ride.Locations, ride.Streams)},
+		{models.TrackKindRaw, toChunks(ride.ID, models.TrackKindRaw, ride.RawLocations, nil)},
+	}
+	for _, t := range tracks {
+		filters := make([]interface{}, len(t.docs))
+		for seq := range t.docs {
+			filters[seq] = bson.M{"ride_id": ride.ID, "kind": t.kind, "seq": seq}
+		}
+		if err := s.chunks.UpsertMany(filters, t.docs); err != nil {
+			return fmt.Errorf("save track of ride %s: %w", ride.ID.Hex(), err)
+		}
+		stale := bson.M{"ride_id": ride.ID, "kind": t.kind, "seq": bson.M{"$gte": len(t.docs)}}
+		if _, err := s.chunks.DeleteMany(stale); err != nil {
+			return fmt.Errorf("save track of ride %s: %w", ride.ID.Hex(), err)
+		}
+	}
+	return nil
+}
+
+// Delete 라이드의 트랙 묶음을 모두 삭제
+func (s *Store) Delete(rideID primitive.ObjectID) error {
+	mu := s.lock(rideID)
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, err := s.chunks.DeleteMany(bson.M{"ride_id": rideID}); err != nil {
+		return fmt.Errorf("delete track of ride %s: %w", rideID.Hex(), err)
+	}
+	return nil
+}
+
+// Each 라이드 트랙의 묶음을 순서대로 하나씩 읽어 fn 호출 (전체 트랙을 메모리에 올리지 않음)
+// 트랙이 아직 라이드 문서에 있으면 먼저 옮기며, fn이 오류를 반환하면 중단하고 그 오류를 반환
+func (s *Store) Each(rideID primitive.ObjectID, kind string, fn func(chunk *models.TrackChunk) error) error {
+	if !s.migrated.Load() {
+		if err := s.migrate(rideID); err != nil {
+			return err
+		}
+	}
+	return s.chunks.ReadEach(
+		bson.M{"ride_id": rideID, "kind": kind},
+		func(doc bson.Raw) error {
+			var chunk models.TrackChunk
+			if err := bson.Unmarshal(doc, &chunk); err != nil {
+				return fmt.Errorf("decode track chunk of ride %s: %w", rideID.Hex(), err)
+			}
+			return fn(&chunk)
+		},
+		options.Find().SetSort(bson.D{{Key: "seq", Value: 1}}),
+	)
+}
+
+// Load 라이드의 정제한 트랙과 센서 스트림을 ride.Locations, ride.Streams에 채움
+// withRaw이면 입력 트랙(ride.RawLocations)도 채움
+func (s *Store) Load(ride *models.Ride, withRaw bool) error {
+	points := []models.GeoPoint{}
+	streams := &models.SensorStreams{}
+	err := s.Each(ride.ID, models.TrackKindClean, func(chunk *models.TrackChunk) error {
+		points = appendChunk(points, streams, chunk)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	ride.Locations = points
+	ride.Streams = streams.Compact()
+
+	ride.RawLocations = nil
+	if withRaw {
+		err = s.Each(ride.ID, models.TrackKindRaw, func(chunk *models.TrackChunk) error {
+			ride.RawLocations = appendChunk(ride.RawLocations, nil, chunk)
+			return nil
+		})
+	}
+	return err
+}
+
+// 트랙을 ChunkSize개씩 나눈 묶음 문서
+// streams가 nil이면 센서 열은 저장하지 않음
+func toChunks(rideID primitive.ObjectID, kind string, points []models.GeoPoint, streams *models.SensorStreams) []interface{} {
+	var docs []interface{}
+	for start := 0; start < len(points); start += ChunkSize {
+		end := min(start+ChunkSize, len(points))
+		part := points[start:end]
+		chunk := models.TrackChunk{
+			RideID:    rideID,
+			Kind:      kind,
+			Seq:       len(docs),
+			Start:     start,
+			Count:     len(part),
+			StartTime: part[0].Timestamp,
+			EndTime:   part[len(part)-1].Timestamp,
+			Latitude:  make([]float64, len(part)),
+			Longitude: make([]float64, len(part)),
+			Elevation: make([]*float64, len(part)),
+			Time:      make([]*time.Time, len(part)),
+			Accuracy:  make([]*float64, len(part)),
+		}
+		for i, p := range part {
+			chunk.Latitude[i] = p.Latitude
+			chunk.Longitude[i] = p.Longitude
+			chunk.Elevation[i] = p.Elevation
+			chunk.Time[i] = p.Timestamp
+			chunk.Accuracy[i] = p.Accuracy
+		}
+		chunk.Elevation = column(chunk.Elevation)
+		chunk.Time = column(chunk.Time)
+		chunk.Accuracy = column(chunk.Accuracy)
+		if streams != nil {
+			chunk.HeartRate = column(window(streams.HeartRate, start, end))
+			chunk.Cadence = column(window(streams.Cadence, start, end))
+			chunk.Power = column(window(streams.Power, start, end))
+			chunk.Speed = column(window(streams.Speed, start, end))
+			chunk.Temperature = column(window(streams.Temperature, start, end))
+			chunk.Balance = column(window(streams.Balance, start, end))
+		}
+		docs = append(docs, chunk)
+	}
+	return docs
+}
+
+// 묶음의 지점을 points 뒤에 붙이고, streams가 있으면 센서 값도 같은 인덱스에 붙임
+func appendChunk(points []models.GeoPoint, streams *models.SensorStreams, chunk *models.TrackChunk) []models.GeoPoint {
+	offset := len(points)
+	for i := 0; i < chunk.Count && i < len(chunk.Latitude) && i < len(chunk.Longitude); i++ {
+		points = append(points, models.GeoPoint{
+			Latitude:  chunk.Latitude[i],
+			Longitude: chunk.Longitude[i],
+			Elevation: at(chunk.Elevation, i),
+			Timestamp: at(chunk.Time, i),
+			Accuracy:  at(chunk.Accuracy, i),
+		})
+	}
+	if streams != nil {
+		n := len(points)
+		streams.HeartRate = extend(streams.HeartRate, chunk.HeartRate, offset, n)
+		streams.Cadence = extend(streams.Cadence, chunk.Cadence, offset, n)
+		streams.Power = extend(streams.Power, chunk.Power, offset, n)
+		streams.Speed = extend(streams.Speed, chunk.Speed, offset, n)
+		streams.Temperature = extend(streams.Temperature, chunk.Temperature, offset, n)
+		streams.Balance = extend(streams.Balance, chunk.Balance, offset, n)
+	}
+	return points
+}
+
+// 값이 하나도 없으면 nil (저장하지 않을 열)
+func column[T any](values []*T) []*T {
+	for _, v := range values {
+		if v != nil {
+			return values
+		}
+	}
+	return nil
+}
+
+// values[start:end] (values가 짧으면 빈 값)
+func window[T any](values []*T, start, end int) []*T {
+	if values == nil {
+		return nil
+	}
+	part := make([]*T, end-start)
+	for i := range part {
+		if start+i < len(values) {
+			part[i] = values[start+i]
+		}
+	}
+	return part
+}
+
+func at[T any](values []*T, i int) *T {
+	if i < len(values) {
+		return values[i]
+	}
+	return nil
+}
+
+// 스트림 series를 길이 n으로 늘리고 offset부터 묶음의 값을 채움
+// 묶음에 열이 없고 앞선 값도 없으면 nil 유지
+func extend[T any](series, values []*T, offset, n int) []*T {
+	if series == nil && values == nil {
+		return nil
+	}
+	for len(series) < n {
+		series = append(series, nil)
+	}
+	for i, v := range values {
+		if offset+i < n {
+			series[offset+i] = v
+		}
+	}
+	return series
+}
@@ -0,0 +1,371 @@
+// websocket 패키지는 RFC 6455 WebSocket의 서버 측 연결을 구현합니다.
+// 텍스트/바이너리 메시지, 조각난(fragmented) 메시지, ping/pong, close 핸드셰이크를 지원하며
+// 확장(permessage-deflate 등)과 서브프로토콜 협상은 지원하지 않습니다.
+package websocket
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// 프레임 opcode
+const (
+	OpContinuation = 0x0
+	OpText         = 0x1
+	OpBinary       = 0x2
+	OpClose        = 0x8
+	OpPing         = 0x9
+	OpPong         = 0xA
+)
+
+// close 상태 코드
+const (
+	CloseNormal          = 1000
+	CloseGoingAway       = 1001
+	CloseProtocolError   = 1002
+	CloseUnsupportedData = 1003
+	CloseNoStatus        = 1005
+	CloseInvalidPayload  = 1007
+	ClosePolicyViolation = 1008
+	CloseMessageTooBig   = 1009
+	CloseInternalError   = 1011
+)
+
+const (
+	// Sec-WebSocket-Accept 계산에 사용하는 고정 GUID
+	acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+	finBit  = 0x80
+	rsvBits = 0x70
+	maskBit = 0x80
+
+	maxControlPayload = 125
+
+	// DefaultMaxMessageSize 수신 메시지 최대 크기 (조각 합계)
+	DefaultMaxMessageSize = 1 << 20
+	// DefaultWriteTimeout 프레임 하나를 쓰는 데 허용하는 시간
+	DefaultWriteTimeout = 10 * time.Second
+)
+
+// ErrClosed 이미 닫힌 연결에 쓰기를 시도한 경우
+var ErrClosed = errors.New("websocket: connection closed")
+
+// CloseError 상대방이 close 프레임을 보내 연결이 종료된 경우
+type CloseError struct {
+	Code   int
+	Reason string
+}
+
+func (e *CloseError) Error() string {
+	return fmt.Sprintf("websocket: closed by peer (%d %s)", e.Code, e.Reason)
+}
+
+// Conn 업그레이드된 WebSocket 연결
+// 읽기는 하나의 고루틴에서만 호출해야 하며, 쓰기는 여러 고루틴에서 호출해도 안전함
+type Conn struct {
+	conn net.Conn
+	br   *bufio.Reader
+
+	// MaxMessageSize 수신 메시지 최대 크기 (초과 시 1009로 종료)
+	MaxMessageSize int64
+	// ReadTimeout 0보다 크면 프레임을 받을 때마다 읽기 기한을 연장 (pong 포함)
+	ReadTimeout time.Duration
+	// WriteTimeout 프레임 쓰기 기한
+	WriteTimeout time.Duration
+
+	writeMu   sync.Mutex
+	closeSent bool
+}
+
+// IsUpgradeRequest WebSocket 업그레이드 요청인지 확인
+func IsUpgradeRequest(r *http.Request) bool {
+	return headerContainsToken(r.Header, "Connection", "upgrade") &&
+		headerContainsToken(r.Header, "Upgrade", "websocket")
+}
+
+// Upgrade HTTP 요청을 WebSocket 연결로 전환
+// 핸드셰이크가 올바르지 않으면 에러 응답을 보내고 에러를 반환
+// 성공한 이후에는 w에 응답을 쓰면 안 됨
+func Upgrade(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return nil, errors.New("websocket: upgrade requires GET")
+	}
+	if !IsUpgradeRequest(r) {
+		http.Error(w, "websocket upgrade required", http.StatusBadRequest)
+		return nil, errors.New("websocket: missing upgrade headers")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported websocket version", http.StatusUpgradeRequired)
+		return nil, errors.New("websocket: unsupported version")
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		http.Error(w, "invalid Sec-WebSocket-Key", http.StatusBadRequest)
+		return nil, errors.New("websocket: invalid key")
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket not supported", http.StatusInternalServerError)
+		return nil, errors.New("websocket: response writer does not support hijacking")
+	}
+	netConn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, fmt.Errorf("websocket: hijack failed: %w", err)
+	}
+	// 핸드셰이크 응답 전에 클라이언트가 데이터를 보내는 것은 허용되지 않음
+	if rw.Reader.Buffered() > 0 {
+		netConn.Close()
+		return nil, errors.New("websocket: client sent data before handshake completed")
+	}
+
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n\r\n"
+	netConn.SetWriteDeadline(time.Now().Add(DefaultWriteTimeout))
+	if _, err := netConn.Write([]byte(response)); err != nil {
+		netConn.Close()
+		return nil, fmt.Errorf("websocket: handshake write failed: %w", err)
+	}
+	netConn.SetDeadline(time.Time{})
+
+	return &Conn{
+		conn:           netConn,
+		br:             rw.Reader,
+		MaxMessageSize: DefaultMaxMessageSize,
+		WriteTimeout:   DefaultWriteTimeout,
+	}, nil
+}
+
+// ReadMessage 다음 데이터 메시지(텍스트/바이너리)를 읽음
+// ping에는 자동으로 pong을 응답하고, close 프레임을 받으면 응답 후 *CloseError 반환
+func (c *Conn) ReadMessage() (opcode int, data []byte, err error) {
+	var message []byte
+	messageOp := -1
+
+	for {
+		if c.ReadTimeout > 0 {
+			c.conn.SetReadDeadline(time.Now().Add(c.ReadTimeout))
+		}
+
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch op {
+		case OpPing:
+			if err := c.writeFrame(OpPong, payload); err != nil {
+				return 0, nil, err
+			}
+			continue
+		case OpPong:
+			continue
+		case OpClose:
+			code, reason := CloseNoStatus, ""
+			if len(payload) >= 2 {
+				code = int(binary.BigEndian.Uint16(payload))
+				reason = string(payload[2:])
+			}
+			c.Close(CloseNormal, "")
+			return 0, nil, &CloseError{Code: code, Reason: reason}
+		case OpText, OpBinary:
+			if messageOp != -1 {
+				return 0, nil, c.fail(CloseProtocolError, "new message before previous message finished")
+			}
+			messageOp = op
+		case OpContinuation:
+			if messageOp == -1 {
+				return 0, nil, c.fail(CloseProtocolError, "unexpected continuation frame")
+			}
+		default:
+			return 0, nil, c.fail(CloseProtocolError, fmt.Sprintf("unknown opcode %d", op))
+		}
+
+		if int64(len(message)+len(payload)) > c.MaxMessageSize {
+			return 0, nil, c.fail(CloseMessageTooBig, "message too big")
+		}
+		message = append(message, payload...)
+
+		if fin {
+			if messageOp == OpText && !utf8.Valid(message) {
+				return 0, nil, c.fail(CloseInvalidPayload, "invalid utf-8 text")
+			}
+			return messageOp, message, nil
+		}
+	}
+}
+
+// 프레임 하나를 읽고 마스크를 해제
+func (c *Conn) readFrame() (fin bool, opcode int, payload []byte, err error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.br, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	fin = header[0]&finBit != 0
+	opcode = int(header[0] & 0x0F)
+	if header[0]&rsvBits != 0 {
+		return false, 0, nil, c.fail(CloseProtocolError, "reserved bits set")
+	}
+	// 클라이언트가 보내는 프레임은 반드시 마스킹되어야 함
+	if header[1]&maskBit == 0 {
+		return false, 0, nil, c.fail(CloseProtocolError, "unmasked client frame")
+	}
+
+	length := int64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = int64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = int64(binary.BigEndian.Uint64(ext[:]))
+		if length < 0 {
+			return false, 0, nil, c.fail(CloseProtocolError, "invalid payload length")
+		}
+	}
+
+	if opcode >= OpClose {
+		if !fin || length > maxControlPayload {
+			return false, 0, nil, c.fail(CloseProtocolError, "invalid control frame")
+		}
+	} else if length > c.MaxMessageSize {
+		return false, 0, nil, c.fail(CloseMessageTooBig, "message too big")
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.br, mask[:]); err != nil {
+		return false, 0, nil, err
+	}
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return fin, opcode, payload, nil
+}
+
+// WriteMessage 데이터 메시지를 하나의 프레임으로 전송
+func (c *Conn) WriteMessage(opcode int, data []byte) error {
+	if opcode != OpText && opcode != OpBinary {
+		return fmt.Errorf("websocket: invalid data opcode %d", opcode)
+	}
+	return c.writeFrame(opcode, data)
+}
+
+// WriteJSON 값을 JSON 텍스트 메시지로 전송
+func (c *Conn) WriteJSON(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.writeFrame(OpText, data)
+}
+
+// Ping ping 프레임 전송 (연결 유지 확인용)
+func (c *Conn) Ping() error {
+	return c.writeFrame(OpPing, nil)
+}
+
+// Close close 프레임을 보내고 연결을 닫음 (여러 번 호출해도 안전)
+func (c *Conn) Close(code int, reason string) error {
+	payload := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	if len(reason) > maxControlPayload-2 {
+		reason = reason[:maxControlPayload-2]
+	}
+	payload = append(payload, reason...)
+
+	err := c.writeFrame(OpClose, payload)
+	c.conn.Close()
+	if err == ErrClosed {
+		return nil
+	}
+	return err
+}
+
+// RemoteAddr 클라이언트 주소
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+func (c *Conn) writeFrame(opcode int, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if c.closeSent {
+		return ErrClosed
+	}
+	if opcode == OpClose {
+		c.closeSent = true
+	}
+
+	// 서버가 보내는 프레임은 마스킹하지 않음
+	frame := make([]byte, 0, 10+len(payload))
+	frame = append(frame, finBit|byte(opcode))
+	switch n := len(payload); {
+	case n <= 125:
+		frame = append(frame, byte(n))
+	case n <= 0xFFFF:
+		frame = append(frame, 126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(n))
+	default:
+		frame = append(frame, 127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(n))
+	}
+	frame = append(frame, payload...)
+
+	if c.WriteTimeout > 0 {
+		c.conn.SetWriteDeadline(time.Now().Add(c.WriteTimeout))
+	}
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// 프로토콜 위반 시 close 프레임을 보내고 연결을 닫음
+func (c *Conn) fail(code int, reason string) error {
+	c.Close(code, reason)
+	return &CloseError{Code: code, Reason: reason}
+}
+
+func acceptKey(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// 쉼표로 구분된 헤더 값에 token이 있는지 확인 (대소문자 무시)
+func headerContainsToken(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}